@@ -0,0 +1,37 @@
+package dto
+
+// CategoriesResponse описывает ответ со списком рубрик.
+type CategoriesResponse struct {
+	Categories []CategoryCount `json:"categories"`
+}
+
+// CategoryCount описывает рубрику и количество новостей в ней.
+type CategoryCount struct {
+	Category string `json:"category" example:"go"`
+	Count    int64  `json:"count" example:"42"`
+}
+
+// TrainCategoriesRequest описывает ручную разметку рубрик.
+type TrainCategoriesRequest struct {
+	Labels []CategoryLabel `json:"labels"`
+}
+
+// CategoryLabel описывает рубрики новости, проставленные вручную. Пустой список снимает рубрики.
+type CategoryLabel struct {
+	PostID     int32    `json:"post_id" example:"42"`
+	Categories []string `json:"categories" example:"go,databases"`
+}
+
+// TrainCategoriesResponse описывает результат обучения рубрикатора.
+type TrainCategoriesResponse struct {
+	Labeled    int             `json:"labeled" example:"1"`
+	Examples   int             `json:"examples" example:"120"`
+	Categories []CategoryModel `json:"categories"`
+}
+
+// CategoryModel описывает рубрику модели: количество примеров и назначает ли ее модель.
+type CategoryModel struct {
+	Category string `json:"category" example:"go"`
+	Examples int    `json:"examples" example:"35"`
+	Active   bool   `json:"active" example:"true"`
+}
@@ -0,0 +1,110 @@
+package dto
+
+// Feed описывает структуру источника новостей.
+type Feed struct {
+	ID           int32  `json:"id" example:"1"`
+	URL          string `json:"url" example:"https://habr.com/ru/rss/best/daily/?fl=ru"`
+	Title        string `json:"title" example:"Хабр"`
+	Enabled      bool   `json:"enabled" example:"true"`
+	PollInterval int    `json:"poll_interval" example:"5"`
+	FetchFull    bool   `json:"fetch_full" example:"false"`
+	LastStatus   string `json:"last_status,omitempty" example:"ok"`
+	LastError    string `json:"last_error,omitempty" example:""`
+	// LastWarnings - предупреждения последнего опроса, например записи, пропущенные из-за неразборчивой даты.
+	LastWarnings  []string `json:"last_warnings,omitempty"`
+	LastFetchedAt string   `json:"last_fetched_at,omitempty" example:"2025-06-26T10:00:43Z"`
+	ItemCount     int64    `json:"item_count" example:"42"`
+}
+
+// FeedRequest представляет тело запроса для добавления или изменения источника.
+type FeedRequest struct {
+	URL          string `json:"url" example:"https://habr.com/ru/rss/best/daily/?fl=ru"`
+	Title        string `json:"title" example:"Хабр"`
+	Enabled      bool   `json:"enabled" example:"true"`
+	PollInterval int    `json:"poll_interval" example:"5"`
+	// FetchFull - загружать полный текст статей по ссылкам записей ленты.
+	FetchFull bool `json:"fetch_full" example:"false"`
+}
+
+// FeedSchedule описывает состояние расписания источника.
+type FeedSchedule struct {
+	FeedID         int32  `json:"feed_id" example:"1"`
+	URL            string `json:"url" example:"https://habr.com/ru/rss/best/daily/?fl=ru"`
+	IntervalSec    int64  `json:"interval_sec" example:"300"`
+	NextRunAt      string `json:"next_run_at,omitempty" example:"2025-06-26T10:05:12Z"`
+	Running        bool   `json:"running" example:"false"`
+	Failures       int    `json:"failures" example:"0"`
+	LastError      string `json:"last_error,omitempty" example:""`
+	LastRunAt      string `json:"last_run_at,omitempty" example:"2025-06-26T10:00:43Z"`
+	LastDurationMs int64  `json:"last_duration_ms" example:"420"`
+}
+
+// FeedsSchedule описывает состояние планировщика опроса источников.
+type FeedsSchedule struct {
+	Workers int            `json:"workers" example:"4"`
+	Running int            `json:"running" example:"1"`
+	Feeds   []FeedSchedule `json:"feeds"`
+}
+
+// FeedRun описывает запись журнала опросов источника.
+type FeedRun struct {
+	StartedAt  string `json:"started_at" example:"2025-06-26T10:00:43Z"`
+	FinishedAt string `json:"finished_at" example:"2025-06-26T10:00:44Z"`
+	DurationMs int64  `json:"duration_ms" example:"420"`
+	Status     string `json:"status" example:"ok"`
+	HTTPStatus int    `json:"http_status,omitempty" example:"200"`
+	Seen       int    `json:"seen" example:"20"`
+	Inserted   int    `json:"inserted" example:"3"`
+	Skipped    int    `json:"skipped" example:"17"`
+	Error      string `json:"error,omitempty" example:""`
+}
+
+// FeedRuns описывает журнал опросов источника.
+type FeedRuns struct {
+	Runs []FeedRun `json:"runs"`
+}
+
+// FeedHealth описывает состояние источника в сводке здоровья.
+type FeedHealth struct {
+	ID            int32  `json:"id" example:"1"`
+	URL           string `json:"url" example:"https://habr.com/ru/rss/best/daily/?fl=ru"`
+	Title         string `json:"title" example:"Хабр"`
+	Health        string `json:"health" example:"healthy"`
+	LastStatus    string `json:"last_status,omitempty" example:"ok"`
+	LastError     string `json:"last_error,omitempty" example:""`
+	LastFetchedAt string `json:"last_fetched_at,omitempty" example:"2025-06-26T10:00:43Z"`
+	LastSuccessAt string `json:"last_success_at,omitempty" example:"2025-06-26T10:00:43Z"`
+	Runs          int64  `json:"runs" example:"288"`
+	Failed        int64  `json:"failed" example:"0"`
+}
+
+// FeedsHealth описывает сводку здоровья источников.
+type FeedsHealth struct {
+	Hours    int          `json:"hours" example:"24"`
+	Healthy  int          `json:"healthy" example:"5"`
+	Degraded int          `json:"degraded" example:"1"`
+	Failing  int          `json:"failing" example:"0"`
+	Feeds    []FeedHealth `json:"feeds"`
+}
+
+// FeedRefreshResult описывает результат ручного опроса источника.
+type FeedRefreshResult struct {
+	Seen           int      `json:"seen" example:"20"`
+	Inserted       int      `json:"inserted" example:"3"`
+	Skipped        int      `json:"skipped" example:"17"`
+	NotModified    bool     `json:"not_modified" example:"false"`
+	ArticlesFailed int      `json:"articles_failed" example:"0"`
+	Warnings       []string `json:"warnings,omitempty"`
+}
+
+// FeedRefresh описывает ответ на ручной опрос источника.
+type FeedRefresh struct {
+	FeedID int32             `json:"feed_id" example:"1"`
+	Shared bool              `json:"shared" example:"false"`
+	Result FeedRefreshResult `json:"result"`
+}
+
+// FeedsRefresh описывает ответ на опрос всех источников.
+type FeedsRefresh struct {
+	Queued []int32 `json:"queued"`
+}
@@ -12,11 +12,61 @@ type PostWithComments struct {
 	Comments []Comment `json:"comments"`
 }
 
+// NewsPage описывает страницу списка новостей.
+type NewsPage struct {
+	News []Post `json:"news"`
+	// Total возвращается только при постраничной пагинации (без cursor).
+	Total      *int32 `json:"total,omitempty" example:"120"`
+	NextCursor string `json:"next_cursor,omitempty" example:"bjoxNzM5Mjk2ODAwOjQy"`
+	PrevCursor string `json:"prev_cursor,omitempty" example:"cDoxNzM5Mjk3NDAwOjQ3"`
+}
+
 // Post описывает структуру новости.
 type Post struct {
-	ID      int    `json:"id"`
-	Title   string `json:"title"`
-	Content string `json:"content"`
-	Link    string `json:"link"`
-	PubTime string `json:"pub_time"`
+	ID      int     `json:"id"`
+	Title   string  `json:"title"`
+	Content string  `json:"content"`
+	Link    string  `json:"link"`
+	PubTime string  `json:"pub_time"`
+	Source  *Source `json:"source,omitempty"`
+	// Image - главное изображение статьи, Body - полный текст (только для одной новости).
+	Image string `json:"image,omitempty" example:"https://habr.com/images/lead.png"`
+	Body  string `json:"body,omitempty"`
+	// Media - медиа-вложения: файлы, видео, миниатюры и изображения из описания.
+	Media []Media `json:"media,omitempty"`
+	// Tags - нормализованные теги (категории) новости.
+	Tags []string `json:"tags,omitempty" example:"go,релизы"`
+	// Categories - тематические рубрики, назначенные рубрикатором или вручную.
+	Categories []string `json:"categories,omitempty" example:"go,devops"`
+	// CommentsCount - количество опубликованных комментариев.
+	CommentsCount int `json:"comments_count" example:"3"`
+	// ClusterID - ID первой новости кластера почти одинаковых новостей из разных источников,
+	// ClusterSize - количество новостей кластера в выдаче (только с collapse=true).
+	ClusterID   int32       `json:"cluster_id,omitempty" example:"42"`
+	ClusterSize int         `json:"cluster_size,omitempty" example:"3"`
+	Highlights  *Highlights `json:"highlights,omitempty"`
+}
+
+// Highlights описывает фрагменты новости с подсветкой совпадений с поисковым запросом.
+type Highlights struct {
+	Title   string `json:"title,omitempty" example:"Вышел <mark>Go</mark> 1.24"`
+	Content string `json:"content,omitempty" example:"…релиз <mark>Go</mark> 1.24 уже доступен…"`
+}
+
+// Media описывает медиа-вложение новости. Size задается в байтах, Width и Height - в пикселях,
+// отсутствующие значения неизвестны. Thumbnail отмечает миниатюры для карточки новости.
+type Media struct {
+	URL       string `json:"url" example:"https://cdn.example.com/42.mp4"`
+	Type      string `json:"type,omitempty" example:"video/mp4"`
+	Size      int64  `json:"size,omitempty" example:"987654"`
+	Width     int    `json:"width,omitempty" example:"1280"`
+	Height    int    `json:"height,omitempty" example:"720"`
+	Thumbnail bool   `json:"thumbnail,omitempty" example:"false"`
+}
+
+// Source описывает источник новости.
+type Source struct {
+	FeedURL  string `json:"feed_url" example:"https://habr.com/ru/rss/best/daily/?fl=ru"`
+	Title    string `json:"title" example:"Хабр"`
+	SiteLink string `json:"site_link" example:"https://habr.com/ru/"`
 }
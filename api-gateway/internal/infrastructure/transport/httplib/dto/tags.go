@@ -0,0 +1,12 @@
+package dto
+
+// TagsResponse описывает ответ со списком тегов.
+type TagsResponse struct {
+	Tags []TagCount `json:"tags"`
+}
+
+// TagCount описывает тег и количество новостей с ним.
+type TagCount struct {
+	Tag   string `json:"tag" example:"go"`
+	Count int64  `json:"count" example:"42"`
+}
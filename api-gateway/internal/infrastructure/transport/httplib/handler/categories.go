@@ -0,0 +1,39 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// FindAllCategories получает рубрики новостей.
+// @Summary Получить рубрики
+// @Description Возвращает рубрики новостей с количеством новостей, по убыванию количества.
+// @Tags news
+// @Produce json
+// @Success 200 {object} dto.CategoriesResponse
+// @Router /api/categories [get]
+func (h *Handler) FindAllCategories(c *fiber.Ctx) error {
+	return h.handleServiceRequest(
+		c, ServiceRequest{
+			RouteName: NewsRouteName,
+			Path:      "/categories",
+		},
+	)
+}
+
+// TrainCategories сохраняет рубрики, проставленные вручную, и переобучает рубрикатор.
+// @Summary Разметить рубрики и обучить рубрикатор
+// @Description Заменяет рубрики указанных новостей рубриками, проставленными вручную, и обучает модель рубрикатора на всех размеченных новостях. Без labels модель только переобучается. При невалидной разметке не сохраняется ни одна.
+// @Tags news
+// @Accept json
+// @Param request body dto.TrainCategoriesRequest true "Ручная разметка рубрик"
+// @Produce json
+// @Success 200 {object} dto.TrainCategoriesResponse
+// @Router /api/categories/train [post]
+func (h *Handler) TrainCategories(c *fiber.Ctx) error {
+	return h.handleServiceRequest(
+		c, ServiceRequest{
+			RouteName: NewsRouteName,
+			Path:      "/categories/train",
+		},
+	)
+}
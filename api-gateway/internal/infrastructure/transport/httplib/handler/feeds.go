@@ -0,0 +1,177 @@
+package handler
+
+import (
+	"fmt"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// FindAllFeeds получает все источники новостей.
+// @Summary Получить все источники
+// @Description Возвращает список источников новостей со статусом последнего опроса.
+// @Tags feeds
+// @Produce json
+// @Success 200 {array} dto.Feed
+// @Router /api/feeds [get]
+func (h *Handler) FindAllFeeds(c *fiber.Ctx) error {
+	return h.handleServiceRequest(
+		c, ServiceRequest{
+			RouteName: NewsRouteName,
+			Path:      "/feeds",
+		},
+	)
+}
+
+// FeedsSchedule получает состояние планировщика опроса источников.
+// @Summary Получить состояние планировщика
+// @Description Возвращает время следующего опроса, количество ошибок подряд и последнюю ошибку по каждому источнику.
+// @Tags feeds
+// @Produce json
+// @Success 200 {object} dto.FeedsSchedule
+// @Router /api/feeds/schedule [get]
+func (h *Handler) FeedsSchedule(c *fiber.Ctx) error {
+	return h.handleServiceRequest(
+		c, ServiceRequest{
+			RouteName: NewsRouteName,
+			Path:      "/feeds/schedule",
+		},
+	)
+}
+
+// FeedsHealth получает сводку здоровья источников.
+// @Summary Получить здоровье источников
+// @Description Возвращает состояние каждого источника (healthy, degraded, failing, unknown, disabled) и количество опросов и ошибок за период.
+// @Tags feeds
+// @Param hours query int false "Период журнала опросов в часах (по умолчанию 24, максимум 720)"
+// @Produce json
+// @Success 200 {object} dto.FeedsHealth
+// @Router /api/feeds/health [get]
+func (h *Handler) FeedsHealth(c *fiber.Ctx) error {
+	return h.handleServiceRequest(
+		c, ServiceRequest{
+			RouteName: NewsRouteName,
+			Path:      "/feeds/health",
+		},
+	)
+}
+
+// FeedRuns получает журнал опросов источника.
+// @Summary Получить журнал опросов источника
+// @Description Возвращает последние опросы источника, новые первыми: время, HTTP статус, количество записей и ошибку.
+// @Tags feeds
+// @Param id path string true "ID источника"
+// @Param limit query int false "Количество записей (по умолчанию 20, максимум 100)"
+// @Produce json
+// @Success 200 {object} dto.FeedRuns
+// @Router /api/feeds/{id}/runs [get]
+func (h *Handler) FeedRuns(c *fiber.Ctx) error {
+	return h.handleServiceRequest(
+		c, ServiceRequest{
+			RouteName: NewsRouteName,
+			Path:      fmt.Sprintf("/feeds/%s/runs", c.Params("id")),
+		},
+	)
+}
+
+// RefreshFeed немедленно опрашивает источник.
+// @Summary Опросить источник сейчас
+// @Description Опрашивает источник вне расписания и возвращает результат опроса. Если источник уже опрашивается, возвращается результат текущего опроса (shared = true).
+// @Tags feeds
+// @Param id path string true "ID источника"
+// @Produce json
+// @Success 200 {object} dto.FeedRefresh
+// @Router /api/feeds/{id}/refresh [post]
+func (h *Handler) RefreshFeed(c *fiber.Ctx) error {
+	return h.handleServiceRequest(
+		c, ServiceRequest{
+			RouteName: NewsRouteName,
+			Path:      fmt.Sprintf("/feeds/%s/refresh", c.Params("id")),
+		},
+	)
+}
+
+// RefreshAllFeeds ставит в очередь опрос всех включенных источников.
+// @Summary Опросить все источники сейчас
+// @Description Ставит в очередь планировщика опрос всех включенных источников, кроме уже опрашиваемых, и возвращает их ID.
+// @Tags feeds
+// @Produce json
+// @Success 202 {object} dto.FeedsRefresh
+// @Router /api/feeds/refresh [post]
+func (h *Handler) RefreshAllFeeds(c *fiber.Ctx) error {
+	return h.handleServiceRequest(
+		c, ServiceRequest{
+			RouteName: NewsRouteName,
+			Path:      "/feeds/refresh",
+		},
+	)
+}
+
+// FindByIDFeed получает источник по ID.
+// @Summary Получить источник по ID
+// @Description Возвращает источник новостей по ID.
+// @Tags feeds
+// @Param id path string true "ID источника"
+// @Produce json
+// @Success 200 {object} dto.Feed
+// @Router /api/feeds/{id} [get]
+func (h *Handler) FindByIDFeed(c *fiber.Ctx) error {
+	return h.handleServiceRequest(
+		c, ServiceRequest{
+			RouteName: NewsRouteName,
+			Path:      fmt.Sprintf("/feeds/%s", c.Params("id")),
+		},
+	)
+}
+
+// CreateFeed добавляет источник.
+// @Summary Добавить источник
+// @Description Добавляет новый источник новостей. Интервал опроса задается в минутах, 0 - по умолчанию.
+// @Tags feeds
+// @Accept json
+// @Produce json
+// @Param request body dto.FeedRequest true "Данные источника"
+// @Success 201 {object} dto.Feed
+// @Router /api/feeds [post]
+func (h *Handler) CreateFeed(c *fiber.Ctx) error {
+	return h.handleServiceRequest(
+		c, ServiceRequest{
+			RouteName: NewsRouteName,
+			Path:      "/feeds",
+		},
+	)
+}
+
+// UpdateFeed изменяет источник.
+// @Summary Изменить источник
+// @Description Изменяет адрес, название, интервал опроса или включает/выключает источник.
+// @Tags feeds
+// @Accept json
+// @Produce json
+// @Param id path string true "ID источника"
+// @Param request body dto.FeedRequest true "Данные источника"
+// @Success 200 {object} dto.Feed
+// @Router /api/feeds/{id} [put]
+func (h *Handler) UpdateFeed(c *fiber.Ctx) error {
+	return h.handleServiceRequest(
+		c, ServiceRequest{
+			RouteName: NewsRouteName,
+			Path:      fmt.Sprintf("/feeds/%s", c.Params("id")),
+		},
+	)
+}
+
+// DeleteFeed удаляет источник.
+// @Summary Удалить источник
+// @Description Удаляет источник. Ранее сохраненные новости источника остаются.
+// @Tags feeds
+// @Param id path string true "ID источника"
+// @Success 204
+// @Router /api/feeds/{id} [delete]
+func (h *Handler) DeleteFeed(c *fiber.Ctx) error {
+	return h.handleServiceRequest(
+		c, ServiceRequest{
+			RouteName: NewsRouteName,
+			Path:      fmt.Sprintf("/feeds/%s", c.Params("id")),
+		},
+	)
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"github.com/ee-crocush/go-news/api-gateway/internal/infrastructure/registry"
+	"github.com/ee-crocush/go-news/pkg/logger"
 	"github.com/gofiber/fiber/v2"
 	"io"
 	"net/http"
@@ -113,7 +114,7 @@ func (h *Handler) createProxyRequest(c *fiber.Ctx, url string) (*http.Request, e
 	}
 
 	// Прокидываем request ID, если есть
-	if requestID, ok := c.Locals("request_id").(string); ok && requestID != "" {
+	if requestID, ok := c.Locals(logger.RequestIDKey).(string); ok && requestID != "" {
 		req.Header.Set("X-Request-ID", requestID)
 	}
 
@@ -9,13 +9,24 @@ import (
 
 // FindAllNews получает все новости.
 // @Summary Получить все новости
-// @Description Возвращает список всех новостей.
+// @Description Возвращает список всех новостей. С параметром cursor используется keyset-пагинация:
+// @Description page игнорируется, total не возвращается, соседние страницы - по next_cursor/prev_cursor.
 // @Tags news
 // @Param page path int false "Страница" default(1)
-// @Param search path string false "Поиск заголовка" default("")
+// @Param search path string false "Полнотекстовый поиск по заголовку и содержанию" default("")
 // @Param limit path int false "Страница" default(10)
+// @Param source query string false "Адрес ленты или ссылка на сайт источника"
+// @Param from query string false "Дата публикации не раньше: RFC3339 или YYYY-MM-DD"
+// @Param to query string false "Дата публикации не позже: RFC3339 или YYYY-MM-DD (включая весь день)"
+// @Param has_comments query bool false "Только новости с комментариями (true) или без них (false)"
+// @Param tag query string false "Тег новости (регистр и ведущий # не учитываются)"
+// @Param category query string false "Рубрика новости (регистр не учитывается)"
+// @Param sort query string false "Сортировка: relevance (по умолчанию при поиске) или date" Enums(relevance, date)
+// @Param highlight query bool false "Добавить фрагменты с подсветкой совпадений" default(false)
+// @Param cursor query string false "Токен next_cursor или prev_cursor из предыдущего ответа"
+// @Param collapse query bool false "Показать одну новость из каждого кластера почти одинаковых новостей" default(false)
 // @Produce json
-// @Success 200 {array} dto.Post
+// @Success 200 {object} dto.NewsPage
 // @Router /api/news [get]
 func (h *Handler) FindAllNews(c *fiber.Ctx) error {
 	return h.handleServiceRequest(
@@ -64,9 +75,11 @@ func (h *Handler) FindLatestNews(c *fiber.Ctx) error {
 
 // FindByIDNews получает новость по ID.
 // @Summary Получить новость по ID
-// @Description Возвращает новость по ID.
+// @Description Возвращает новость по ID. С format=html содержание возвращается очищенным HTML
+// @Description (абзацы, ссылки, код, изображения), по умолчанию - простым текстом.
 // @Tags news
 // @Param id path string true "ID новости"
+// @Param format query string false "Формат содержания" Enums(text, html) default(text)
 // @Produce json
 // @Success 200 {object} dto.PostWithComments
 // @Router /api/news/{id} [get]
@@ -0,0 +1,22 @@
+package handler
+
+import (
+	"github.com/gofiber/fiber/v2"
+)
+
+// FindAllTags получает самые частые теги новостей.
+// @Summary Получить теги
+// @Description Возвращает самые частые теги новостей с количеством новостей, по убыванию количества.
+// @Tags news
+// @Param limit query int false "Количество тегов (максимум 500)" default(50)
+// @Produce json
+// @Success 200 {object} dto.TagsResponse
+// @Router /api/tags [get]
+func (h *Handler) FindAllTags(c *fiber.Ctx) error {
+	return h.handleServiceRequest(
+		c, ServiceRequest{
+			RouteName: NewsRouteName,
+			Path:      "/tags",
+		},
+	)
+}
@@ -41,6 +41,10 @@ func SetupRoutes(app *fiber.App, handlers *Handlers) {
 	api := app.Group("/api")
 	setupNewsRoutes(api, handlers.NewsComments)
 	setupCommentsRoutes(api, handlers.NewsComments)
+	setupFeedsRoutes(api, handlers.NewsComments)
+	api.Get("/tags", handlers.NewsComments.FindAllTags)
+	api.Get("/categories", handlers.NewsComments.FindAllCategories)
+	api.Post("/categories/train", handlers.NewsComments.TrainCategories)
 
 	app.Use(
 		func(c *fiber.Ctx) error {
@@ -74,3 +78,20 @@ func setupCommentsRoutes(api fiber.Router, h *handler.Handler) {
 		commentsGroup.Post("/", h.CreateComments)
 	}
 }
+
+// setupFeedsRoutes настраивает маршруты для источников новостей.
+func setupFeedsRoutes(api fiber.Router, h *handler.Handler) {
+	feedsGroup := api.Group("/feeds")
+	{
+		feedsGroup.Get("/", h.FindAllFeeds)
+		feedsGroup.Post("/", h.CreateFeed)
+		feedsGroup.Get("/schedule", h.FeedsSchedule)
+		feedsGroup.Get("/health", h.FeedsHealth)
+		feedsGroup.Get("/:id", h.FindByIDFeed)
+		feedsGroup.Get("/:id/runs", h.FeedRuns)
+		feedsGroup.Post("/refresh", h.RefreshAllFeeds)
+		feedsGroup.Post("/:id/refresh", h.RefreshFeed)
+		feedsGroup.Put("/:id", h.UpdateFeed)
+		feedsGroup.Delete("/:id", h.DeleteFeed)
+	}
+}
@@ -21,8 +21,13 @@ function create_topic() {
   echo ${DELIMITER}
 }
 
-# Создаём два топика
+# Создаём топики
 create_topic "comments.created"
 create_topic "comments.moderated"
+create_topic "comments.published"
+create_topic "news.published"
+create_topic "comments.created.dlq"
+create_topic "comments.moderated.dlq"
+create_topic "comments.published.dlq"
 
 echo -e "${GREEN}Все топики созданы успешно.${NC}"
\ No newline at end of file
@@ -4,24 +4,45 @@ package app
 import (
 	"fmt"
 	"github.com/ee-crocush/go-news/go-comments/internal/infrastructure/config"
+	"github.com/ee-crocush/go-news/go-comments/internal/infrastructure/events"
 	repo "github.com/ee-crocush/go-news/go-comments/internal/infrastructure/repo/postgres"
 	"github.com/ee-crocush/go-news/go-comments/internal/infrastructure/transport/httplib"
 	"github.com/ee-crocush/go-news/go-comments/internal/infrastructure/transport/httplib/handler"
 	uc "github.com/ee-crocush/go-news/go-comments/internal/usecase/comment"
 	"github.com/ee-crocush/go-news/pkg/kafka"
+	"github.com/ee-crocush/go-news/pkg/outbox"
 	"github.com/ee-crocush/go-news/pkg/server"
 	commonFiber "github.com/ee-crocush/go-news/pkg/server/fiber"
 	"github.com/gofiber/fiber/v2"
+	"github.com/jackc/pgx/v4/pgxpool"
 )
 
 // Run запускает HTTP сервер и инициализирует все необходимые компоненты.
 func Run(cfg *config.Config) error {
-	repository, err := connectDB(cfg)
+	pgxPool, err := connectDB(cfg)
 	if err != nil {
 		return fmt.Errorf("failed to connectDB: %w", err)
 	}
 
-	commentHandler, err := initHandler(cfg, repository)
+	repository := repo.NewCommentRepository(pgxPool)
+
+	createdRelay, createdPublisher, err := initOutbox(
+		cfg, pgxPool, "comment_created", events.CommentCreatedType,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to init outbox: %w", err)
+	}
+	defer createdPublisher.Close()
+
+	publishedRelay, publishedPublisher, err := initOutbox(
+		cfg, pgxPool, "comment_published", events.CommentPublishedType,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to init outbox: %w", err)
+	}
+	defer publishedPublisher.Close()
+
+	commentHandler, err := initHandler(repository, createdRelay)
 	if err != nil {
 		return fmt.Errorf("failed to init handler: %w", err)
 	}
@@ -33,53 +54,123 @@ func Run(cfg *config.Config) error {
 		},
 	)
 
-	consumer, err := initConsumer(cfg, repository)
+	consumer, dlq, err := initConsumer(cfg, repository, publishedRelay)
+	if err != nil {
+		return fmt.Errorf("failed to init consumer: %w", err)
+	}
+	if dlq != nil {
+		defer dlq.Close()
+	}
+
 	// Запускаем сервер
-	serverManager := server.NewServerManager(fiberServer)
+	serverManager := server.NewServerManager(fiberServer, createdRelay, publishedRelay)
 	return serverManager.StartAll(consumer)
 }
 
 // connectDB выполняет подключение к БД.
-func connectDB(cfg *config.Config) (*repo.CommentRepository, error) {
+func connectDB(cfg *config.Config) (*pgxpool.Pool, error) {
 	pgxPool, err := repo.Init(cfg)
 	if err != nil {
 		return nil, fmt.Errorf("failed to connect to postgres: %w", err)
 	}
 
-	repository := repo.NewCommentRepository(pgxPool)
-
 	fmt.Printf(
 		"PostgreSQL connected successfully! host=%s, port=%d, database=%s\n", cfg.DB.Host, cfg.DB.Port,
 		cfg.DB.Name,
 	)
 
-	return repository, nil
+	return pgxPool, nil
 }
 
-// initHandler создает хендлеры.
-func initHandler(cfg *config.Config, repository *repo.CommentRepository) (*handler.Handler, error) {
-	// Создаем топик, куда будем отправлять события о создании нового комментария, подлежащего модерации
-	topic, err := cfg.GetTopic("comment_created")
+// initOutbox создает relay, отправляющий события типа eventType из outbox в топик topicName:
+// события о создании комментариев для модерации и о публикации комментариев для счетчиков
+// комментариев новостей. Publisher нужно закрыть после остановки relay.
+func initOutbox(cfg *config.Config, pgxPool *pgxpool.Pool, topicName, eventType string) (
+	*outbox.Relay, *kafka.Publisher, error,
+) {
+	topic, err := cfg.GetTopic(topicName)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get topic: %w", err)
+		return nil, nil, fmt.Errorf("failed to get topic: %w", err)
 	}
 
-	commentPublisher := kafka.NewPublisher(cfg.Kafka.Brokers, topic)
-	commentCreateUC := uc.NewCreateUseCase(repository, commentPublisher)
+	// Отправка синхронная: relay отмечает событие отправленным только после подтверждения записи
+	publisher := newPublisher(cfg, topic, kafka.WithEventType(eventType, events.SchemaVersion))
+	relay := outbox.NewRelay(
+		repo.NewOutboxRepository(pgxPool, eventType), publisher, outbox.Config{
+			Interval:   cfg.Outbox.GetInterval(),
+			BatchSize:  cfg.Outbox.GetBatchSize(),
+			MaxBackoff: cfg.Outbox.GetMaxBackoff(),
+			Retention:  cfg.Outbox.GetRetention(),
+		},
+	)
+
+	return relay, publisher, nil
+}
+
+// initHandler создает хендлеры.
+func initHandler(repository *repo.CommentRepository, relay *outbox.Relay) (*handler.Handler, error) {
+	commentCreateUC := uc.NewCreateUseCase(repository, relay)
 	commentFindAllUC := uc.NewFindAllByNewsIDUseCase(repository)
 
 	return handler.NewHandler(commentCreateUC, commentFindAllUC), nil
 }
 
 // initConsumer создает consumer кафки для получения результатов модерации.
-func initConsumer(cfg *config.Config, repository *repo.CommentRepository) (*kafka.Consumer, error) {
+// События о публикации комментариев отправляет publishedRelay.
+func initConsumer(cfg *config.Config, repository *repo.CommentRepository, publishedRelay *outbox.Relay) (
+	*kafka.Consumer, *kafka.Publisher, error,
+) {
 	topic, err := cfg.GetTopic("comment_moderated")
 	if err != nil {
-		return nil, fmt.Errorf("failed to get topic: %w", err)
+		return nil, nil, fmt.Errorf("failed to get topic: %w", err)
 	}
 
-	updateStatusUC := uc.NewChangeStatusUseCase(repository)
-	consumer := kafka.NewConsumer(cfg.Kafka.Brokers, topic, cfg.Kafka.ConsumerGroup, updateStatusUC)
+	updateStatusUC := uc.NewChangeStatusUseCase(repository, publishedRelay)
+	opts, dlq := consumerOptions(cfg, "comment_moderated_dlq")
+	consumer := kafka.NewConsumer(cfg.Kafka.Brokers, topic, cfg.Kafka.ConsumerGroup, updateStatusUC, opts...)
+
+	return consumer, dlq, nil
+}
+
+// consumerOptions возвращает политику повторов и количество обработчиков consumer'а и, если в конфигурации
+// задан топик dlqTopicName, публикацию необработанных сообщений в этот dead-letter топик. Publisher
+// dead-letter топика (nil, если топик не задан) нужно закрыть после остановки consumer'а.
+func consumerOptions(cfg *config.Config, dlqTopicName string) ([]kafka.ConsumerOption, *kafka.Publisher) {
+	opts := []kafka.ConsumerOption{
+		kafka.WithRetryPolicy(
+			kafka.RetryPolicy{
+				Attempts:   cfg.Kafka.Retry.Attempts,
+				MinBackoff: cfg.Kafka.Retry.GetMinBackoff(),
+				MaxBackoff: cfg.Kafka.Retry.GetMaxBackoff(),
+			},
+		),
+		kafka.WithWorkers(cfg.Kafka.Workers),
+	}
+
+	dlqTopic, err := cfg.GetTopic(dlqTopicName)
+	if err != nil {
+		return opts, nil
+	}
+
+	dlq := newPublisher(cfg, dlqTopic)
+
+	return append(opts, kafka.WithDeadLetter(dlq)), dlq
+}
+
+// newPublisher создает publisher топика с параметрами отправки из конфигурации.
+func newPublisher(cfg *config.Config, topic string, opts ...kafka.PublisherOption) *kafka.Publisher {
+	opts = append(
+		[]kafka.PublisherOption{
+			kafka.WithPublisherConfig(
+				kafka.PublisherConfig{
+					Acks:         cfg.Kafka.Publisher.Acks,
+					Compression:  cfg.Kafka.Publisher.Compression,
+					BatchSize:    cfg.Kafka.Publisher.BatchSize,
+					BatchTimeout: cfg.Kafka.Publisher.GetBatchTimeout(),
+				},
+			),
+		}, opts...,
+	)
 
-	return consumer, nil
+	return kafka.NewPublisher(cfg.Kafka.Brokers, topic, opts...)
 }
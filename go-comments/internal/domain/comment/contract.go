@@ -10,7 +10,8 @@ type Creator interface {
 
 // Updater определяет контракт изменения комментария.
 type Updater interface {
-	// UpdateStatus публикует/отклоняет комментарий.
+	// UpdateStatus публикует/отклоняет комментарий. При публикации (pubTime != nil) вместе со статусом
+	// сохраняется событие о публикации комментария.
 	UpdateStatus(ctx context.Context, id ID, status Status, pubTime *CommentTime) error
 }
 
@@ -65,13 +65,109 @@ type LoggingConfig struct {
 	EnableHTTPLogs bool   `yaml:"enable_http_logs" validate:"required"`
 }
 
+// ConsumerRetryConfig - политика повторной обработки сообщений consumer'а.
+// Нулевые значения заменяются значениями по умолчанию pkg/kafka.
+type ConsumerRetryConfig struct {
+	// Attempts - количество попыток обработки сообщения, включая первую.
+	Attempts int `yaml:"attempts" validate:"min=0"`
+	// MinBackoff - начальная задержка между попытками в секундах.
+	MinBackoff int `yaml:"min_backoff" validate:"min=0"`
+	// MaxBackoff - максимальная задержка между попытками в секундах.
+	MaxBackoff int `yaml:"max_backoff" validate:"min=0"`
+}
+
+// GetMinBackoff возвращает начальную задержку между попытками как time.Duration в секундах.
+func (r *ConsumerRetryConfig) GetMinBackoff() time.Duration {
+	return time.Duration(r.MinBackoff) * time.Second
+}
+
+// GetMaxBackoff возвращает максимальную задержку между попытками как time.Duration в секундах.
+func (r *ConsumerRetryConfig) GetMaxBackoff() time.Duration {
+	return time.Duration(r.MaxBackoff) * time.Second
+}
+
+// KafkaPublisherConfig - параметры отправки сообщений в Kafka. Нулевые значения заменяются значениями
+// по умолчанию pkg/kafka.
+type KafkaPublisherConfig struct {
+	// Acks - подтверждение записи: all - всеми репликами (по умолчанию), one - лидером партиции,
+	// none - без подтверждения.
+	Acks string `yaml:"acks" validate:"omitempty,oneof=all one none"`
+	// Compression - сжатие сообщений: none (по умолчанию), gzip, snappy, lz4 или zstd.
+	Compression string `yaml:"compression" validate:"omitempty,oneof=none gzip snappy lz4 zstd"`
+	// BatchSize - максимальное количество сообщений в пакете, по умолчанию 100.
+	BatchSize int `yaml:"batch_size" validate:"min=0"`
+	// BatchTimeout - максимальное время накопления неполного пакета в миллисекундах, по умолчанию 1000.
+	BatchTimeout int `yaml:"batch_timeout" validate:"min=0"`
+}
+
+// GetBatchTimeout возвращает время накопления пакета как time.Duration в миллисекундах.
+func (p *KafkaPublisherConfig) GetBatchTimeout() time.Duration {
+	return time.Duration(p.BatchTimeout) * time.Millisecond
+}
+
 // KafkaConfig - конфигурация Kafka.
 type KafkaConfig struct {
-	Brokers              []string          `yaml:"brokers" validate:"required"`
-	Topics               map[string]string `yaml:"topics" validate:"required"`
-	ConsumerGroup        string            `yaml:"consumer_group" validate:"required"`
-	Partition            int               `yaml:"partition"`
-	LeaderReloadInterval time.Duration     `yaml:"leader_reload_interval" validate:"required"`
+	Brokers              []string            `yaml:"brokers" validate:"required"`
+	Topics               map[string]string   `yaml:"topics" validate:"required"`
+	ConsumerGroup        string              `yaml:"consumer_group" validate:"required"`
+	Partition            int                 `yaml:"partition"`
+	LeaderReloadInterval time.Duration       `yaml:"leader_reload_interval" validate:"required"`
+	Retry                ConsumerRetryConfig `yaml:"retry"`
+	// Workers - количество параллельных обработчиков сообщений consumer'а, по умолчанию 1.
+	Workers   int                  `yaml:"workers" validate:"min=0"`
+	Publisher KafkaPublisherConfig `yaml:"publisher"`
+}
+
+// OutboxConfig - конфигурация отправки событий из outbox в Kafka.
+// Нулевые значения заменяются значениями по умолчанию.
+type OutboxConfig struct {
+	// Interval - период проверки outbox в секундах.
+	Interval int `yaml:"interval" validate:"min=0"`
+	// BatchSize - максимальное количество событий за одну проверку.
+	BatchSize int `yaml:"batch_size" validate:"min=0"`
+	// MaxBackoff - максимальная задержка повторной отправки в минутах.
+	MaxBackoff int `yaml:"max_backoff" validate:"min=0"`
+	// Retention - срок хранения отправленных событий в днях.
+	Retention int `yaml:"retention" validate:"min=0"`
+}
+
+const (
+	defaultOutboxInterval   = 1
+	defaultOutboxBatchSize  = 100
+	defaultOutboxMaxBackoff = 5
+	defaultOutboxRetention  = 3
+)
+
+// GetInterval возвращает период проверки outbox как time.Duration в секундах.
+func (o *OutboxConfig) GetInterval() time.Duration {
+	if o.Interval == 0 {
+		return defaultOutboxInterval * time.Second
+	}
+	return time.Duration(o.Interval) * time.Second
+}
+
+// GetBatchSize возвращает максимальное количество событий за одну проверку.
+func (o *OutboxConfig) GetBatchSize() int {
+	if o.BatchSize == 0 {
+		return defaultOutboxBatchSize
+	}
+	return o.BatchSize
+}
+
+// GetMaxBackoff возвращает максимальную задержку повторной отправки как time.Duration в минутах.
+func (o *OutboxConfig) GetMaxBackoff() time.Duration {
+	if o.MaxBackoff == 0 {
+		return defaultOutboxMaxBackoff * time.Minute
+	}
+	return time.Duration(o.MaxBackoff) * time.Minute
+}
+
+// GetRetention возвращает срок хранения отправленных событий как time.Duration в днях.
+func (o *OutboxConfig) GetRetention() time.Duration {
+	if o.Retention == 0 {
+		return defaultOutboxRetention * 24 * time.Hour
+	}
+	return time.Duration(o.Retention) * 24 * time.Hour
 }
 
 // Config основная конфигурация.
@@ -81,6 +177,7 @@ type Config struct {
 	DB      DBConfig      `yaml:"database"`
 	Logging LoggingConfig `yaml:"logging"`
 	Kafka   KafkaConfig   `yaml:"kafka"`
+	Outbox  OutboxConfig  `yaml:"outbox"`
 }
 
 func (c *Config) GetAppName() string {
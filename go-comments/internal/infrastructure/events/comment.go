@@ -6,6 +6,15 @@ import (
 	"time"
 )
 
+const (
+	// CommentCreatedType - тип события создания комментария.
+	CommentCreatedType = "comment.created"
+	// CommentPublishedType - тип события публикации комментария.
+	CommentPublishedType = "comment.published"
+	// SchemaVersion - версия схемы событий, передается в заголовке сообщения.
+	SchemaVersion = 1
+)
+
 // CommentCreatedEvent - событие создания комментария для модерации.
 type CommentCreatedEvent struct {
 	CommentID int64     `json:"comment_id"`
@@ -27,6 +36,27 @@ func (e *CommentCreatedEvent) ToJSON() ([]byte, error) {
 	return json.Marshal(e)
 }
 
+// CommentPublishedEvent - событие публикации комментария после успешной модерации.
+type CommentPublishedEvent struct {
+	CommentID   int64     `json:"comment_id"`
+	NewsID      int32     `json:"news_id"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// NewCommentPublishedEvent создает экземпляр CommentPublishedEvent.
+func NewCommentPublishedEvent(commentID int64, newsID int32, publishedAt time.Time) *CommentPublishedEvent {
+	return &CommentPublishedEvent{
+		CommentID:   commentID,
+		NewsID:      newsID,
+		PublishedAt: publishedAt,
+	}
+}
+
+// ToJSON конвертирует событие в JSON.
+func (e *CommentPublishedEvent) ToJSON() ([]byte, error) {
+	return json.Marshal(e)
+}
+
 // CommentModerationResult - результат модерации комментария.
 type CommentModerationResult struct {
 	CommentID   int64     `json:"comment_id"`
@@ -5,8 +5,10 @@ import (
 	"database/sql"
 	"fmt"
 	dom "github.com/ee-crocush/go-news/go-comments/internal/domain/comment"
+	"github.com/ee-crocush/go-news/go-comments/internal/infrastructure/events"
 	"github.com/ee-crocush/go-news/go-comments/internal/infrastructure/repo/postgres/mapper"
 	"github.com/jackc/pgx/v4/pgxpool"
+	"strconv"
 )
 
 var _ dom.Repository = (*CommentRepository)(nil)
@@ -21,7 +23,8 @@ func NewCommentRepository(pool *pgxpool.Pool) *CommentRepository {
 	return &CommentRepository{pool: pool}
 }
 
-// Create сохраняет комментарий.
+// Create сохраняет комментарий и в той же транзакции записывает в outbox событие CommentCreated
+// для модерации: событие отправит relay, даже если Kafka сейчас недоступна.
 func (r *CommentRepository) Create(ctx context.Context, comment *dom.Comment) (dom.ID, error) {
 	const query = `
 		INSERT INTO comments (news_id, parent_id, user_name, content, created_at, status)
@@ -29,43 +32,84 @@ func (r *CommentRepository) Create(ctx context.Context, comment *dom.Comment) (d
 		RETURNING id
 	`
 
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return dom.ID{}, fmt.Errorf("CommentRepository.Create.Begin: %w", err)
+	}
+	// После Commit откат ничего не делает
+	defer tx.Rollback(ctx)
+
 	var id int64
-	err := r.pool.QueryRow(
+	err = tx.QueryRow(
 		ctx, query, comment.NewsID().Value(), comment.ParentID().Value(), comment.Username().Value(),
 		comment.Content().Value(), comment.CreatedAt().Time().UTC().Unix(), comment.Status().Value(),
 	).Scan(&id)
 	if err != nil {
-		return dom.ID{}, fmt.Errorf("NewCommentRepository.Create: %w", err)
+		return dom.ID{}, fmt.Errorf("CommentRepository.Create: %w", err)
 	}
 
 	commentID, err := dom.NewID(id)
 	if err != nil {
-		return dom.ID{}, fmt.Errorf("NewCommentRepository.Create: %w", err)
+		return dom.ID{}, fmt.Errorf("CommentRepository.Create: %w", err)
+	}
+
+	data, err := events.NewCommentCreatedEvent(commentID.Value(), comment.Content().Value()).ToJSON()
+	if err != nil {
+		return dom.ID{}, fmt.Errorf("CommentRepository.Create.ToJSON: %w", err)
+	}
+	if err = insertOutbox(ctx, tx, events.CommentCreatedType, strconv.FormatInt(id, 10), data); err != nil {
+		return dom.ID{}, fmt.Errorf("CommentRepository.Create: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return dom.ID{}, fmt.Errorf("CommentRepository.Create.Commit: %w", err)
 	}
 
 	return commentID, nil
 }
 
-// UpdateStatus публикует/отклоняет комментарий.
+// UpdateStatus публикует/отклоняет комментарий. При публикации (pubTime != nil) в той же транзакции
+// в outbox записывается событие CommentPublished для счетчиков комментариев сервиса новостей,
+// в том числе при повторной обработке уже опубликованного комментария: сервис новостей учитывает
+// комментарий один раз.
 func (r *CommentRepository) UpdateStatus(
 	ctx context.Context, id dom.ID, status dom.Status, pubTime *dom.CommentTime,
 ) error {
-	const queryWithTime = `UPDATE comments SET status = $2, pub_time = $3 WHERE id = $1`
+	const queryWithTime = `UPDATE comments SET status = $2, pub_time = $3 WHERE id = $1 RETURNING news_id`
 	const queryNoTime = `UPDATE comments SET status = $2 WHERE id = $1`
 
-	if pubTime != nil {
-		_, err := r.pool.Exec(ctx, queryWithTime, id.Value(), status.Value(), pubTime.Time().UTC().Unix())
-		if err != nil {
+	if pubTime == nil {
+		if _, err := r.pool.Exec(ctx, queryNoTime, id.Value(), status.Value()); err != nil {
 			return fmt.Errorf("CommentRepository.UpdateStatus: %w", err)
 		}
-
 		return nil
 	}
 
-	_, err := r.pool.Exec(ctx, queryNoTime, id.Value(), status.Value())
+	tx, err := r.pool.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("CommentRepository.UpdateStatus.Begin: %w", err)
+	}
+	// После Commit откат ничего не делает
+	defer tx.Rollback(ctx)
+
+	var newsID int32
+	err = tx.QueryRow(ctx, queryWithTime, id.Value(), status.Value(), pubTime.Time().UTC().Unix()).Scan(&newsID)
 	if err != nil {
 		return fmt.Errorf("CommentRepository.UpdateStatus: %w", err)
 	}
+
+	data, err := events.NewCommentPublishedEvent(id.Value(), newsID, pubTime.Time()).ToJSON()
+	if err != nil {
+		return fmt.Errorf("CommentRepository.UpdateStatus.ToJSON: %w", err)
+	}
+	if err = insertOutbox(ctx, tx, events.CommentPublishedType, strconv.FormatInt(int64(newsID), 10), data); err != nil {
+		return fmt.Errorf("CommentRepository.UpdateStatus: %w", err)
+	}
+
+	if err = tx.Commit(ctx); err != nil {
+		return fmt.Errorf("CommentRepository.UpdateStatus.Commit: %w", err)
+	}
+
 	return nil
 }
 
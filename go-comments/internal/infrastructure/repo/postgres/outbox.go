@@ -0,0 +1,169 @@
+package postgres
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/ee-crocush/go-news/pkg/outbox"
+	"github.com/jackc/pgx/v4"
+	"github.com/jackc/pgx/v4/pgxpool"
+)
+
+var (
+	_ outbox.Store   = (*OutboxRepository)(nil)
+	_ outbox.Cleaner = (*OutboxRepository)(nil)
+)
+
+// OutboxRepository представляет собой хранилище событий outbox в PostgreSQL.
+// События записывает CommentRepository в транзакции вместе с комментариями. События разных типов
+// отправляются в разные топики, поэтому хранилище выдает только события своего типа.
+type OutboxRepository struct {
+	pool      *pgxpool.Pool
+	eventType string
+}
+
+// NewOutboxRepository создаёт новое PostgreSQL-хранилище событий outbox типа eventType.
+func NewOutboxRepository(pool *pgxpool.Pool, eventType string) *OutboxRepository {
+	return &OutboxRepository{pool: pool, eventType: eventType}
+}
+
+// insertOutbox записывает событие в outbox в транзакции tx. Вместе с событием сохраняются заголовки
+// сообщения из контекста (request_id запроса, см. outbox.ContextHeaders).
+func insertOutbox(ctx context.Context, tx pgx.Tx, eventType, key string, payload []byte) error {
+	const query = `
+		INSERT INTO outbox (event_type, event_key, payload, headers)
+		VALUES ($1, $2, $3::jsonb, $4::jsonb)`
+
+	headers := outbox.ContextHeaders(ctx)
+	if headers == nil {
+		headers = map[string]string{}
+	}
+
+	data, err := json.Marshal(headers)
+	if err != nil {
+		return fmt.Errorf("insertOutbox.Marshal: %w", err)
+	}
+
+	if _, err = tx.Exec(ctx, query, eventType, key, string(payload), string(data)); err != nil {
+		return fmt.Errorf("insertOutbox: %w", err)
+	}
+
+	return nil
+}
+
+// Claim захватывает до limit готовых к отправке событий: время следующей попытки сдвигается на lease.
+// Строки, захваченные параллельно другим экземпляром сервиса, пропускаются (SKIP LOCKED).
+func (r *OutboxRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]outbox.Message, error) {
+	const query = `
+		UPDATE outbox SET next_attempt_at = now() + $2 * interval '1 millisecond'
+		WHERE id IN (
+			SELECT id FROM outbox
+			WHERE event_type = $3 AND sent_at IS NULL AND next_attempt_at <= now()
+			ORDER BY next_attempt_at, id
+			LIMIT $1
+			FOR UPDATE SKIP LOCKED
+		)
+		RETURNING id, event_key, payload, headers, attempts`
+
+	rows, err := r.pool.Query(ctx, query, limit, lease.Milliseconds(), r.eventType)
+	if err != nil {
+		return nil, fmt.Errorf("OutboxRepository.Claim: %w", err)
+	}
+	defer rows.Close()
+
+	type claimed struct {
+		id  int64
+		msg outbox.Message
+	}
+
+	var items []claimed
+	for rows.Next() {
+		var item claimed
+		var headers []byte
+		if err = rows.Scan(&item.id, &item.msg.Key, &item.msg.Payload, &headers, &item.msg.Attempts); err != nil {
+			return nil, fmt.Errorf("OutboxRepository.Claim: %w", err)
+		}
+		if err = json.Unmarshal(headers, &item.msg.Headers); err != nil {
+			return nil, fmt.Errorf("OutboxRepository.Claim: %w", err)
+		}
+		item.msg.ID = strconv.FormatInt(item.id, 10)
+		items = append(items, item)
+	}
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("OutboxRepository.Claim: %w", err)
+	}
+
+	// RETURNING не сохраняет порядок подзапроса: события отправляются в порядке записи.
+	sort.Slice(items, func(i, j int) bool { return items[i].id < items[j].id })
+
+	messages := make([]outbox.Message, 0, len(items))
+	for _, item := range items {
+		messages = append(messages, item.msg)
+	}
+
+	return messages, nil
+}
+
+// MarkSent отмечает события отправленными.
+func (r *OutboxRepository) MarkSent(ctx context.Context, ids []string) error {
+	const query = `UPDATE outbox SET sent_at = now() WHERE id = ANY($1)`
+
+	rowIDs, err := outboxIDs(ids)
+	if err != nil {
+		return fmt.Errorf("OutboxRepository.MarkSent: %w", err)
+	}
+
+	if _, err = r.pool.Exec(ctx, query, rowIDs); err != nil {
+		return fmt.Errorf("OutboxRepository.MarkSent: %w", err)
+	}
+
+	return nil
+}
+
+// MarkFailed увеличивает счетчик попыток отправки событий и откладывает следующую до retryAt.
+func (r *OutboxRepository) MarkFailed(ctx context.Context, ids []string, retryAt time.Time, reason string) error {
+	const query = `
+		UPDATE outbox SET attempts = attempts + 1, next_attempt_at = $2, last_error = $3
+		WHERE id = ANY($1)`
+
+	rowIDs, err := outboxIDs(ids)
+	if err != nil {
+		return fmt.Errorf("OutboxRepository.MarkFailed: %w", err)
+	}
+
+	if _, err = r.pool.Exec(ctx, query, rowIDs, retryAt, reason); err != nil {
+		return fmt.Errorf("OutboxRepository.MarkFailed: %w", err)
+	}
+
+	return nil
+}
+
+// outboxIDs преобразует идентификаторы событий в числовые.
+func outboxIDs(ids []string) ([]int64, error) {
+	result := make([]int64, 0, len(ids))
+	for _, id := range ids {
+		outboxID, err := strconv.ParseInt(id, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, outboxID)
+	}
+
+	return result, nil
+}
+
+// DeleteSent удаляет события, отправленные раньше before.
+func (r *OutboxRepository) DeleteSent(ctx context.Context, before time.Time) (int64, error) {
+	const query = `DELETE FROM outbox WHERE event_type = $2 AND sent_at < $1`
+
+	tag, err := r.pool.Exec(ctx, query, before, r.eventType)
+	if err != nil {
+		return 0, fmt.Errorf("OutboxRepository.DeleteSent: %w", err)
+	}
+
+	return tag.RowsAffected(), nil
+}
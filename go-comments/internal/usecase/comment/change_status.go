@@ -12,12 +12,15 @@ var _ ChangeStatusContract = (*ChangeStatusUseCase)(nil)
 
 // ChangeStatusUseCase представляет структуру, реализующую бизнес-логику для изменения статуса комментария.
 type ChangeStatusUseCase struct {
-	repo dom.Repository
+	repo     dom.Repository
+	notifier Notifier
 }
 
 // NewChangeStatusUseCase создает новый экземпляр adapter для изменения статуса комментария.
-func NewChangeStatusUseCase(repo dom.Repository) *ChangeStatusUseCase {
-	return &ChangeStatusUseCase{repo: repo}
+// Событие о публикации комментария репозиторий записывает в outbox вместе со статусом,
+// notifier запускает его отправку, не дожидаясь периода relay; nil - отправка по периоду.
+func NewChangeStatusUseCase(repo dom.Repository, notifier Notifier) *ChangeStatusUseCase {
+	return &ChangeStatusUseCase{repo: repo, notifier: notifier}
 }
 
 // Execute выполняет бизнес-логику изменения статуса комментария.
@@ -45,11 +48,22 @@ func (uc *ChangeStatusUseCase) Execute(ctx context.Context, msg kafka.Message) e
 
 	comment.SetStatus(status)
 
-	if comment.IsApproved() {
-		now := dom.NewTime()
-		err = uc.repo.UpdateStatus(ctx, comment.ID(), comment.Status(), &now)
-	} else {
-		err = uc.repo.UpdateStatus(ctx, comment.ID(), comment.Status(), nil)
+	if !comment.IsApproved() {
+		if err = uc.repo.UpdateStatus(ctx, comment.ID(), comment.Status(), nil); err != nil {
+			return fmt.Errorf("ChangeUseCase.UpdateStatus: %w", err)
+		}
+		return nil
+	}
+
+	// Событие о публикации записывается и при повторной доставке результата: если прежняя обработка
+	// прервалась, событие не потеряется, а сервис новостей учитывает комментарий один раз
+	now := dom.NewTime()
+	if err = uc.repo.UpdateStatus(ctx, comment.ID(), comment.Status(), &now); err != nil {
+		return fmt.Errorf("ChangeUseCase.UpdateStatus: %w", err)
+	}
+
+	if uc.notifier != nil {
+		uc.notifier.Notify()
 	}
 
 	return nil
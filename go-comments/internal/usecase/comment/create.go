@@ -4,22 +4,26 @@ import (
 	"context"
 	"fmt"
 	dom "github.com/ee-crocush/go-news/go-comments/internal/domain/comment"
-	"github.com/ee-crocush/go-news/go-comments/internal/infrastructure/events"
-	"github.com/ee-crocush/go-news/pkg/kafka"
-	"github.com/ee-crocush/go-news/pkg/logger"
 )
 
 var _ CreateContract = (*CreateUseCase)(nil)
 
+// Notifier — интерфейс уведомления о новых событиях outbox.
+type Notifier interface {
+	Notify()
+}
+
 // CreateUseCase представляет структуру, реализующую бизнес-логику для создания комментария.
 type CreateUseCase struct {
-	repo      dom.Repository
-	publisher *kafka.Publisher
+	repo     dom.Repository
+	notifier Notifier
 }
 
 // NewCreateUseCase создает новый экземпляр adapter для создания комментария.
-func NewCreateUseCase(repo dom.Repository, publisher *kafka.Publisher) *CreateUseCase {
-	return &CreateUseCase{repo: repo, publisher: publisher}
+// Событие для модерации репозиторий записывает в outbox вместе с комментарием,
+// notifier запускает его отправку, не дожидаясь периода relay; nil - отправка по периоду.
+func NewCreateUseCase(repo dom.Repository, notifier Notifier) *CreateUseCase {
+	return &CreateUseCase{repo: repo, notifier: notifier}
 }
 
 // Execute выполняет бизнес-логику создания комментария.
@@ -44,27 +48,13 @@ func (uc *CreateUseCase) Execute(ctx context.Context, in CommentDTO) error {
 		comment.SetParentID(parentID)
 	}
 
-	// Создаем комментарий
-	commentID, err := uc.repo.Create(ctx, comment)
-	if err != nil {
+	// Создаем комментарий вместе с событием для модерации
+	if _, err = uc.repo.Create(ctx, comment); err != nil {
 		return fmt.Errorf("CreateUseCase.Create: %w", err)
 	}
-	comment.SetID(commentID)
-
-	// Публикуем в кафку событие для модерации
-	e := events.NewCommentCreatedEvent(comment.ID().Value(), comment.Content().Value())
-	data, err := e.ToJSON()
-	if err != nil {
-		return fmt.Errorf("CreateUseCase.ToJSON: %w", err)
-	}
 
-	// Логгируем тут, чтобы не пропустить косяк
-	if err = uc.publisher.Publish(ctx, fmt.Sprintf("%d", commentID.Value()), data); err != nil {
-		log := logger.GetLogger()
-		log.
-			Err(err).
-			Str("comment_id", fmt.Sprintf("%d", commentID.Value())).
-			Msg("Failed to publish comment created events")
+	if uc.notifier != nil {
+		uc.notifier.Notify()
 	}
 
 	return nil
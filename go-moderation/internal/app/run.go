@@ -9,6 +9,7 @@ import (
 
 	"github.com/ee-crocush/go-news/go-moderation/internal/adapter"
 	"github.com/ee-crocush/go-news/go-moderation/internal/infrastructure/config"
+	"github.com/ee-crocush/go-news/go-moderation/internal/infrastructure/events"
 	"github.com/ee-crocush/go-news/go-moderation/internal/service"
 	"github.com/ee-crocush/go-news/pkg/kafka"
 	"github.com/ee-crocush/go-news/pkg/logger"
@@ -23,7 +24,10 @@ func Run(cfg *config.Config) {
 	defer pub.Close()
 
 	// Создаем Consumer
-	consumer := initConsumer(cfg, log, pub)
+	consumer, dlq := initConsumer(cfg, log, pub)
+	if dlq != nil {
+		defer dlq.Close()
+	}
 	defer consumer.Close()
 
 	ctx, cancel := context.WithCancel(context.Background())
@@ -38,10 +42,14 @@ func initPublisher(cfg *config.Config, log *zerolog.Logger) *kafka.Publisher {
 		log.Error().Err(err).Msg("Failed to get topic")
 	}
 
-	return kafka.NewPublisher(cfg.Kafka.Brokers, topic)
+	// Результат модерации отправляется синхронно: смещение комментария подтверждается только после записи
+	// результата, иначе при ошибке асинхронной отправки результат был бы потерян.
+	return newPublisher(cfg, topic, kafka.WithEventType(events.CommentModeratedType, events.SchemaVersion))
 }
 
-func initConsumer(cfg *config.Config, log *zerolog.Logger, pub *kafka.Publisher) *kafka.Consumer {
+// initConsumer создает consumer событий о новых комментариях и publisher его dead-letter топика
+// (nil, если топик не задан).
+func initConsumer(cfg *config.Config, log *zerolog.Logger, pub *kafka.Publisher) (*kafka.Consumer, *kafka.Publisher) {
 	moderationService := service.NewService(pub)
 
 	topic, err := cfg.GetTopic("comment_created")
@@ -50,7 +58,9 @@ func initConsumer(cfg *config.Config, log *zerolog.Logger, pub *kafka.Publisher)
 	}
 
 	moderationAdapter := adapter.NewModerationAdapter(moderationService)
-	return kafka.NewConsumer(cfg.Kafka.Brokers, topic, cfg.Kafka.ConsumerGroup, moderationAdapter)
+	opts, dlq := consumerOptions(cfg, "comment_created_dlq")
+
+	return kafka.NewConsumer(cfg.Kafka.Brokers, topic, cfg.Kafka.ConsumerGroup, moderationAdapter, opts...), dlq
 }
 
 func gracefulShutdown(ctx context.Context, consumer *kafka.Consumer, log *zerolog.Logger) {
@@ -68,3 +78,46 @@ func gracefulShutdown(ctx context.Context, consumer *kafka.Consumer, log *zerolo
 	consumer.Close()
 	fmt.Println("Shutting down moderation service...")
 }
+
+// consumerOptions возвращает политику повторов и количество обработчиков consumer'а и, если в конфигурации
+// задан топик dlqTopicName, публикацию необработанных сообщений в этот dead-letter топик. Publisher
+// dead-letter топика (nil, если топик не задан) нужно закрыть после остановки consumer'а.
+func consumerOptions(cfg *config.Config, dlqTopicName string) ([]kafka.ConsumerOption, *kafka.Publisher) {
+	opts := []kafka.ConsumerOption{
+		kafka.WithRetryPolicy(
+			kafka.RetryPolicy{
+				Attempts:   cfg.Kafka.Retry.Attempts,
+				MinBackoff: cfg.Kafka.Retry.GetMinBackoff(),
+				MaxBackoff: cfg.Kafka.Retry.GetMaxBackoff(),
+			},
+		),
+		kafka.WithWorkers(cfg.Kafka.Workers),
+	}
+
+	dlqTopic, err := cfg.GetTopic(dlqTopicName)
+	if err != nil {
+		return opts, nil
+	}
+
+	dlq := newPublisher(cfg, dlqTopic)
+
+	return append(opts, kafka.WithDeadLetter(dlq)), dlq
+}
+
+// newPublisher создает publisher топика с параметрами отправки из конфигурации.
+func newPublisher(cfg *config.Config, topic string, opts ...kafka.PublisherOption) *kafka.Publisher {
+	opts = append(
+		[]kafka.PublisherOption{
+			kafka.WithPublisherConfig(
+				kafka.PublisherConfig{
+					Acks:         cfg.Kafka.Publisher.Acks,
+					Compression:  cfg.Kafka.Publisher.Compression,
+					BatchSize:    cfg.Kafka.Publisher.BatchSize,
+					BatchTimeout: cfg.Kafka.Publisher.GetBatchTimeout(),
+				},
+			),
+		}, opts...,
+	)
+
+	return kafka.NewPublisher(cfg.Kafka.Brokers, topic, opts...)
+}
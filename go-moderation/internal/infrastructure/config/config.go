@@ -6,6 +6,7 @@ import (
 	"github.com/joho/godotenv"
 	"gopkg.in/yaml.v3"
 	"os"
+	"time"
 )
 
 // AppConfig - конфигурация приложения.
@@ -20,11 +21,55 @@ type LoggingConfig struct {
 	Format string `yaml:"format" validate:"required"`
 }
 
+// ConsumerRetryConfig - политика повторной обработки сообщений consumer'а.
+// Нулевые значения заменяются значениями по умолчанию pkg/kafka.
+type ConsumerRetryConfig struct {
+	// Attempts - количество попыток обработки сообщения, включая первую.
+	Attempts int `yaml:"attempts" validate:"min=0"`
+	// MinBackoff - начальная задержка между попытками в секундах.
+	MinBackoff int `yaml:"min_backoff" validate:"min=0"`
+	// MaxBackoff - максимальная задержка между попытками в секундах.
+	MaxBackoff int `yaml:"max_backoff" validate:"min=0"`
+}
+
+// GetMinBackoff возвращает начальную задержку между попытками как time.Duration в секундах.
+func (r *ConsumerRetryConfig) GetMinBackoff() time.Duration {
+	return time.Duration(r.MinBackoff) * time.Second
+}
+
+// GetMaxBackoff возвращает максимальную задержку между попытками как time.Duration в секундах.
+func (r *ConsumerRetryConfig) GetMaxBackoff() time.Duration {
+	return time.Duration(r.MaxBackoff) * time.Second
+}
+
+// KafkaPublisherConfig - параметры отправки сообщений в Kafka. Нулевые значения заменяются значениями
+// по умолчанию pkg/kafka.
+type KafkaPublisherConfig struct {
+	// Acks - подтверждение записи: all - всеми репликами (по умолчанию), one - лидером партиции,
+	// none - без подтверждения.
+	Acks string `yaml:"acks" validate:"omitempty,oneof=all one none"`
+	// Compression - сжатие сообщений: none (по умолчанию), gzip, snappy, lz4 или zstd.
+	Compression string `yaml:"compression" validate:"omitempty,oneof=none gzip snappy lz4 zstd"`
+	// BatchSize - максимальное количество сообщений в пакете, по умолчанию 100.
+	BatchSize int `yaml:"batch_size" validate:"min=0"`
+	// BatchTimeout - максимальное время накопления неполного пакета в миллисекундах, по умолчанию 1000.
+	BatchTimeout int `yaml:"batch_timeout" validate:"min=0"`
+}
+
+// GetBatchTimeout возвращает время накопления пакета как time.Duration в миллисекундах.
+func (p *KafkaPublisherConfig) GetBatchTimeout() time.Duration {
+	return time.Duration(p.BatchTimeout) * time.Millisecond
+}
+
 // KafkaConfig - конфигурация Kafka.
 type KafkaConfig struct {
-	Brokers       []string          `yaml:"brokers" validate:"required"`
-	Topics        map[string]string `yaml:"topics" validate:"required"`
-	ConsumerGroup string            `yaml:"consumer_group" validate:"required"`
+	Brokers       []string            `yaml:"brokers" validate:"required"`
+	Topics        map[string]string   `yaml:"topics" validate:"required"`
+	ConsumerGroup string              `yaml:"consumer_group" validate:"required"`
+	Retry         ConsumerRetryConfig `yaml:"retry"`
+	// Workers - количество параллельных обработчиков сообщений consumer'а, по умолчанию 1.
+	Workers   int                  `yaml:"workers" validate:"min=0"`
+	Publisher KafkaPublisherConfig `yaml:"publisher"`
 }
 
 // Config основная конфигурация.
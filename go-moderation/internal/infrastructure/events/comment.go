@@ -5,6 +5,13 @@ import (
 	"time"
 )
 
+const (
+	// CommentModeratedType - тип события с результатом модерации комментария.
+	CommentModeratedType = "comment.moderated"
+	// SchemaVersion - версия схемы событий, передается в заголовке сообщения.
+	SchemaVersion = 1
+)
+
 // CommentCreatedEvent - событие создания комментария для модерации.
 type CommentCreatedEvent struct {
 	CommentID int64     `json:"comment_id"`
@@ -0,0 +1,79 @@
+// Command dedupe-posts удаляет новости с повторяющейся ссылкой, сохраненные до появления уникального
+// индекса ссылки. Сервис не запускается, пока такие новости есть.
+//
+//	go run ./cmd/dedupe-posts -timeout 30m
+//
+// Для каждой группы дубликатов печатается SQL, переносящий комментарии go-comments
+// с удаленных новостей на оставшуюся.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ee-crocush/go-news/go-news/internal/infrastructure/config"
+	repo "github.com/ee-crocush/go-news/go-news/internal/infrastructure/repo/mongo"
+	configLoader "github.com/ee-crocush/go-news/pkg/config"
+)
+
+func main() {
+	timeout := flag.Duration("timeout", 30*time.Minute, "максимальное время очистки")
+	flag.Parse()
+
+	rssConfigPath := configLoader.FindConfigFile(
+		"./configs/rss_config.json",
+		"./go-news/configs/rss_config.json",
+		"/app/configs/rss_config.json",
+	)
+	cfg, err := config.LoadConfig(configLoader.FindConfigFile(), rssConfigPath)
+	if err != nil || cfg == nil {
+		fmt.Fprintln(os.Stderr, "failed to load config from all known paths:", err)
+		os.Exit(1)
+	}
+
+	client, db, err := repo.Connect(cfg)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "failed to connect to MongoDB:", err)
+		os.Exit(1)
+	}
+	defer func() { _ = client.Disconnect(context.Background()) }()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	ctx, cancel := context.WithTimeout(ctx, *timeout)
+	defer cancel()
+
+	groups, err := repo.DedupePosts(ctx, db)
+
+	removed := 0
+	for _, group := range groups {
+		removed += len(group.Removed)
+		fmt.Printf(
+			"UPDATE comments SET news_id = %d WHERE news_id IN (%s); -- %s\n",
+			group.Kept, joinIDs(group.Removed), group.Link,
+		)
+	}
+	fmt.Printf("links: %d, removed: %d\n", len(groups), removed)
+
+	if err != nil {
+		// Уже обработанные группы удалены; повторный запуск продолжит очистку.
+		fmt.Fprintf(os.Stderr, "dedupe failed: %v\n", err)
+		os.Exit(1)
+	}
+}
+
+func joinIDs(ids []int32) string {
+	parts := make([]string, 0, len(ids))
+	for _, id := range ids {
+		parts = append(parts, strconv.Itoa(int(id)))
+	}
+
+	return strings.Join(parts, ", ")
+}
@@ -2,16 +2,26 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
+	"github.com/ee-crocush/go-news/go-news/internal/infrastructure/classifier"
 	"github.com/ee-crocush/go-news/go-news/internal/infrastructure/config"
+	"github.com/ee-crocush/go-news/go-news/internal/infrastructure/events"
+	"github.com/ee-crocush/go-news/go-news/internal/infrastructure/readability"
 	repo "github.com/ee-crocush/go-news/go-news/internal/infrastructure/repo/mongo"
 	"github.com/ee-crocush/go-news/go-news/internal/infrastructure/rss"
+	"github.com/ee-crocush/go-news/go-news/internal/infrastructure/scheduler"
+	"github.com/ee-crocush/go-news/go-news/internal/infrastructure/summary"
 	"github.com/ee-crocush/go-news/go-news/internal/infrastructure/transport/httplib"
 	"github.com/ee-crocush/go-news/go-news/internal/infrastructure/transport/httplib/handler"
+	feedHandler "github.com/ee-crocush/go-news/go-news/internal/infrastructure/transport/httplib/handler/feed"
+	feedUC "github.com/ee-crocush/go-news/go-news/internal/usecase/feed"
 	uc "github.com/ee-crocush/go-news/go-news/internal/usecase/post"
+	"github.com/ee-crocush/go-news/pkg/kafka"
 	"github.com/ee-crocush/go-news/pkg/logger"
+	"github.com/ee-crocush/go-news/pkg/outbox"
 	"github.com/ee-crocush/go-news/pkg/server"
 	commonFiber "github.com/ee-crocush/go-news/pkg/server/fiber"
 	"github.com/gofiber/fiber/v2"
@@ -19,6 +29,12 @@ import (
 	"go.mongodb.org/mongo-driver/v2/mongo"
 )
 
+// articleFetchTimeout - таймаут загрузки страницы статьи для источников с загрузкой полного текста.
+const articleFetchTimeout = 15 * time.Second
+
+// ErrNoTransactions представляет ошибку запуска с событиями новостей на MongoDB без транзакций.
+var ErrNoTransactions = errors.New("mongodb does not support transactions, replica set is required for news events")
+
 // Run запускает HTTP сервер и инициализирует все необходимые компоненты.
 func Run(cfg *config.Config) error {
 	client, db, err := connectDB(cfg)
@@ -36,21 +52,73 @@ func Run(cfg *config.Config) error {
 	}()
 
 	postRepo := repo.NewPostRepository(db, cfg.MongoDB.ConnectTimeout)
+	feedRepo := repo.NewFeedRepository(db, cfg.MongoDB.ConnectTimeout)
+	runRepo := repo.NewRunRepository(db, cfg.MongoDB.ConnectTimeout)
 	rssParser := rss.NewParser(cfg.RSS.GetRequestPeriodDuration())
-	postStoreUC := uc.NewParseAndStoreUseCase(postRepo, rssParser)
-	go startRSSBackgroundJob(cfg, postStoreUC, log)
+	articleExtractor := readability.NewExtractor(articleFetchTimeout)
+
+	categorizer, err := initClassifier(cfg)
+	if err != nil {
+		return fmt.Errorf("failed to init classifier: %w", err)
+	}
+
+	// Модель рубрикатора не хранится: при старте она обучается на новостях, размеченных вручную.
+	trainUC := uc.NewTrainCategoriesUseCase(postRepo, categorizer)
+	trained, err := trainUC.Execute(context.Background(), uc.TrainCategoriesInputDTO{})
+	if err != nil {
+		log.Warn().Err(err).Msg("failed to train classifier, categories are assigned by rules only")
+	} else {
+		log.Info().Int("examples", trained.Examples).Msg("classifier trained")
+	}
+
+	postStoreUC := uc.NewParseAndStoreUseCase(
+		postRepo, feedRepo, runRepo, rssParser, articleExtractor, summary.NewSummarizer(uc.ContentLimit),
+		categorizer,
+	)
+
+	// Источники из rss_config.json добавляются в хранилище при старте, дальше ими управляют через API.
+	added, err := feedUC.NewImportUseCase(feedRepo).Execute(context.Background(), cfg.RSS.RSS)
+	if err != nil {
+		return fmt.Errorf("failed to import feeds: %w", err)
+	}
+	log.Info().Int("added", added).Msg("feeds imported from config")
+
+	rssScheduler := initScheduler(cfg, feedRepo, postStoreUC, log)
 
-	postHandler := initHandler(postRepo)
+	postHandler := initHandler(postRepo, trainUC)
+	feedHandler := initFeedHandler(feedRepo, runRepo, rssScheduler)
 	// Создаем Fiber сервер
 	fiberServer := commonFiber.NewFiberServer(
 		cfg, func(app *fiber.App) {
-			httplib.SetupRoutes(app, postHandler)
+			httplib.SetupRoutes(app, postHandler, feedHandler)
 		},
 	)
 
+	consumer, dlq, err := initConsumer(cfg, postRepo)
+	if err != nil {
+		return fmt.Errorf("failed to init consumer: %w", err)
+	}
+	if consumer == nil {
+		log.Warn().Msg("kafka is not configured, comments counters are not updated")
+	}
+	if dlq != nil {
+		defer dlq.Close()
+	}
+
+	servers := []server.GracefulServer{fiberServer, rssScheduler}
+
+	relay, publisher, err := initOutbox(cfg, db, postRepo)
+	if err != nil {
+		return fmt.Errorf("failed to init outbox: %w", err)
+	}
+	if relay != nil {
+		defer publisher.Close()
+		servers = append(servers, relay)
+	}
+
 	// Запускаем сервер
-	serverManager := server.NewServerManager(fiberServer)
-	return serverManager.StartAll(nil)
+	serverManager := server.NewServerManager(servers...)
+	return serverManager.StartAll(consumer)
 }
 
 func connectDB(cfg *config.Config) (*mongo.Client, *mongo.Database, error) {
@@ -67,29 +135,170 @@ func connectDB(cfg *config.Config) (*mongo.Client, *mongo.Database, error) {
 	return client, db, nil
 }
 
-func initHandler(repos *repo.PostRepository) *handler.Handler {
+func initHandler(repos *repo.PostRepository, trainUC *uc.TrainCategoriesUseCase) *handler.Handler {
 	findByIDUC := uc.NewFindByIDUseCase(repos)
 	findLastUC := uc.NewFindLastUseCase(repos)
 	findLatestUC := uc.NewFindLatestUseCase(repos)
 	findAllUC := uc.NewFindAllUseCase(repos)
+	findTagsUC := uc.NewFindTagsUseCase(repos)
+	findCategoriesUC := uc.NewFindCategoriesUseCase(repos)
 
-	return handler.NewHandler(findByIDUC, findLastUC, findLatestUC, findAllUC)
+	return handler.NewHandler(
+		findByIDUC, findLastUC, findLatestUC, findAllUC, findTagsUC, findCategoriesUC, trainUC,
+	)
 }
 
-func startRSSBackgroundJob(cfg *config.Config, ucp uc.ParseAndStoreUseCase, log *zerolog.Logger) {
-	period := cfg.RSS.GetRequestPeriodDuration()
-	ticker := time.NewTicker(period)
-	defer ticker.Stop()
-
-	for {
-		for _, url := range cfg.RSS.RSS {
-			go func(u string) {
-				in := uc.ParseAndStoreInputDTO{URL: u}
-				if err := ucp.Execute(context.Background(), in); err != nil {
-					log.Error().Err(err).Str("url", u).Msg("rss parse failed")
-				}
-			}(url)
-		}
-		<-ticker.C
+// initClassifier создает рубрикатор по правилам из конфигурации.
+func initClassifier(cfg *config.Config) (*classifier.Classifier, error) {
+	rules := make([]classifier.Rule, 0, len(cfg.Classifier.Categories))
+	for _, c := range cfg.Classifier.Categories {
+		rules = append(rules, classifier.Rule{Category: c.Name, Keywords: c.Keywords, Patterns: c.Patterns})
+	}
+
+	return classifier.New(
+		classifier.Config{
+			Rules:          rules,
+			MinProbability: cfg.Classifier.GetMinProbability(),
+			MinExamples:    cfg.Classifier.GetMinExamples(),
+		},
+	)
+}
+
+// initConsumer создает consumer кафки для учета опубликованных комментариев.
+// Возвращает nil, если Kafka не настроена. Publisher dead-letter топика (nil, если топик не задан)
+// нужно закрыть после остановки consumer'а.
+func initConsumer(cfg *config.Config, repos *repo.PostRepository) (*kafka.Consumer, *kafka.Publisher, error) {
+	if !cfg.Kafka.Enabled() {
+		return nil, nil, nil
+	}
+
+	topic, err := cfg.GetTopic("comment_published")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get topic: %w", err)
+	}
+
+	addCommentUC := uc.NewAddCommentUseCase(repos)
+	opts, dlq := consumerOptions(cfg, "comment_published_dlq")
+
+	return kafka.NewConsumer(cfg.Kafka.Brokers, topic, cfg.Kafka.ConsumerGroup, addCommentUC, opts...), dlq, nil
+}
+
+// initOutbox включает запись событий о новых новостях в outbox и создает relay, отправляющий их в Kafka.
+// Возвращает nil, если Kafka не настроена: тогда события не записываются. Новость и событие записываются
+// в одной транзакции, поэтому MongoDB без поддержки транзакций - ошибка ErrNoTransactions.
+func initOutbox(cfg *config.Config, db *mongo.Database, posts *repo.PostRepository) (
+	*outbox.Relay, *kafka.Publisher, error,
+) {
+	if !cfg.Kafka.Enabled() {
+		return nil, nil, nil
+	}
+
+	topic, err := cfg.GetTopic("news_published")
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get topic: %w", err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.MongoDB.ConnectTimeout)
+	defer cancel()
+
+	// Без транзакции событие, не записанное после новости, потерялось бы: при следующем опросе новость
+	// уже сохранена и пропускается
+	transactions, err := repo.SupportsTransactions(ctx, db)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !transactions {
+		return nil, nil, ErrNoTransactions
+	}
+	posts.EnableEvents()
+
+	// Отправка синхронная: relay отмечает событие отправленным только после подтверждения записи
+	publisher := newPublisher(cfg, topic, kafka.WithEventType(events.NewsPublishedType, events.SchemaVersion))
+	relay := outbox.NewRelay(
+		repo.NewOutboxRepository(db, cfg.MongoDB.ConnectTimeout),
+		publisher,
+		outbox.Config{
+			Interval:   cfg.Outbox.GetInterval(),
+			BatchSize:  cfg.Outbox.GetBatchSize(),
+			MaxBackoff: cfg.Outbox.GetMaxBackoff(),
+		},
+	)
+
+	return relay, publisher, nil
+}
+
+func initScheduler(
+	cfg *config.Config, repos *repo.FeedRepository, ucp uc.ParseAndStoreUseCase, log *zerolog.Logger,
+) *scheduler.Scheduler {
+	return scheduler.New(
+		feedUC.NewFindEnabledUseCase(repos),
+		ucp,
+		scheduler.Config{
+			Workers:         cfg.Scheduler.GetWorkers(),
+			Tick:            cfg.Scheduler.GetTick(),
+			DefaultInterval: cfg.RSS.GetRequestPeriodDuration(),
+			Jitter:          cfg.Scheduler.GetJitter(),
+			MaxBackoff:      cfg.Scheduler.GetMaxBackoff(),
+		},
+		log,
+	)
+}
+
+func initFeedHandler(
+	repos *repo.FeedRepository, runs *repo.RunRepository, schedule *scheduler.Scheduler,
+) *feedHandler.Handler {
+	createUC := feedUC.NewCreateUseCase(repos)
+	updateUC := feedUC.NewUpdateUseCase(repos)
+	deleteUC := feedUC.NewDeleteUseCase(repos)
+	findByIDUC := feedUC.NewFindByIDUseCase(repos)
+	findAllUC := feedUC.NewFindAllUseCase(repos)
+	findRunsUC := feedUC.NewFindRunsUseCase(repos, runs)
+	findHealthUC := feedUC.NewFindHealthUseCase(repos, runs)
+
+	return feedHandler.NewHandler(
+		createUC, updateUC, deleteUC, findByIDUC, findAllUC, findRunsUC, findHealthUC, schedule, schedule,
+	)
+}
+
+// consumerOptions возвращает политику повторов и количество обработчиков consumer'а и, если в конфигурации
+// задан топик dlqTopicName, публикацию необработанных сообщений в этот dead-letter топик. Publisher
+// dead-letter топика (nil, если топик не задан) нужно закрыть после остановки consumer'а.
+func consumerOptions(cfg *config.Config, dlqTopicName string) ([]kafka.ConsumerOption, *kafka.Publisher) {
+	opts := []kafka.ConsumerOption{
+		kafka.WithRetryPolicy(
+			kafka.RetryPolicy{
+				Attempts:   cfg.Kafka.Retry.Attempts,
+				MinBackoff: cfg.Kafka.Retry.GetMinBackoff(),
+				MaxBackoff: cfg.Kafka.Retry.GetMaxBackoff(),
+			},
+		),
+		kafka.WithWorkers(cfg.Kafka.Workers),
 	}
+
+	dlqTopic, err := cfg.GetTopic(dlqTopicName)
+	if err != nil {
+		return opts, nil
+	}
+
+	dlq := newPublisher(cfg, dlqTopic)
+
+	return append(opts, kafka.WithDeadLetter(dlq)), dlq
+}
+
+// newPublisher создает publisher топика с параметрами отправки из конфигурации.
+func newPublisher(cfg *config.Config, topic string, opts ...kafka.PublisherOption) *kafka.Publisher {
+	opts = append(
+		[]kafka.PublisherOption{
+			kafka.WithPublisherConfig(
+				kafka.PublisherConfig{
+					Acks:         cfg.Kafka.Publisher.Acks,
+					Compression:  cfg.Kafka.Publisher.Compression,
+					BatchSize:    cfg.Kafka.Publisher.BatchSize,
+					BatchTimeout: cfg.Kafka.Publisher.GetBatchTimeout(),
+				},
+			),
+		}, opts...,
+	)
+
+	return kafka.NewPublisher(cfg.Kafka.Brokers, topic, opts...)
 }
@@ -0,0 +1,45 @@
+package feed
+
+import (
+	"context"
+	"time"
+)
+
+// FeedStore определяет контракт сохранения источника.
+type FeedStore interface {
+	// Save создает или обновляет источник.
+	Save(ctx context.Context, feed *Feed) error
+	// Delete удаляет источник.
+	Delete(ctx context.Context, id FeedID) error
+	// SaveFetch сохраняет результат последнего опроса источника и увеличивает счетчик новостей
+	// на inserted, не изменяя настроек источника.
+	SaveFetch(ctx context.Context, feed *Feed, inserted int) error
+	// SaveValidators сохраняет валидаторы кэша источника, если адрес ленты с тех пор не изменился.
+	SaveValidators(ctx context.Context, feed *Feed) error
+}
+
+// FeedFinder определяет контракт получения источников.
+type FeedFinder interface {
+	// FindByID получает источник по ID.
+	FindByID(ctx context.Context, id FeedID) (*Feed, error)
+	// FindByURL получает источник по адресу ленты.
+	FindByURL(ctx context.Context, url FeedURL) (*Feed, error)
+	// FindAll получает все источники.
+	FindAll(ctx context.Context) ([]*Feed, error)
+	// FindEnabled получает включенные источники.
+	FindEnabled(ctx context.Context) ([]*Feed, error)
+}
+
+// RunStore определяет контракт сохранения журнала опросов.
+type RunStore interface {
+	// SaveRun сохраняет запись об опросе источника.
+	SaveRun(ctx context.Context, run *Run) error
+}
+
+// RunFinder определяет контракт получения журнала опросов.
+type RunFinder interface {
+	// FindRuns получает последние опросы источника, новые первыми.
+	FindRuns(ctx context.Context, id FeedID, limit int) ([]*Run, error)
+	// FindRunStats получает сводку опросов источников, начатых не раньше since.
+	FindRunStats(ctx context.Context, since time.Time) ([]RunStats, error)
+}
@@ -0,0 +1,18 @@
+package feed
+
+import "errors"
+
+var (
+	// ErrInvalidFeedID представляет ошибку невалидного идентификатора источника.
+	ErrInvalidFeedID = errors.New("invalid Feed ID")
+	// ErrEmptyFeedURL представляет ошибку незаполненного адреса ленты.
+	ErrEmptyFeedURL = errors.New("empty Feed URL")
+	// ErrInvalidFeedURL представляет ошибку невалидного адреса ленты.
+	ErrInvalidFeedURL = errors.New("invalid Feed URL")
+	// ErrInvalidPollInterval представляет ошибку невалидного интервала опроса.
+	ErrInvalidPollInterval = errors.New("invalid Feed poll interval")
+	// ErrFeedNotFound представляет ошибку ненайденного источника.
+	ErrFeedNotFound = errors.New("feed not found")
+	// ErrFeedAlreadyExists представляет ошибку добавления источника с уже существующим адресом.
+	ErrFeedAlreadyExists = errors.New("feed already exists")
+)
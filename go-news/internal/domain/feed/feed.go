@@ -0,0 +1,143 @@
+// Package feed содержит определения бизнес-правил и логики для сущности Feed.
+package feed
+
+import (
+	"fmt"
+	"time"
+)
+
+// Feed представляет источник новостей (RSS/Atom ленту).
+type Feed struct {
+	id           FeedID
+	url          FeedURL
+	title        string
+	enabled      bool
+	pollInterval PollInterval
+	etag         string
+	lastModified string
+	lastFetch    LastFetch
+	itemCount    int64
+	fetchFull    bool
+}
+
+// NewFeed создает новый включенный источник новостей с интервалом опроса по умолчанию.
+func NewFeed(url string) (*Feed, error) {
+	feedURL, err := NewFeedURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("NewFeed.NewFeedURL: %w", err)
+	}
+
+	return &Feed{url: feedURL, enabled: true}, nil
+}
+
+// ID возвращает идентификатор источника.
+func (f *Feed) ID() FeedID { return f.id }
+
+// URL возвращает адрес ленты.
+func (f *Feed) URL() FeedURL { return f.url }
+
+// Title возвращает название источника.
+func (f *Feed) Title() string { return f.title }
+
+// Enabled сообщает, опрашивается ли источник.
+func (f *Feed) Enabled() bool { return f.enabled }
+
+// PollInterval возвращает интервал опроса источника.
+func (f *Feed) PollInterval() PollInterval { return f.pollInterval }
+
+// ETag возвращает значение заголовка ETag последнего успешного ответа.
+func (f *Feed) ETag() string { return f.etag }
+
+// LastModified возвращает значение заголовка Last-Modified последнего успешного ответа.
+func (f *Feed) LastModified() string { return f.lastModified }
+
+// LastFetch возвращает результат последнего опроса источника.
+func (f *Feed) LastFetch() LastFetch { return f.lastFetch }
+
+// ItemCount возвращает количество новостей, сохраненных из источника.
+func (f *Feed) ItemCount() int64 { return f.itemCount }
+
+// FetchFullArticle сообщает, нужно ли загружать полный текст статей источника по ссылкам записей.
+func (f *Feed) FetchFullArticle() bool { return f.fetchFull }
+
+// RehydrateFeed — вспомогательный конструктор для «восстановления» сущности из БД.
+func RehydrateFeed(
+	id FeedID, url FeedURL, title string, enabled bool, pollInterval PollInterval, etag, lastModified string,
+	lastFetch LastFetch, itemCount int64,
+) *Feed {
+	return &Feed{
+		id:           id,
+		url:          url,
+		title:        title,
+		enabled:      enabled,
+		pollInterval: pollInterval,
+		etag:         etag,
+		lastModified: lastModified,
+		lastFetch:    lastFetch,
+		itemCount:    itemCount,
+	}
+}
+
+// SetID устанавливает идентификатор источника.
+func (f *Feed) SetID(id FeedID) { f.id = id }
+
+// SetFetchFullArticle включает или выключает загрузку полного текста статей источника.
+func (f *Feed) SetFetchFullArticle(enabled bool) { f.fetchFull = enabled }
+
+// SetValidators сохраняет валидаторы кэша (ETag/Last-Modified) для условных запросов.
+func (f *Feed) SetValidators(etag, lastModified string) {
+	f.etag = etag
+	f.lastModified = lastModified
+}
+
+// ChangeURL меняет адрес ленты. Валидаторы кэша относятся к старому адресу и сбрасываются.
+func (f *Feed) ChangeURL(url FeedURL) {
+	if f.url == url {
+		return
+	}
+
+	f.url = url
+	f.SetValidators("", "")
+}
+
+// Rename меняет название источника.
+func (f *Feed) Rename(title string) { f.title = title }
+
+// Enable включает опрос источника.
+func (f *Feed) Enable() { f.enabled = true }
+
+// Disable выключает опрос источника.
+func (f *Feed) Disable() { f.enabled = false }
+
+// SetPollInterval устанавливает интервал опроса источника.
+func (f *Feed) SetPollInterval(interval PollInterval) { f.pollInterval = interval }
+
+// RecordSuccess фиксирует успешный опрос источника и количество добавленных новостей.
+func (f *Feed) RecordSuccess(at time.Time, inserted int, notModified bool) {
+	status := FetchStatusOK
+	if notModified {
+		status = FetchStatusNotModified
+	}
+
+	f.lastFetch = LastFetch{status: status, at: at}
+	f.itemCount += int64(inserted)
+}
+
+// RecordWarnings добавляет предупреждения к результату последнего опроса.
+// Сохраняются только первые MaxFetchWarnings предупреждений.
+func (f *Feed) RecordWarnings(warnings []string) {
+	if len(warnings) > MaxFetchWarnings {
+		warnings = warnings[:MaxFetchWarnings]
+	}
+	if len(warnings) == 0 {
+		f.lastFetch.warnings = nil
+		return
+	}
+
+	f.lastFetch.warnings = append([]string(nil), warnings...)
+}
+
+// RecordFailure фиксирует неудачный опрос источника.
+func (f *Feed) RecordFailure(at time.Time, err error) {
+	f.lastFetch = LastFetch{status: FetchStatusError, err: err.Error(), at: at}
+}
@@ -0,0 +1,192 @@
+package feed
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestNewFeedURL(t *testing.T) {
+	tests := []struct {
+		name    string
+		input   string
+		wantErr error
+	}{
+		{name: "valid https", input: "https://habr.com/ru/rss/hub/go/all/?fl=ru"},
+		{name: "valid http", input: "http://example.com/feed.xml"},
+		{name: "empty", input: "", wantErr: ErrEmptyFeedURL},
+		{name: "relative", input: "/feed.xml", wantErr: ErrInvalidFeedURL},
+		{name: "unsupported scheme", input: "ftp://example.com/feed.xml", wantErr: ErrInvalidFeedURL},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				u, err := NewFeedURL(tt.input)
+				if tt.wantErr != nil {
+					if !errors.Is(err, tt.wantErr) {
+						t.Errorf("NewFeedURL() error = %v, want %v", err, tt.wantErr)
+					}
+					return
+				}
+
+				if err != nil {
+					t.Fatalf("NewFeedURL() unexpected error: %v", err)
+				}
+				if u.Value() != tt.input {
+					t.Errorf("Value() = %v, want %v", u.Value(), tt.input)
+				}
+			},
+		)
+	}
+}
+
+func TestNewFeedID(t *testing.T) {
+	if _, err := NewFeedID(0); !errors.Is(err, ErrInvalidFeedID) {
+		t.Errorf("expected ErrInvalidFeedID, got %v", err)
+	}
+
+	id, err := NewFeedID(7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if id.Value() != 7 {
+		t.Errorf("Value() = %d, want 7", id.Value())
+	}
+}
+
+func TestFeed_SetValidators(t *testing.T) {
+	f, err := NewFeed("https://example.com/rss")
+	if err != nil {
+		t.Fatalf("NewFeed() unexpected error: %v", err)
+	}
+
+	if f.ETag() != "" || f.LastModified() != "" {
+		t.Fatal("new feed must not have cache validators")
+	}
+
+	f.SetValidators(`"abc"`, "Wed, 21 Oct 2015 07:28:00 GMT")
+
+	if f.ETag() != `"abc"` {
+		t.Errorf("ETag() = %v, want %v", f.ETag(), `"abc"`)
+	}
+	if f.LastModified() != "Wed, 21 Oct 2015 07:28:00 GMT" {
+		t.Errorf("LastModified() = %v", f.LastModified())
+	}
+}
+
+func TestNewPollInterval(t *testing.T) {
+	if _, err := NewPollInterval(-1); !errors.Is(err, ErrInvalidPollInterval) {
+		t.Errorf("expected ErrInvalidPollInterval, got %v", err)
+	}
+	if _, err := NewPollInterval(MaxPollInterval + 1); !errors.Is(err, ErrInvalidPollInterval) {
+		t.Errorf("expected ErrInvalidPollInterval, got %v", err)
+	}
+
+	def, err := NewPollInterval(0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := def.Or(5 * time.Minute); got != 5*time.Minute {
+		t.Errorf("default interval Or() = %v, want 5m", got)
+	}
+
+	custom, _ := NewPollInterval(30)
+	if got := custom.Or(5 * time.Minute); got != 30*time.Minute {
+		t.Errorf("custom interval Or() = %v, want 30m", got)
+	}
+}
+
+func TestFeed_ChangeURL(t *testing.T) {
+	f, _ := NewFeed("https://example.com/rss")
+	f.SetValidators(`"abc"`, "Wed, 21 Oct 2015 07:28:00 GMT")
+
+	same, _ := NewFeedURL("https://example.com/rss")
+	f.ChangeURL(same)
+	if f.ETag() == "" {
+		t.Error("validators must be kept when URL is unchanged")
+	}
+
+	other, _ := NewFeedURL("https://example.com/atom")
+	f.ChangeURL(other)
+	if f.URL() != other {
+		t.Errorf("URL() = %v, want %v", f.URL().Value(), other.Value())
+	}
+	if f.ETag() != "" || f.LastModified() != "" {
+		t.Error("validators must be reset when URL changes")
+	}
+}
+
+func TestFeed_RecordFetch(t *testing.T) {
+	f, _ := NewFeed("https://example.com/rss")
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	f.RecordSuccess(now, 3, false)
+	f.RecordSuccess(now, 2, false)
+
+	if f.ItemCount() != 5 {
+		t.Errorf("ItemCount() = %d, want 5", f.ItemCount())
+	}
+	if f.LastFetch().Status() != FetchStatusOK {
+		t.Errorf("Status() = %v, want %v", f.LastFetch().Status(), FetchStatusOK)
+	}
+
+	f.RecordSuccess(now, 0, true)
+	if f.LastFetch().Status() != FetchStatusNotModified {
+		t.Errorf("Status() = %v, want %v", f.LastFetch().Status(), FetchStatusNotModified)
+	}
+
+	warnings := make([]string, MaxFetchWarnings+5)
+	for i := range warnings {
+		warnings[i] = fmt.Sprintf("item %d skipped", i)
+	}
+	f.RecordSuccess(now, 1, false)
+	f.RecordWarnings(warnings)
+	if got := f.LastFetch().Warnings(); len(got) != MaxFetchWarnings || got[0] != "item 0 skipped" {
+		t.Errorf("Warnings() = %v, want first %d warnings", got, MaxFetchWarnings)
+	}
+
+	f.RecordSuccess(now, 0, true)
+	if len(f.LastFetch().Warnings()) != 0 {
+		t.Errorf("warnings must be reset by next fetch, got %v", f.LastFetch().Warnings())
+	}
+
+	f.RecordFailure(now, errors.New("timeout"))
+	if f.LastFetch().Status() != FetchStatusError || f.LastFetch().Error() != "timeout" {
+		t.Errorf("unexpected last fetch after failure: %+v", f.LastFetch())
+	}
+}
+
+func TestRun_Finish(t *testing.T) {
+	id, _ := NewFeedID(1)
+	url, _ := NewFeedURL("https://example.com/rss")
+	started := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	run := NewRun(id, url, started)
+	run.SetHTTPStatus(200)
+	run.Succeed(started.Add(1500*time.Millisecond), RunCounts{Seen: 3, Inserted: 1, Skipped: 2}, false)
+
+	if run.Status() != FetchStatusOK || run.HTTPStatus() != 200 || run.Error() != "" {
+		t.Errorf("unexpected successful run: %+v", run)
+	}
+	if run.Duration() != 1500*time.Millisecond {
+		t.Errorf("Duration() = %v, want 1.5s", run.Duration())
+	}
+	if run.Counts() != (RunCounts{Seen: 3, Inserted: 1, Skipped: 2}) {
+		t.Errorf("Counts() = %+v", run.Counts())
+	}
+
+	notModified := NewRun(id, url, started)
+	notModified.Succeed(started, RunCounts{}, true)
+	if notModified.Status() != FetchStatusNotModified {
+		t.Errorf("Status() = %v, want %v", notModified.Status(), FetchStatusNotModified)
+	}
+
+	failed := NewRun(id, url, started)
+	failed.SetHTTPStatus(502)
+	failed.Fail(started.Add(time.Second), RunCounts{Seen: 3, Inserted: 1}, errors.New("bad gateway"))
+	if failed.Status() != FetchStatusError || failed.Error() != "bad gateway" || failed.Counts().Inserted != 1 {
+		t.Errorf("unexpected failed run: %+v", failed)
+	}
+}
@@ -0,0 +1,13 @@
+package feed
+
+// Repository представляет репозиторий для реализации.
+type Repository interface {
+	FeedStore
+	FeedFinder
+}
+
+// RunRepository представляет репозиторий журнала опросов для реализации.
+type RunRepository interface {
+	RunStore
+	RunFinder
+}
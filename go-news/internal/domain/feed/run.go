@@ -0,0 +1,109 @@
+package feed
+
+import "time"
+
+// RunCounts - количество записей ленты, обработанных за опрос.
+type RunCounts struct {
+	// Seen - записи, полученные из ленты.
+	Seen int
+	// Inserted - сохраненные новые новости.
+	Inserted int
+	// Skipped - записи, уже сохраненные ранее.
+	Skipped int
+}
+
+// Run - запись журнала опросов источника.
+type Run struct {
+	feedID     FeedID
+	url        FeedURL
+	startedAt  time.Time
+	finishedAt time.Time
+	status     FetchStatus
+	httpStatus int
+	counts     RunCounts
+	err        string
+}
+
+// NewRun создает запись о начатом опросе источника.
+func NewRun(feedID FeedID, url FeedURL, startedAt time.Time) *Run {
+	return &Run{feedID: feedID, url: url, startedAt: startedAt}
+}
+
+// RehydrateRun восстанавливает запись журнала опросов из БД.
+func RehydrateRun(
+	feedID FeedID, url FeedURL, startedAt, finishedAt time.Time, status FetchStatus, httpStatus int,
+	counts RunCounts, err string,
+) *Run {
+	return &Run{
+		feedID:     feedID,
+		url:        url,
+		startedAt:  startedAt,
+		finishedAt: finishedAt,
+		status:     status,
+		httpStatus: httpStatus,
+		counts:     counts,
+		err:        err,
+	}
+}
+
+// FeedID возвращает ID источника.
+func (r *Run) FeedID() FeedID { return r.feedID }
+
+// URL возвращает адрес ленты на момент опроса.
+func (r *Run) URL() FeedURL { return r.url }
+
+// StartedAt возвращает время начала опроса.
+func (r *Run) StartedAt() time.Time { return r.startedAt }
+
+// FinishedAt возвращает время окончания опроса.
+func (r *Run) FinishedAt() time.Time { return r.finishedAt }
+
+// Duration возвращает длительность опроса.
+func (r *Run) Duration() time.Duration { return r.finishedAt.Sub(r.startedAt) }
+
+// Status возвращает результат опроса.
+func (r *Run) Status() FetchStatus { return r.status }
+
+// HTTPStatus возвращает HTTP статус ответа источника, 0 - если ответ не получен.
+func (r *Run) HTTPStatus() int { return r.httpStatus }
+
+// Counts возвращает количество обработанных записей ленты.
+func (r *Run) Counts() RunCounts { return r.counts }
+
+// Error возвращает текст ошибки опроса.
+func (r *Run) Error() string { return r.err }
+
+// SetHTTPStatus устанавливает HTTP статус ответа источника.
+func (r *Run) SetHTTPStatus(code int) { r.httpStatus = code }
+
+// Succeed завершает опрос успешно.
+func (r *Run) Succeed(at time.Time, counts RunCounts, notModified bool) {
+	r.status = FetchStatusOK
+	if notModified {
+		r.status = FetchStatusNotModified
+	}
+
+	r.finishedAt = at
+	r.counts = counts
+}
+
+// Fail завершает опрос с ошибкой. Количество записей, обработанных до ошибки, сохраняется.
+func (r *Run) Fail(at time.Time, counts RunCounts, err error) {
+	r.status = FetchStatusError
+	r.finishedAt = at
+	r.counts = counts
+	r.err = err.Error()
+}
+
+// RunStats - сводка опросов источника за период.
+type RunStats struct {
+	FeedID FeedID
+	// Runs - количество опросов.
+	Runs int64
+	// Failed - количество опросов, завершившихся ошибкой.
+	Failed int64
+	// LastRunAt - время начала последнего опроса.
+	LastRunAt time.Time
+	// LastSuccessAt - время начала последнего успешного опроса, нулевое - если успешных не было.
+	LastSuccessAt time.Time
+}
@@ -0,0 +1,121 @@
+package feed
+
+import (
+	"net/url"
+	"time"
+)
+
+// FeedID - идентификатор источника.
+type FeedID struct {
+	value int32
+}
+
+// NewFeedID создает новый идентификатор источника.
+func NewFeedID(id int32) (FeedID, error) {
+	if id < 1 {
+		return FeedID{}, ErrInvalidFeedID
+	}
+	return FeedID{value: id}, nil
+}
+
+// Value возвращает значение идентификатора источника.
+func (f FeedID) Value() int32 { return f.value }
+
+// Equal сравнивает два идентификатора.
+func (f FeedID) Equal(other FeedID) bool { return f.value == other.value }
+
+// FeedURL - адрес ленты источника.
+type FeedURL struct {
+	value string
+}
+
+// NewFeedURL создает адрес ленты источника.
+func NewFeedURL(text string) (FeedURL, error) {
+	if len(text) == 0 {
+		return FeedURL{}, ErrEmptyFeedURL
+	}
+
+	u, err := url.ParseRequestURI(text)
+	if err != nil || u.Host == "" || (u.Scheme != "http" && u.Scheme != "https") {
+		return FeedURL{}, ErrInvalidFeedURL
+	}
+
+	return FeedURL{value: text}, nil
+}
+
+// Value возвращает значение адреса ленты.
+func (f FeedURL) Value() string { return f.value }
+
+// MaxPollInterval - максимально допустимый интервал опроса источника в минутах (сутки).
+const MaxPollInterval = 24 * 60
+
+// PollInterval - интервал опроса источника в минутах. Нулевое значение означает интервал по умолчанию.
+type PollInterval struct {
+	minutes int
+}
+
+// NewPollInterval создает интервал опроса источника.
+func NewPollInterval(minutes int) (PollInterval, error) {
+	if minutes < 0 || minutes > MaxPollInterval {
+		return PollInterval{}, ErrInvalidPollInterval
+	}
+
+	return PollInterval{minutes: minutes}, nil
+}
+
+// Minutes возвращает интервал в минутах.
+func (p PollInterval) Minutes() int { return p.minutes }
+
+// IsDefault сообщает, что для источника используется интервал по умолчанию.
+func (p PollInterval) IsDefault() bool { return p.minutes == 0 }
+
+// Or возвращает интервал как time.Duration либо fallback, если используется интервал по умолчанию.
+func (p PollInterval) Or(fallback time.Duration) time.Duration {
+	if p.IsDefault() {
+		return fallback
+	}
+
+	return time.Duration(p.minutes) * time.Minute
+}
+
+// FetchStatus - статус последнего опроса источника.
+type FetchStatus string
+
+const (
+	// FetchStatusNone - источник еще не опрашивался.
+	FetchStatusNone FetchStatus = ""
+	// FetchStatusOK - лента успешно получена и обработана.
+	FetchStatusOK FetchStatus = "ok"
+	// FetchStatusNotModified - лента не изменилась с прошлого опроса.
+	FetchStatusNotModified FetchStatus = "not_modified"
+	// FetchStatusError - опрос завершился ошибкой.
+	FetchStatusError FetchStatus = "error"
+)
+
+// MaxFetchWarnings - максимальное количество предупреждений, хранимых для последнего опроса.
+const MaxFetchWarnings = 10
+
+// LastFetch - результат последнего опроса источника.
+type LastFetch struct {
+	status   FetchStatus
+	err      string
+	warnings []string
+	at       time.Time
+}
+
+// RehydrateLastFetch восстанавливает результат последнего опроса из БД.
+func RehydrateLastFetch(status FetchStatus, err string, warnings []string, at time.Time) LastFetch {
+	return LastFetch{status: status, err: err, warnings: warnings, at: at}
+}
+
+// Status возвращает статус последнего опроса.
+func (l LastFetch) Status() FetchStatus { return l.status }
+
+// Error возвращает текст ошибки последнего опроса.
+func (l LastFetch) Error() string { return l.err }
+
+// Warnings возвращает предупреждения последнего опроса (например, пропущенные записи).
+func (l LastFetch) Warnings() []string { return l.warnings }
+
+// At возвращает время последнего опроса.
+func (l LastFetch) At() time.Time { return l.at }
@@ -0,0 +1,98 @@
+package post
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// MaxCategoryLength - максимальная длина рубрики в символах.
+	MaxCategoryLength = 32
+	// MaxPostCategories - максимальное количество рубрик у новости.
+	MaxPostCategories = 5
+)
+
+// PostCategory - тематическая рубрика новости (go, databases, devops...).
+// В отличие от тегов, рубрики назначаются сервисом, а не берутся из ленты.
+type PostCategory struct {
+	value string
+}
+
+// NewPostCategory создает рубрику: регистр приводится к нижнему, пробелы заменяются на дефис.
+// Допускаются буквы, цифры, дефис и подчеркивание; пустая рубрика или длиннее MaxCategoryLength невалидна.
+func NewPostCategory(value string) (PostCategory, error) {
+	value = strings.ToLower(strings.Join(strings.Fields(value), "-"))
+
+	if value == "" || utf8.RuneCountInString(value) > MaxCategoryLength {
+		return PostCategory{}, ErrInvalidPostCategory
+	}
+	for _, r := range value {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) && r != '-' && r != '_' {
+			return PostCategory{}, ErrInvalidPostCategory
+		}
+	}
+
+	return PostCategory{value}, nil
+}
+
+// Value возвращает значение рубрики.
+func (c PostCategory) Value() string { return c.value }
+
+// IsZero сообщает, что рубрика не задана.
+func (c PostCategory) IsZero() bool { return c.value == "" }
+
+// NewPostCategories создает рубрики новости. Невалидные значения и повторы пропускаются,
+// рубрик остается не больше MaxPostCategories.
+func NewPostCategories(values []string) []PostCategory {
+	var categories []PostCategory
+	seen := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		category, err := NewPostCategory(value)
+		if err != nil {
+			continue
+		}
+		if _, ok := seen[category.value]; ok {
+			continue
+		}
+
+		seen[category.value] = struct{}{}
+		categories = append(categories, category)
+		if len(categories) == MaxPostCategories {
+			break
+		}
+	}
+
+	return categories
+}
+
+// ParsePostCategories создает рубрики, проставленные вручную: в отличие от NewPostCategories,
+// невалидное значение или превышение MaxPostCategories - ошибка. Повторы объединяются.
+func ParsePostCategories(values []string) ([]PostCategory, error) {
+	categories := make([]PostCategory, 0, len(values))
+	seen := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		category, err := NewPostCategory(value)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[category.value]; ok {
+			continue
+		}
+
+		seen[category.value] = struct{}{}
+		categories = append(categories, category)
+	}
+
+	if len(categories) > MaxPostCategories {
+		return nil, ErrInvalidPostCategory
+	}
+
+	return categories, nil
+}
+
+// CategoryCount - рубрика и количество новостей в ней.
+type CategoryCount struct {
+	Category PostCategory
+	Count    int64
+}
@@ -0,0 +1,70 @@
+package post
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNewPostCategory(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr error
+	}{
+		{value: "Go", want: "go"},
+		{value: "  Machine   Learning ", want: "machine-learning"},
+		{value: "базы_данных", want: "базы_данных"},
+		{value: "   ", wantErr: ErrInvalidPostCategory},
+		{value: "c++", wantErr: ErrInvalidPostCategory},
+		{value: strings.Repeat("я", MaxCategoryLength+1), wantErr: ErrInvalidPostCategory},
+	}
+
+	for _, tt := range tests {
+		category, err := NewPostCategory(tt.value)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("NewPostCategory(%q) error = %v, want %v", tt.value, err, tt.wantErr)
+		}
+		if category.Value() != tt.want {
+			t.Errorf("NewPostCategory(%q) = %q, want %q", tt.value, category.Value(), tt.want)
+		}
+	}
+}
+
+func TestNewPostCategories(t *testing.T) {
+	categories := NewPostCategories([]string{"Go", "go", "c++", "DevOps"})
+	if len(categories) != 2 || categories[0].Value() != "go" || categories[1].Value() != "devops" {
+		t.Errorf("unexpected categories: %+v", categories)
+	}
+
+	values := make([]string, MaxPostCategories+3)
+	for i := range values {
+		values[i] = strings.Repeat("c", i+1)
+	}
+	if got := len(NewPostCategories(values)); got != MaxPostCategories {
+		t.Errorf("expected %d categories, got %d", MaxPostCategories, got)
+	}
+}
+
+func TestParsePostCategories(t *testing.T) {
+	categories, err := ParsePostCategories([]string{"Go", "go", "DevOps"})
+	if err != nil || len(categories) != 2 {
+		t.Errorf("unexpected result: %+v, %v", categories, err)
+	}
+
+	if _, err = ParsePostCategories([]string{"go", "c++"}); !errors.Is(err, ErrInvalidPostCategory) {
+		t.Errorf("expected ErrInvalidPostCategory, got %v", err)
+	}
+
+	values := make([]string, MaxPostCategories+1)
+	for i := range values {
+		values[i] = strings.Repeat("c", i+1)
+	}
+	if _, err = ParsePostCategories(values); !errors.Is(err, ErrInvalidPostCategory) {
+		t.Errorf("expected ErrInvalidPostCategory for too many categories, got %v", err)
+	}
+
+	if categories, err = ParsePostCategories(nil); err != nil || len(categories) != 0 {
+		t.Errorf("expected empty categories, got %+v, %v", categories, err)
+	}
+}
@@ -6,16 +6,59 @@ import "context"
 type PostStore interface {
 	// Store сохраняет новость.
 	Store(ctx context.Context, post *Post) error
+	// StoreIfNotExists сохраняет новость, если новости с таким же ключом или ссылкой еще нет.
+	// Возвращает true, если новость была добавлена.
+	StoreIfNotExists(ctx context.Context, post *Post) (bool, error)
+}
+
+// PostCommentsCounter определяет контракт учета опубликованных комментариев к новостям.
+type PostCommentsCounter interface {
+	// AddComment учитывает опубликованный комментарий к новости. Повторный учет того же комментария
+	// не меняет счетчик, возвращает false.
+	AddComment(ctx context.Context, postID PostID, commentID int64) (bool, error)
+}
+
+// PostTagCounter определяет контракт подсчета тегов новостей.
+type PostTagCounter interface {
+	// CountTags возвращает до limit самых частых тегов с количеством новостей,
+	// упорядоченных по убыванию количества, при равенстве - по тегу.
+	CountTags(ctx context.Context, limit int) ([]TagCount, error)
 }
 
 // PostFinder определяет контракт получения новостей.
 type PostFinder interface {
 	// FindByID получает новость по ID.
 	FindByID(ctx context.Context, postID PostID) (*Post, error)
+	// FindByLink получает новость по ссылке на источник.
+	FindByLink(ctx context.Context, link PostLink) (*Post, error)
+	// ExistsByKey проверяет, есть ли новость с таким ключом дедупликации или ссылкой (с учетом нормализации).
+	ExistsByKey(ctx context.Context, key PostKey, link PostLink) (bool, error)
 	// FindLast получает последнюю новость.
 	FindLast(ctx context.Context) (*Post, error)
 	// FindLatest получает последние n новостей.
 	FindLatest(ctx context.Context, limit int) ([]*Post, error)
-	// FindAll получает все новости.
-	FindAll(ctx context.Context, search string, limit, offset int) ([]*Post, int32, error)
+	// FindAll получает новости, подходящие под фильтр, и их общее количество.
+	FindAll(ctx context.Context, filter PostFilter, limit, offset int) ([]*Post, int32, error)
+	// FindByCursor получает до limit новостей, подходящих под фильтр, следующих за курсором
+	// в его направлении. Новости возвращаются в порядке ленты (от свежих к старым), без подсчета общего количества.
+	FindByCursor(ctx context.Context, filter PostFilter, cursor PostCursor, limit int) ([]*Post, error)
+}
+
+// PostSimilarFinder определяет контракт поиска почти одинаковых новостей.
+type PostSimilarFinder interface {
+	// FindSimilar получает новости, опубликованные в интервале pubTime, отпечаток которых совпадает
+	// с fingerprint хотя бы в одной части (см. PostFingerprint.Bands). Это кандидаты: расстояние
+	// между отпечатками проверяется вызывающей стороной.
+	FindSimilar(ctx context.Context, fingerprint PostFingerprint, pubTime PubTimeRange) ([]*Post, error)
+}
+
+// PostCategoryStore определяет контракт рубрик новостей.
+type PostCategoryStore interface {
+	// LabelCategories сохраняет рубрики новости, проставленные вручную.
+	LabelCategories(ctx context.Context, postID PostID, categories []PostCategory) error
+	// FindLabeled получает до limit новостей с рубриками, проставленными вручную, начиная с последних.
+	FindLabeled(ctx context.Context, limit int) ([]*Post, error)
+	// CountCategories возвращает рубрики с количеством новостей, упорядоченные по убыванию количества,
+	// при равенстве - по рубрике.
+	CountCategories(ctx context.Context) ([]CategoryCount, error)
 }
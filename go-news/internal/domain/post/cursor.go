@@ -0,0 +1,47 @@
+package post
+
+// CursorDirection - направление листания относительно курсора.
+type CursorDirection int
+
+const (
+	// CursorNext - более старые новости (следующая страница ленты).
+	CursorNext CursorDirection = iota
+	// CursorPrev - более свежие новости (предыдущая страница ленты).
+	CursorPrev
+)
+
+// PostCursor - позиция в ленте новостей для keyset-пагинации по (pub_time, id).
+// Новости в ленте упорядочены по убыванию даты публикации, при равной дате - по убыванию ID.
+type PostCursor struct {
+	pubTime   int64
+	id        PostID
+	direction CursorDirection
+}
+
+// NewPostCursor создает курсор на позиции новости с указанными датой публикации и ID.
+func NewPostCursor(pubTime int64, id int32, direction CursorDirection) (PostCursor, error) {
+	postID, err := NewPostID(id)
+	if err != nil {
+		return PostCursor{}, err
+	}
+
+	if direction != CursorNext && direction != CursorPrev {
+		return PostCursor{}, ErrInvalidCursor
+	}
+
+	return PostCursor{pubTime: pubTime, id: postID, direction: direction}, nil
+}
+
+// CursorFromPost создает курсор на позиции новости.
+func CursorFromPost(post *Post, direction CursorDirection) PostCursor {
+	return PostCursor{pubTime: post.PubTime().Time().Unix(), id: post.ID(), direction: direction}
+}
+
+// PubTime возвращает дату публикации новости на позиции курсора в секундах.
+func (c PostCursor) PubTime() int64 { return c.pubTime }
+
+// ID возвращает ID новости на позиции курсора.
+func (c PostCursor) ID() PostID { return c.id }
+
+// Direction возвращает направление листания.
+func (c PostCursor) Direction() CursorDirection { return c.direction }
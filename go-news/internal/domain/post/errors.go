@@ -13,6 +13,26 @@ var (
 	ErrEmptyPostLink = errors.New("empty Post link")
 	// ErrEmptyPubTime представляет ошибку незаполненной даты публикации новости.
 	ErrEmptyPubTime = errors.New("empty Publication time")
+	// ErrEmptySourceURL представляет ошибку незаполненного адреса ленты источника новости.
+	ErrEmptySourceURL = errors.New("empty Post source URL")
+	// ErrInvalidPostSort представляет ошибку неизвестного порядка сортировки новостей.
+	ErrInvalidPostSort = errors.New("invalid Post sort")
+	// ErrInvalidPubTimeRange представляет ошибку невалидного интервала дат публикации.
+	ErrInvalidPubTimeRange = errors.New("invalid Publication time range")
+	// ErrInvalidCommentsFilter представляет ошибку неизвестного значения отбора по комментариям.
+	ErrInvalidCommentsFilter = errors.New("invalid Post comments filter")
+	// ErrInvalidCursor представляет ошибку невалидного курсора пагинации.
+	ErrInvalidCursor = errors.New("invalid Post cursor")
+	// ErrCursorSort представляет ошибку keyset-пагинации при сортировке по релевантности.
+	ErrCursorSort = errors.New("cursor pagination supports date sort only")
+	// ErrInvalidContentFormat представляет ошибку неизвестного формата содержания новости.
+	ErrInvalidContentFormat = errors.New("invalid Post content format")
+	// ErrInvalidMediaURL представляет ошибку невалидного адреса медиа-вложения.
+	ErrInvalidMediaURL = errors.New("invalid Post media URL")
+	// ErrInvalidPostTag представляет ошибку пустого или слишком длинного тега новости.
+	ErrInvalidPostTag = errors.New("invalid Post tag")
+	// ErrInvalidPostCategory представляет ошибку невалидной рубрики новости.
+	ErrInvalidPostCategory = errors.New("invalid Post category")
 	// ErrPostNotFound представляет ошибку ненайденного поста.
 	ErrPostNotFound = errors.New("post not found")
 )
@@ -0,0 +1,108 @@
+package post
+
+import (
+	"strconv"
+	"time"
+)
+
+// PostSort - порядок сортировки новостей.
+type PostSort string
+
+const (
+	// SortByDate - сначала свежие новости.
+	SortByDate PostSort = "date"
+	// SortByRelevance - сначала наиболее релевантные поисковому запросу новости.
+	// Без поискового запроса новости сортируются по дате.
+	SortByRelevance PostSort = "relevance"
+)
+
+// NewPostSort создает порядок сортировки. Пустое значение означает сортировку по умолчанию:
+// по релевантности при наличии поискового запроса, иначе по дате.
+func NewPostSort(value string, hasSearch bool) (PostSort, error) {
+	switch PostSort(value) {
+	case "":
+		if hasSearch {
+			return SortByRelevance, nil
+		}
+		return SortByDate, nil
+	case SortByDate, SortByRelevance:
+		return PostSort(value), nil
+	default:
+		return "", ErrInvalidPostSort
+	}
+}
+
+// PubTimeRange - интервал дат публикации, границы включаются. Нулевая граница означает отсутствие ограничения.
+type PubTimeRange struct {
+	from time.Time
+	to   time.Time
+}
+
+// NewPubTimeRange создает интервал дат публикации.
+func NewPubTimeRange(from, to time.Time) (PubTimeRange, error) {
+	if !from.IsZero() && !to.IsZero() && to.Before(from) {
+		return PubTimeRange{}, ErrInvalidPubTimeRange
+	}
+
+	return PubTimeRange{from: from, to: to}, nil
+}
+
+// From возвращает нижнюю границу интервала.
+func (r PubTimeRange) From() time.Time { return r.from }
+
+// To возвращает верхнюю границу интервала.
+func (r PubTimeRange) To() time.Time { return r.to }
+
+// IsZero сообщает, что интервал не ограничен.
+func (r PubTimeRange) IsZero() bool { return r.from.IsZero() && r.to.IsZero() }
+
+// CommentsFilter - отбор новостей по наличию опубликованных комментариев.
+type CommentsFilter string
+
+const (
+	// CommentsAny - без отбора по комментариям.
+	CommentsAny CommentsFilter = ""
+	// CommentsWith - только новости с комментариями.
+	CommentsWith CommentsFilter = "with"
+	// CommentsWithout - только новости без комментариев.
+	CommentsWithout CommentsFilter = "without"
+)
+
+// NewCommentsFilter создает отбор по комментариям из булевого значения ("true", "false", "1", "0").
+// Пустое значение означает отсутствие отбора.
+func NewCommentsFilter(value string) (CommentsFilter, error) {
+	if value == "" {
+		return CommentsAny, nil
+	}
+
+	has, err := strconv.ParseBool(value)
+	if err != nil {
+		return "", ErrInvalidCommentsFilter
+	}
+
+	if has {
+		return CommentsWith, nil
+	}
+
+	return CommentsWithout, nil
+}
+
+// PostFilter - параметры отбора новостей.
+type PostFilter struct {
+	// Search - полнотекстовый поисковый запрос по заголовку и содержанию.
+	Search string
+	// Source - адрес ленты или ссылка на сайт источника.
+	Source string
+	// PubTime - интервал дат публикации.
+	PubTime PubTimeRange
+	// Comments - отбор по наличию комментариев.
+	Comments CommentsFilter
+	// Tag - отбор по тегу.
+	Tag PostTag
+	// Category - отбор по рубрике.
+	Category PostCategory
+	// Sort - порядок сортировки результатов.
+	Sort PostSort
+	// Collapse - оставить от каждого кластера почти одинаковых новостей одну, первую в порядке сортировки.
+	Collapse bool
+}
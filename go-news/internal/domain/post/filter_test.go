@@ -0,0 +1,78 @@
+package post
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestNewPostSort(t *testing.T) {
+	testCases := []struct {
+		value     string
+		hasSearch bool
+		want      PostSort
+		wantErr   error
+	}{
+		{value: "", hasSearch: false, want: SortByDate},
+		{value: "", hasSearch: true, want: SortByRelevance},
+		{value: "date", hasSearch: true, want: SortByDate},
+		{value: "relevance", hasSearch: false, want: SortByRelevance},
+		{value: "popularity", wantErr: ErrInvalidPostSort},
+	}
+
+	for _, tc := range testCases {
+		got, err := NewPostSort(tc.value, tc.hasSearch)
+		if !errors.Is(err, tc.wantErr) {
+			t.Errorf("NewPostSort(%q, %v) error = %v, want %v", tc.value, tc.hasSearch, err, tc.wantErr)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("NewPostSort(%q, %v) = %q, want %q", tc.value, tc.hasSearch, got, tc.want)
+		}
+	}
+}
+
+func TestNewPubTimeRange(t *testing.T) {
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := from.Add(7 * 24 * time.Hour)
+
+	if _, err := NewPubTimeRange(from, to); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := NewPubTimeRange(from, time.Time{}); err != nil {
+		t.Errorf("open range: unexpected error: %v", err)
+	}
+	if _, err := NewPubTimeRange(to, from); !errors.Is(err, ErrInvalidPubTimeRange) {
+		t.Errorf("expected ErrInvalidPubTimeRange, got %v", err)
+	}
+
+	r, _ := NewPubTimeRange(time.Time{}, time.Time{})
+	if !r.IsZero() {
+		t.Error("expected empty range to be zero")
+	}
+}
+
+func TestNewCommentsFilter(t *testing.T) {
+	testCases := []struct {
+		value   string
+		want    CommentsFilter
+		wantErr error
+	}{
+		{value: "", want: CommentsAny},
+		{value: "true", want: CommentsWith},
+		{value: "1", want: CommentsWith},
+		{value: "false", want: CommentsWithout},
+		{value: "yes", wantErr: ErrInvalidCommentsFilter},
+	}
+
+	for _, tc := range testCases {
+		got, err := NewCommentsFilter(tc.value)
+		if !errors.Is(err, tc.wantErr) {
+			t.Errorf("NewCommentsFilter(%q) error = %v, want %v", tc.value, err, tc.wantErr)
+			continue
+		}
+		if got != tc.want {
+			t.Errorf("NewCommentsFilter(%q) = %q, want %q", tc.value, got, tc.want)
+		}
+	}
+}
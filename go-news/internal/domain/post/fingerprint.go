@@ -0,0 +1,158 @@
+package post
+
+import (
+	"hash/fnv"
+	"math/bits"
+	"strings"
+	"time"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// MaxFingerprintDistance - максимальное расстояние Хэмминга между отпечатками почти одинаковых новостей.
+	MaxFingerprintDistance = 6
+	// FingerprintBands - количество частей отпечатка для поиска кандидатов. Отпечатки с расстоянием
+	// не больше MaxFingerprintDistance совпадают хотя бы в одной части, поэтому частей должно быть больше.
+	FingerprintBands = 8
+	// ClusterWindow - максимальная разница дат публикации новостей одного кластера.
+	ClusterWindow = 48 * time.Hour
+
+	// minFingerprintTokens - минимальное количество слов, по которому строится отпечаток:
+	// по более коротким текстам отпечатки случайно совпадают слишком часто.
+	minFingerprintTokens = 4
+	// maxFingerprintTokens - количество первых слов текста, учитываемых в отпечатке.
+	maxFingerprintTokens = 200
+	// minTokenLength - слова короче (предлоги, союзы) не учитываются.
+	minTokenLength = 3
+)
+
+// PostFingerprint - отпечаток SimHash нормализованных заголовка и содержания новости.
+// У почти одинаковых текстов отпечатки отличаются в небольшом количестве бит.
+type PostFingerprint struct {
+	value uint64
+}
+
+// NewPostFingerprint вычисляет отпечаток новости. Текст приводится к нижнему регистру и разбивается
+// на слова, признаками служат слова и пары соседних слов. Для слишком короткого текста
+// возвращается нулевой отпечаток - такая новость не объединяется с другими.
+func NewPostFingerprint(title, content string) PostFingerprint {
+	tokens := fingerprintTokens(title+"\n"+content, maxFingerprintTokens)
+	if len(tokens) < minFingerprintTokens {
+		return PostFingerprint{}
+	}
+
+	var weights [64]int
+	for i, token := range tokens {
+		addFeature(&weights, token)
+		if i > 0 {
+			addFeature(&weights, tokens[i-1]+" "+token)
+		}
+	}
+
+	var value uint64
+	for i, w := range weights {
+		if w > 0 {
+			value |= 1 << uint(i)
+		}
+	}
+
+	return PostFingerprint{value: value}
+}
+
+// RehydratePostFingerprint восстанавливает отпечаток из БД.
+func RehydratePostFingerprint(value uint64) PostFingerprint {
+	return PostFingerprint{value: value}
+}
+
+// Value возвращает значение отпечатка.
+func (f PostFingerprint) Value() uint64 { return f.value }
+
+// IsZero сообщает, что отпечаток не вычислен.
+func (f PostFingerprint) IsZero() bool { return f.value == 0 }
+
+// Distance возвращает расстояние Хэмминга между отпечатками.
+func (f PostFingerprint) Distance(other PostFingerprint) int {
+	return bits.OnesCount64(f.value ^ other.value)
+}
+
+// Similar сообщает, что отпечатки принадлежат почти одинаковым новостям.
+func (f PostFingerprint) Similar(other PostFingerprint) bool {
+	return !f.IsZero() && !other.IsZero() && f.Distance(other) <= MaxFingerprintDistance
+}
+
+// Bands возвращает части отпечатка для поиска кандидатов по точному совпадению.
+// Номер части входит в значение, чтобы одинаковые биты в разных частях не совпадали.
+func (f PostFingerprint) Bands() []int64 {
+	const width = 64 / FingerprintBands
+
+	bands := make([]int64, 0, FingerprintBands)
+	for i := range FingerprintBands {
+		part := (f.value >> uint(i*width)) & (1<<width - 1)
+		bands = append(bands, int64(i)<<width|int64(part))
+	}
+
+	return bands
+}
+
+// ClosestPost возвращает из кандидатов новость, наиболее похожую по отпечатку,
+// если расстояние до нее не больше MaxFingerprintDistance.
+func ClosestPost(fingerprint PostFingerprint, candidates []*Post) (*Post, bool) {
+	var closest *Post
+	best := MaxFingerprintDistance + 1
+	for _, candidate := range candidates {
+		if !fingerprint.Similar(candidate.Fingerprint()) {
+			continue
+		}
+		if d := fingerprint.Distance(candidate.Fingerprint()); d < best {
+			closest, best = candidate, d
+		}
+	}
+
+	return closest, closest != nil
+}
+
+// ClusterPubTimeRange возвращает интервал дат публикации новостей, с которыми может быть объединена
+// новость, опубликованная в pubTime.
+func ClusterPubTimeRange(pubTime PubTime) PubTimeRange {
+	t := pubTime.Time()
+	return PubTimeRange{from: t.Add(-ClusterWindow), to: t.Add(ClusterWindow)}
+}
+
+// fingerprintTokens разбивает текст на нормализованные слова, короткие слова пропускаются.
+func fingerprintTokens(text string, limit int) []string {
+	words := strings.FieldsFunc(
+		strings.ToLower(text), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		},
+	)
+
+	tokens := make([]string, 0, min(len(words), limit))
+	for _, word := range words {
+		if utf8.RuneCountInString(word) < minTokenLength {
+			continue
+		}
+		// Ё и е в новостях взаимозаменяемы.
+		tokens = append(tokens, strings.ReplaceAll(word, "ё", "е"))
+		if len(tokens) == limit {
+			break
+		}
+	}
+
+	return tokens
+}
+
+// addFeature добавляет хэш признака к весам бит отпечатка.
+func addFeature(weights *[64]int, feature string) {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(feature))
+	sum := h.Sum64()
+
+	for i := range weights {
+		if sum&(1<<uint(i)) != 0 {
+			weights[i]++
+		} else {
+			weights[i]--
+		}
+	}
+}
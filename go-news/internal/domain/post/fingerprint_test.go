@@ -0,0 +1,115 @@
+package post
+
+import "testing"
+
+const fingerprintContent = "Компания представила новый смартфон с увеличенным аккумулятором и улучшенной камерой. " +
+	"Продажи стартуют в следующем месяце, цена пока не объявлена. Аналитики ожидают высокий спрос на устройство."
+
+func TestNewPostFingerprint(t *testing.T) {
+	base := NewPostFingerprint("Apple представила iPhone 17 с увеличенным аккумулятором", fingerprintContent)
+	if base.IsZero() {
+		t.Fatal("expected non-zero fingerprint")
+	}
+
+	tests := []struct {
+		name    string
+		title   string
+		content string
+		similar bool
+	}{
+		{"same text", "Apple представила iPhone 17 с увеличенным аккумулятором", fingerprintContent, true},
+		{
+			"case and punctuation", "APPLE ПРЕДСТАВИЛА iPhone-17 с увеличенным аккумулятором!",
+			fingerprintContent, true,
+		},
+		{
+			"word added to title", "Apple представила новый iPhone 17 с увеличенным аккумулятором",
+			fingerprintContent, true,
+		},
+		{
+			"sentence added to content", "Apple представила iPhone 17 с увеличенным аккумулятором",
+			fingerprintContent + " Подробнее на сайте.", true,
+		},
+		{
+			"different story", "Центробанк сохранил ключевую ставку на прежнем уровне",
+			"Совет директоров Банка России принял решение сохранить ключевую ставку, сообщила пресс-служба.", false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				fp := NewPostFingerprint(tt.title, tt.content)
+				if got := base.Similar(fp); got != tt.similar {
+					t.Errorf("Similar() = %v, want %v (distance %d)", got, tt.similar, base.Distance(fp))
+				}
+			},
+		)
+	}
+}
+
+func TestNewPostFingerprint_ShortText(t *testing.T) {
+	fp := NewPostFingerprint("Новость", "и в на")
+	if !fp.IsZero() {
+		t.Errorf("expected zero fingerprint for short text, got %x", fp.Value())
+	}
+	if fp.Similar(fp) {
+		t.Error("zero fingerprint must not be similar to anything")
+	}
+}
+
+func TestPostFingerprint_Bands(t *testing.T) {
+	a := RehydratePostFingerprint(0x0123456789abcdef)
+	// Отличие в 3 битах разных частей: остальные части совпадают.
+	b := RehydratePostFingerprint(0x0123456789abcdef ^ (1 | 1<<20 | 1<<63))
+
+	bandsA, bandsB := a.Bands(), b.Bands()
+	if len(bandsA) != FingerprintBands {
+		t.Fatalf("expected %d bands, got %d", FingerprintBands, len(bandsA))
+	}
+
+	var equal int
+	for i := range bandsA {
+		if bandsA[i] == bandsB[i] {
+			equal++
+		}
+	}
+	if equal != FingerprintBands-3 {
+		t.Errorf("expected %d equal bands, got %d", FingerprintBands-3, equal)
+	}
+
+	// Одинаковые биты в разных частях не должны давать одинаковые значения.
+	zero := RehydratePostFingerprint(0).Bands()
+	if zero[0] == zero[1] {
+		t.Error("expected band index to be part of band value")
+	}
+}
+
+func TestClosestPost(t *testing.T) {
+	fp := RehydratePostFingerprint(0xff00ff00ff00ff00)
+
+	far := &Post{fingerprint: RehydratePostFingerprint(0x00ff00ff00ff00ff), clusterID: 1}
+	near := &Post{fingerprint: RehydratePostFingerprint(0xff00ff00ff00ff07), clusterID: 2}
+	nearest := &Post{fingerprint: RehydratePostFingerprint(0xff00ff00ff00ff01), clusterID: 3}
+
+	got, ok := ClosestPost(fp, []*Post{far, near, nearest})
+	if !ok || got != nearest {
+		t.Fatalf("expected nearest post, got %v, %v", got, ok)
+	}
+
+	if _, ok = ClosestPost(fp, []*Post{far}); ok {
+		t.Error("expected no similar post")
+	}
+}
+
+func TestClusterPubTimeRange(t *testing.T) {
+	pubTime, _ := NewFromUnixSeconds(1700000000)
+	r := ClusterPubTimeRange(pubTime)
+
+	if !r.From().Equal(pubTime.Time().Add(-ClusterWindow)) || !r.To().Equal(pubTime.Time().Add(ClusterWindow)) {
+		t.Errorf("unexpected range %v - %v", r.From(), r.To())
+	}
+	if r.To().Sub(r.From()) != 2*ClusterWindow {
+		t.Errorf("expected window %v, got %v", 2*ClusterWindow, r.To().Sub(r.From()))
+	}
+}
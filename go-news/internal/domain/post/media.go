@@ -0,0 +1,87 @@
+package post
+
+import (
+	"net/url"
+	"strings"
+)
+
+// PostMedia - медиа-вложение новости: файл из enclosure, media:content, media:thumbnail
+// или изображение из описания записи.
+type PostMedia struct {
+	url       string
+	mimeType  string
+	size      int64
+	width     int
+	height    int
+	thumbnail bool
+}
+
+// NewPostMedia создает медиа-вложение. Адрес должен быть абсолютной http(s) ссылкой,
+// отрицательные размеры приводятся к нулю (неизвестно).
+func NewPostMedia(link, mimeType string, size int64, width, height int, thumbnail bool) (PostMedia, error) {
+	link = strings.TrimSpace(link)
+	u, err := url.Parse(link)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return PostMedia{}, ErrInvalidMediaURL
+	}
+
+	return PostMedia{
+		url:       link,
+		mimeType:  strings.ToLower(strings.TrimSpace(mimeType)),
+		size:      max(size, 0),
+		width:     max(width, 0),
+		height:    max(height, 0),
+		thumbnail: thumbnail,
+	}, nil
+}
+
+// URL возвращает адрес файла.
+func (m PostMedia) URL() string { return m.url }
+
+// MIMEType возвращает MIME-тип файла, если он известен.
+func (m PostMedia) MIMEType() string { return m.mimeType }
+
+// Size возвращает размер файла в байтах, 0 - неизвестен.
+func (m PostMedia) Size() int64 { return m.size }
+
+// Width возвращает ширину изображения или видео в пикселях, 0 - неизвестна.
+func (m PostMedia) Width() int { return m.width }
+
+// Height возвращает высоту изображения или видео в пикселях, 0 - неизвестна.
+func (m PostMedia) Height() int { return m.height }
+
+// Thumbnail сообщает, что вложение является миниатюрой для карточки новости.
+func (m PostMedia) Thumbnail() bool { return m.thumbnail }
+
+// uniqueMedia убирает повторяющиеся по адресу вложения, сохраняя порядок.
+// Из дубликатов остается первое вложение, недостающие тип и размеры дополняются из остальных.
+func uniqueMedia(media []PostMedia) []PostMedia {
+	if len(media) == 0 {
+		return nil
+	}
+
+	out := make([]PostMedia, 0, len(media))
+	index := make(map[string]int, len(media))
+	for _, m := range media {
+		i, ok := index[m.url]
+		if !ok {
+			index[m.url] = len(out)
+			out = append(out, m)
+			continue
+		}
+
+		existing := &out[i]
+		if existing.mimeType == "" {
+			existing.mimeType = m.mimeType
+		}
+		if existing.size == 0 {
+			existing.size = m.size
+		}
+		if existing.width == 0 && existing.height == 0 {
+			existing.width, existing.height = m.width, m.height
+		}
+		existing.thumbnail = existing.thumbnail || m.thumbnail
+	}
+
+	return out
+}
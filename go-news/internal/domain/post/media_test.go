@@ -0,0 +1,48 @@
+package post
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestNewPostMedia(t *testing.T) {
+	media, err := NewPostMedia(" https://example.com/a.mp3 ", "Audio/MPEG", 1024, -1, 0, false)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if media.URL() != "https://example.com/a.mp3" || media.MIMEType() != "audio/mpeg" || media.Size() != 1024 {
+		t.Errorf("unexpected media: %+v", media)
+	}
+	if media.Width() != 0 {
+		t.Errorf("expected negative width to be reset, got %d", media.Width())
+	}
+
+	for _, link := range []string{"", "/a.png", "data:image/png;base64,AAAA", "ftp://example.com/a.png"} {
+		if _, err = NewPostMedia(link, "", 0, 0, 0, false); !errors.Is(err, ErrInvalidMediaURL) {
+			t.Errorf("NewPostMedia(%q) error = %v, want %v", link, err, ErrInvalidMediaURL)
+		}
+	}
+}
+
+func TestPost_SetMedia(t *testing.T) {
+	post, _ := NewPost("Title", "Content", "https://example.com", 1700000000)
+
+	image, _ := NewPostMedia("https://example.com/1.png", "image/png", 0, 0, 0, false)
+	thumb, _ := NewPostMedia("https://example.com/1.png", "", 2048, 320, 240, true)
+	video, _ := NewPostMedia("https://example.com/1.mp4", "video/mp4", 0, 0, 0, false)
+
+	post.SetMedia([]PostMedia{image, video, thumb})
+
+	media := post.Media()
+	if len(media) != 2 {
+		t.Fatalf("expected 2 media, got %d", len(media))
+	}
+	if media[0].URL() != image.URL() || media[1].URL() != video.URL() {
+		t.Errorf("expected original order, got %+v", media)
+	}
+
+	merged := media[0]
+	if merged.MIMEType() != "image/png" || merged.Size() != 2048 || merged.Width() != 320 || !merged.Thumbnail() {
+		t.Errorf("expected duplicate to be merged, got %+v", merged)
+	}
+}
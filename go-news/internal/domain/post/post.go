@@ -10,8 +10,29 @@ type Post struct {
 	id      PostID
 	title   PostTitle
 	content PostContent
+	// contentHTML - содержание в виде очищенного HTML, content - то же содержание простым текстом.
+	contentHTML PostContentHTML
+	// summary - краткое содержание для списков новостей.
+	summary PostSummary
 	pubTime PubTime
 	link    PostLink
+	key     PostKey
+	source  PostSource
+	article PostArticle
+	media   []PostMedia
+	tags    []PostTag
+	// categories - тематические рубрики, categoriesLabeled - рубрики проставлены вручную
+	// и служат примерами для обучения рубрикатора.
+	categories        []PostCategory
+	categoriesLabeled bool
+	// commentsCount - количество опубликованных комментариев.
+	commentsCount int64
+	// fingerprint - отпечаток текста для поиска почти одинаковых новостей.
+	fingerprint PostFingerprint
+	// clusterID - ID первой новости кластера почти одинаковых новостей.
+	clusterID int32
+	// clusterSize - количество новостей кластера в выдаче, заполняется только при схлопывании кластеров.
+	clusterSize int64
 }
 
 // NewPost создает новую новость.
@@ -36,11 +57,18 @@ func NewPost(title, content, link string, pubTime int64) (*Post, error) {
 		return nil, fmt.Errorf("NewPost.NewPubTime: %w", err)
 	}
 
+	postKey, err := NewPostKey("", link)
+	if err != nil {
+		return nil, fmt.Errorf("NewPost.NewPostKey: %w", err)
+	}
+
 	return &Post{
-		title:   postTitle,
-		content: postContent,
-		pubTime: postPubTime,
-		link:    postLink,
+		title:       postTitle,
+		content:     postContent,
+		pubTime:     postPubTime,
+		link:        postLink,
+		key:         postKey,
+		fingerprint: NewPostFingerprint(title, content),
 	}, nil
 }
 
@@ -53,12 +81,52 @@ func (p *Post) Title() PostTitle { return p.title }
 // Content возвращает содержимое новости.
 func (p *Post) Content() PostContent { return p.content }
 
+// ContentHTML возвращает содержимое новости в виде очищенного HTML.
+func (p *Post) ContentHTML() PostContentHTML { return p.contentHTML }
+
+// Summary возвращает краткое содержание новости.
+func (p *Post) Summary() PostSummary { return p.summary }
+
 // PubTime возвращает дату публикации новости.
 func (p *Post) PubTime() PubTime { return p.pubTime }
 
 // Link возвращает источник новости.
 func (p *Post) Link() PostLink { return p.link }
 
+// Key возвращает ключ дедупликации новости.
+func (p *Post) Key() PostKey { return p.key }
+
+// Source возвращает источник новости.
+func (p *Post) Source() PostSource { return p.source }
+
+// Article возвращает полный текст статьи и главное изображение, если они загружались.
+func (p *Post) Article() PostArticle { return p.article }
+
+// Media возвращает медиа-вложения новости.
+func (p *Post) Media() []PostMedia { return p.media }
+
+// Tags возвращает теги новости.
+func (p *Post) Tags() []PostTag { return p.tags }
+
+// Categories возвращает рубрики новости.
+func (p *Post) Categories() []PostCategory { return p.categories }
+
+// CategoriesLabeled сообщает, что рубрики проставлены вручную.
+func (p *Post) CategoriesLabeled() bool { return p.categoriesLabeled }
+
+// CommentsCount возвращает количество опубликованных комментариев к новости.
+func (p *Post) CommentsCount() int64 { return p.commentsCount }
+
+// Fingerprint возвращает отпечаток текста новости.
+func (p *Post) Fingerprint() PostFingerprint { return p.fingerprint }
+
+// ClusterID возвращает ID первой новости кластера почти одинаковых новостей.
+// Для новости, не объединенной с другими, совпадает с ее ID; 0 - если новость еще не сохранена.
+func (p *Post) ClusterID() int32 { return p.clusterID }
+
+// ClusterSize возвращает количество новостей кластера в выдаче со схлопнутыми кластерами.
+func (p *Post) ClusterSize() int64 { return p.clusterSize }
+
 // RehydratePost — вспомогательный конструктор для «восстановления» сущности из БД.
 func RehydratePost(id PostID, title PostTitle, content PostContent, pubTime PubTime, link PostLink) *Post {
 	return &Post{
@@ -72,3 +140,45 @@ func RehydratePost(id PostID, title PostTitle, content PostContent, pubTime PubT
 
 // SetID устанавливает идентификатор новости.
 func (p *Post) SetID(id PostID) { p.id = id }
+
+// SetContentHTML устанавливает содержимое новости в виде очищенного HTML.
+func (p *Post) SetContentHTML(html PostContentHTML) { p.contentHTML = html }
+
+// SetSummary устанавливает краткое содержание новости.
+func (p *Post) SetSummary(summary PostSummary) { p.summary = summary }
+
+// SetKey устанавливает ключ дедупликации новости.
+func (p *Post) SetKey(key PostKey) { p.key = key }
+
+// SetSource устанавливает источник новости.
+func (p *Post) SetSource(source PostSource) { p.source = source }
+
+// SetArticle устанавливает полный текст статьи и главное изображение.
+func (p *Post) SetArticle(article PostArticle) { p.article = article }
+
+// SetMedia устанавливает медиа-вложения новости, повторяющиеся по адресу вложения объединяются.
+func (p *Post) SetMedia(media []PostMedia) { p.media = uniqueMedia(media) }
+
+// SetTags устанавливает теги новости.
+func (p *Post) SetTags(tags []PostTag) { p.tags = tags }
+
+// SetFingerprint устанавливает отпечаток текста новости.
+func (p *Post) SetFingerprint(fingerprint PostFingerprint) { p.fingerprint = fingerprint }
+
+// JoinCluster добавляет новость в кластер почти одинаковых новостей.
+func (p *Post) JoinCluster(clusterID int32) { p.clusterID = clusterID }
+
+// SetClusterSize устанавливает количество новостей кластера в выдаче.
+func (p *Post) SetClusterSize(size int64) { p.clusterSize = size }
+
+// SetCategories устанавливает рубрики, назначенные рубрикатором.
+func (p *Post) SetCategories(categories []PostCategory) { p.categories = categories }
+
+// LabelCategories устанавливает рубрики, проставленные вручную.
+func (p *Post) LabelCategories(categories []PostCategory) {
+	p.categories = categories
+	p.categoriesLabeled = true
+}
+
+// SetCommentsCount устанавливает количество опубликованных комментариев к новости.
+func (p *Post) SetCommentsCount(count int64) { p.commentsCount = count }
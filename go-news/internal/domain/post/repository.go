@@ -4,4 +4,8 @@ package post
 type Repository interface {
 	PostStore
 	PostFinder
+	PostCommentsCounter
+	PostTagCounter
+	PostSimilarFinder
+	PostCategoryStore
 }
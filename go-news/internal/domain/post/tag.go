@@ -0,0 +1,67 @@
+package post
+
+import (
+	"strings"
+	"unicode/utf8"
+)
+
+const (
+	// MaxTagLength - максимальная длина тега в символах.
+	MaxTagLength = 64
+	// MaxPostTags - максимальное количество тегов у новости.
+	MaxPostTags = 20
+)
+
+// PostTag - нормализованный тег (категория) новости.
+type PostTag struct {
+	value string
+}
+
+// NewPostTag создает тег: пробелы по краям и ведущий '#' удаляются, пробелы внутри схлопываются,
+// регистр приводится к нижнему. Пустой тег или тег длиннее MaxTagLength символов невалиден.
+func NewPostTag(value string) (PostTag, error) {
+	value = strings.TrimLeft(strings.TrimSpace(value), "#")
+	value = strings.ToLower(strings.Join(strings.Fields(value), " "))
+
+	if value == "" || utf8.RuneCountInString(value) > MaxTagLength {
+		return PostTag{}, ErrInvalidPostTag
+	}
+
+	return PostTag{value}, nil
+}
+
+// Value возвращает значение тега.
+func (t PostTag) Value() string { return t.value }
+
+// IsZero сообщает, что тег не задан.
+func (t PostTag) IsZero() bool { return t.value == "" }
+
+// NewPostTags создает теги новости из категорий записи ленты.
+// Невалидные значения и повторы после нормализации пропускаются, тегов остается не больше MaxPostTags.
+func NewPostTags(values []string) []PostTag {
+	var tags []PostTag
+	seen := make(map[string]struct{}, len(values))
+	for _, value := range values {
+		tag, err := NewPostTag(value)
+		if err != nil {
+			continue
+		}
+		if _, ok := seen[tag.value]; ok {
+			continue
+		}
+
+		seen[tag.value] = struct{}{}
+		tags = append(tags, tag)
+		if len(tags) == MaxPostTags {
+			break
+		}
+	}
+
+	return tags
+}
+
+// TagCount - тег и количество новостей с ним.
+type TagCount struct {
+	Tag   PostTag
+	Count int64
+}
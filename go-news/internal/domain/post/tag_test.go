@@ -0,0 +1,47 @@
+package post
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNewPostTag(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    string
+		wantErr error
+	}{
+		{value: "Go", want: "go"},
+		{value: "  #Машинное   обучение ", want: "машинное обучение"},
+		{value: "##DevOps", want: "devops"},
+		{value: "   ", wantErr: ErrInvalidPostTag},
+		{value: "#", wantErr: ErrInvalidPostTag},
+		{value: strings.Repeat("я", MaxTagLength+1), wantErr: ErrInvalidPostTag},
+	}
+
+	for _, tt := range tests {
+		tag, err := NewPostTag(tt.value)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("NewPostTag(%q) error = %v, want %v", tt.value, err, tt.wantErr)
+		}
+		if tag.Value() != tt.want {
+			t.Errorf("NewPostTag(%q) = %q, want %q", tt.value, tag.Value(), tt.want)
+		}
+	}
+}
+
+func TestNewPostTags(t *testing.T) {
+	tags := NewPostTags([]string{"Go", "go ", "", "Backend", "#GO"})
+	if len(tags) != 2 || tags[0].Value() != "go" || tags[1].Value() != "backend" {
+		t.Errorf("unexpected tags: %+v", tags)
+	}
+
+	values := make([]string, MaxPostTags+5)
+	for i := range values {
+		values[i] = strings.Repeat("t", i+1)
+	}
+	if got := len(NewPostTags(values)); got != MaxPostTags {
+		t.Errorf("expected %d tags, got %d", MaxPostTags, got)
+	}
+}
@@ -1,6 +1,11 @@
 package post
 
-import "time"
+import (
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
 
 // PostID - идентификатор новости.
 type PostID struct {
@@ -100,3 +105,202 @@ func NewPostLink(text string) (PostLink, error) {
 
 // Value возвращает значение источника новости.
 func (p PostLink) Value() string { return p.value }
+
+// PostKey - ключ дедупликации новости.
+// Строится из GUID записи ленты, если он является URI, иначе из нормализованной ссылки.
+type PostKey struct {
+	value string
+}
+
+// NewPostKey создает ключ дедупликации новости.
+func NewPostKey(guid, link string) (PostKey, error) {
+	guid = strings.TrimSpace(guid)
+	if strings.Contains(guid, ":") {
+		return PostKey{value: "guid:" + guid}, nil
+	}
+
+	if len(strings.TrimSpace(link)) == 0 {
+		return PostKey{}, ErrEmptyPostLink
+	}
+
+	return PostKey{value: "link:" + NormalizeLink(link)}, nil
+}
+
+// RehydratePostKey восстанавливает ранее вычисленный ключ дедупликации из БД.
+func RehydratePostKey(value string) PostKey { return PostKey{value: value} }
+
+// Value возвращает значение ключа дедупликации.
+func (k PostKey) Value() string { return k.value }
+
+// trackingParams содержит префиксы query-параметров, не влияющих на содержимое страницы.
+var trackingParams = []string{"utm_", "fbclid", "gclid", "yclid", "_openstat"}
+
+// NormalizeLink приводит ссылку к каноничному виду: схема и хост в нижнем регистре,
+// без порта по умолчанию, фрагмента, завершающего слэша и трекинговых параметров.
+func NormalizeLink(link string) string {
+	link = strings.TrimSpace(link)
+
+	u, err := url.Parse(link)
+	if err != nil || u.Host == "" {
+		return link
+	}
+
+	u.Scheme = strings.ToLower(u.Scheme)
+	u.Host = strings.ToLower(u.Host)
+	if (u.Scheme == "http" && u.Port() == "80") || (u.Scheme == "https" && u.Port() == "443") {
+		u.Host = u.Hostname()
+	}
+
+	u.Fragment = ""
+	u.RawFragment = ""
+
+	if len(u.Path) > 1 {
+		u.Path = strings.TrimRight(u.Path, "/")
+		u.RawPath = ""
+	}
+
+	query := u.Query()
+	for name := range query {
+		if isTrackingParam(name) {
+			query.Del(name)
+		}
+	}
+	u.RawQuery = encodeSorted(query)
+
+	return u.String()
+}
+
+func isTrackingParam(name string) bool {
+	name = strings.ToLower(name)
+	for _, prefix := range trackingParams {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// encodeSorted кодирует query-параметры в стабильном порядке.
+func encodeSorted(query url.Values) string {
+	for _, values := range query {
+		sort.Strings(values)
+	}
+
+	// url.Values.Encode сортирует параметры по ключу
+	return query.Encode()
+}
+
+// PostSource - источник, из которого получена новость: адрес ленты, название канала и ссылка на сайт.
+type PostSource struct {
+	feedURL  string
+	title    string
+	siteLink string
+}
+
+// NewPostSource создает источник новости. Адрес ленты обязателен.
+func NewPostSource(feedURL, title, siteLink string) (PostSource, error) {
+	feedURL = strings.TrimSpace(feedURL)
+	if feedURL == "" {
+		return PostSource{}, ErrEmptySourceURL
+	}
+
+	return PostSource{
+		feedURL:  feedURL,
+		title:    strings.TrimSpace(title),
+		siteLink: strings.TrimSpace(siteLink),
+	}, nil
+}
+
+// FeedURL возвращает адрес ленты источника.
+func (s PostSource) FeedURL() string { return s.feedURL }
+
+// Title возвращает название канала источника.
+func (s PostSource) Title() string { return s.title }
+
+// SiteLink возвращает ссылку на сайт источника.
+func (s PostSource) SiteLink() string { return s.siteLink }
+
+// IsZero сообщает, что источник не задан (новости, сохраненные до появления атрибуции).
+func (s PostSource) IsZero() bool { return s.feedURL == "" }
+
+// PostArticle - полный текст статьи и главное изображение, загруженные по ссылке новости.
+// Содержимое новости (content) при этом остается кратким описанием из ленты.
+type PostArticle struct {
+	body  string
+	image string
+}
+
+// NewPostArticle создает статью новости. Изображение сохраняется, только если это абсолютная http(s) ссылка.
+func NewPostArticle(body, image string) PostArticle {
+	image = strings.TrimSpace(image)
+	if u, err := url.Parse(image); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		image = ""
+	}
+
+	return PostArticle{body: strings.TrimSpace(body), image: image}
+}
+
+// Body возвращает полный текст статьи.
+func (a PostArticle) Body() string { return a.body }
+
+// Image возвращает ссылку на главное изображение статьи.
+func (a PostArticle) Image() string { return a.image }
+
+// IsZero сообщает, что статья не загружалась или из нее ничего не извлечено.
+func (a PostArticle) IsZero() bool { return a.body == "" && a.image == "" }
+
+// PostContentHTML - содержание новости в виде очищенного HTML (разрешенные теги и атрибуты).
+// Очистку выполняет парсер ленты, у новостей, сохраненных до ее появления, значение пустое.
+type PostContentHTML struct {
+	value string
+}
+
+// NewPostContentHTML создает HTML-содержание новости.
+func NewPostContentHTML(html string) PostContentHTML {
+	return PostContentHTML{strings.TrimSpace(html)}
+}
+
+// Value возвращает HTML-содержание новости.
+func (c PostContentHTML) Value() string { return c.value }
+
+// IsZero сообщает, что HTML-содержание отсутствует.
+func (c PostContentHTML) IsZero() bool { return c.value == "" }
+
+// PostSummary - краткое содержание новости для списков.
+type PostSummary struct {
+	value string
+}
+
+// NewPostSummary создает краткое содержание новости.
+func NewPostSummary(text string) PostSummary {
+	return PostSummary{strings.TrimSpace(text)}
+}
+
+// Value возвращает краткое содержание новости.
+func (s PostSummary) Value() string { return s.value }
+
+// IsZero сообщает, что краткое содержание отсутствует.
+func (s PostSummary) IsZero() bool { return s.value == "" }
+
+// ContentFormat - формат содержания новости в ответе.
+type ContentFormat string
+
+const (
+	// FormatText - простой текст, абзацы разделены пустой строкой.
+	FormatText ContentFormat = "text"
+	// FormatHTML - очищенный HTML.
+	FormatHTML ContentFormat = "html"
+)
+
+// NewContentFormat создает формат содержания. Пустое значение означает простой текст.
+func NewContentFormat(value string) (ContentFormat, error) {
+	switch ContentFormat(value) {
+	case "", FormatText:
+		return FormatText, nil
+	case FormatHTML:
+		return FormatHTML, nil
+	default:
+		return "", ErrInvalidContentFormat
+	}
+}
@@ -1,6 +1,7 @@
 package post
 
 import (
+	"errors"
 	"testing"
 	"time"
 )
@@ -174,3 +175,144 @@ func TestNewPostLink(t *testing.T) {
 		},
 	)
 }
+
+func TestNormalizeLink(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "already normalized",
+			in:   "https://habr.com/ru/articles/123",
+			want: "https://habr.com/ru/articles/123",
+		},
+		{
+			name: "case, default port, fragment and trailing slash",
+			in:   "HTTPS://Habr.COM:443/ru/articles/123/#comments",
+			want: "https://habr.com/ru/articles/123",
+		},
+		{
+			name: "tracking params removed, others sorted",
+			in:   "https://example.com/post?utm_source=rss&b=2&utm_medium=feed&a=1",
+			want: "https://example.com/post?a=1&b=2",
+		},
+		{
+			name: "root path kept",
+			in:   "http://example.com/",
+			want: "http://example.com/",
+		},
+		{
+			name: "not an absolute URL",
+			in:   " some-link ",
+			want: "some-link",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				if got := NormalizeLink(tt.in); got != tt.want {
+					t.Errorf("NormalizeLink(%q) = %q, want %q", tt.in, got, tt.want)
+				}
+			},
+		)
+	}
+}
+
+func TestNewPostKey(t *testing.T) {
+	t.Run(
+		"guid as URI", func(t *testing.T) {
+			key, err := NewPostKey("https://habr.com/ru/post/1/", "https://habr.com/ru/articles/1/?utm_source=rss")
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if key.Value() != "guid:https://habr.com/ru/post/1/" {
+				t.Errorf("unexpected key %q", key.Value())
+			}
+		},
+	)
+
+	t.Run(
+		"opaque guid falls back to link", func(t *testing.T) {
+			key, err := NewPostKey("12345", "https://Example.com/a/?utm_source=rss")
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if key.Value() != "link:https://example.com/a" {
+				t.Errorf("unexpected key %q", key.Value())
+			}
+		},
+	)
+
+	t.Run(
+		"empty guid and link", func(t *testing.T) {
+			_, err := NewPostKey("", "")
+			if err != ErrEmptyPostLink {
+				t.Errorf("expected ErrEmptyPostLink, got %v", err)
+			}
+		},
+	)
+}
+
+func TestNewPostSource(t *testing.T) {
+	if _, err := NewPostSource("  ", "Title", "https://example.com"); !errors.Is(err, ErrEmptySourceURL) {
+		t.Errorf("expected ErrEmptySourceURL, got %v", err)
+	}
+
+	source, err := NewPostSource("https://example.com/rss", " Example ", "https://example.com")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if source.FeedURL() != "https://example.com/rss" || source.Title() != "Example" ||
+		source.SiteLink() != "https://example.com" {
+		t.Errorf("unexpected source: %+v", source)
+	}
+	if source.IsZero() {
+		t.Error("expected source to be set")
+	}
+	if !(PostSource{}).IsZero() {
+		t.Error("expected zero source to be reported as zero")
+	}
+}
+
+func TestNewPostArticle(t *testing.T) {
+	article := NewPostArticle("  Полный текст  ", "https://example.com/lead.jpg")
+	if article.Body() != "Полный текст" || article.Image() != "https://example.com/lead.jpg" {
+		t.Errorf("unexpected article: %+v", article)
+	}
+
+	for _, image := range []string{"/lead.jpg", "data:image/png;base64,AAAA", "javascript:alert(1)"} {
+		if got := NewPostArticle("text", image).Image(); got != "" {
+			t.Errorf("NewPostArticle(image=%q).Image() = %q, want empty", image, got)
+		}
+	}
+
+	if !NewPostArticle(" ", "").IsZero() {
+		t.Error("expected empty article to be zero")
+	}
+}
+
+func TestNewContentFormat(t *testing.T) {
+	tests := []struct {
+		value   string
+		want    ContentFormat
+		wantErr error
+	}{
+		{value: "", want: FormatText},
+		{value: "text", want: FormatText},
+		{value: "html", want: FormatHTML},
+		{value: "xml", wantErr: ErrInvalidContentFormat},
+	}
+
+	for _, tt := range tests {
+		got, err := NewContentFormat(tt.value)
+		if !errors.Is(err, tt.wantErr) {
+			t.Errorf("NewContentFormat(%q) error = %v, want %v", tt.value, err, tt.wantErr)
+		}
+		if got != tt.want {
+			t.Errorf("NewContentFormat(%q) = %q, want %q", tt.value, got, tt.want)
+		}
+	}
+}
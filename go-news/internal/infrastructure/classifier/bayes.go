@@ -0,0 +1,116 @@
+package classifier
+
+import (
+	"math"
+	"sort"
+	"strings"
+
+	uc "github.com/ee-crocush/go-news/go-news/internal/usecase/post"
+)
+
+// bayesModel - наивная байесовская модель «рубрика против остальных»: для каждой рубрики сравниваются
+// вероятности текста среди ее примеров и среди остальных. Слово учитывается один раз на пример,
+// поэтому длинные статьи не получают завышенную уверенность.
+type bayesModel struct {
+	examples int
+	// words - количество примеров со словом, total - сумма по всем словам.
+	words      map[string]int
+	total      int
+	categories map[string]*categoryModel
+}
+
+// categoryModel - статистика примеров рубрики.
+type categoryModel struct {
+	docs  int
+	words map[string]int
+	total int
+}
+
+// prediction - вероятность рубрики.
+type prediction struct {
+	category    string
+	probability float64
+}
+
+// newBayesModel обучает модель на примерах. Без примеров модель ничего не назначает.
+func newBayesModel(examples []uc.TrainingExampleDTO) *bayesModel {
+	m := &bayesModel{words: make(map[string]int), categories: make(map[string]*categoryModel)}
+
+	for _, ex := range examples {
+		if len(ex.Categories) == 0 {
+			continue
+		}
+
+		text := strings.Join(append([]string{ex.Title, ex.Content}, ex.Tags...), "\n")
+		words := uniqueTokens(text)
+		m.examples++
+		for _, w := range words {
+			m.words[w]++
+		}
+		m.total += len(words)
+
+		for _, category := range ex.Categories {
+			cm, ok := m.categories[category]
+			if !ok {
+				cm = &categoryModel{words: make(map[string]int)}
+				m.categories[category] = cm
+			}
+			cm.docs++
+			for _, w := range words {
+				cm.words[w]++
+			}
+			cm.total += len(words)
+		}
+	}
+
+	return m
+}
+
+// active сообщает, что примеров рубрики достаточно для предсказания: нужны и ее примеры,
+// и примеры других рубрик.
+func (m *bayesModel) active(cm *categoryModel, minExamples int) bool {
+	return cm.docs >= max(minExamples, 1) && cm.docs < m.examples
+}
+
+// predict возвращает вероятности активных рубрик для текста, от более вероятных.
+// Слова, которых не было в примерах, не учитываются.
+func (m *bayesModel) predict(words []string, minExamples int) []prediction {
+	if len(m.categories) == 0 {
+		return nil
+	}
+
+	words = unique(words)
+	vocabulary := float64(len(m.words))
+
+	var predictions []prediction
+	for category, cm := range m.categories {
+		if !m.active(cm, minExamples) {
+			continue
+		}
+
+		pos := math.Log(float64(cm.docs) / float64(m.examples))
+		neg := math.Log(float64(m.examples-cm.docs) / float64(m.examples))
+		for _, w := range words {
+			all, ok := m.words[w]
+			if !ok {
+				continue
+			}
+			// Сглаживание Лапласа.
+			pos += math.Log(float64(cm.words[w]+1) / (float64(cm.total) + vocabulary))
+			neg += math.Log(float64(all-cm.words[w]+1) / (float64(m.total-cm.total) + vocabulary))
+		}
+
+		predictions = append(predictions, prediction{category: category, probability: 1 / (1 + math.Exp(neg-pos))})
+	}
+
+	sort.Slice(
+		predictions, func(i, j int) bool {
+			if predictions[i].probability != predictions[j].probability {
+				return predictions[i].probability > predictions[j].probability
+			}
+			return predictions[i].category < predictions[j].category
+		},
+	)
+
+	return predictions
+}
@@ -0,0 +1,146 @@
+// Package classifier назначает новостям тематические рубрики по правилам и наивной байесовской модели.
+package classifier
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	uc "github.com/ee-crocush/go-news/go-news/internal/usecase/post"
+)
+
+var (
+	_ uc.Classifier      = (*Classifier)(nil)
+	_ uc.CategoryTrainer = (*Classifier)(nil)
+)
+
+// Rule - правило рубрики: новость попадает в рубрику, если в ее тексте есть хотя бы одно ключевое слово
+// (целым словом, без учета регистра) или совпадение с регулярным выражением.
+type Rule struct {
+	Category string
+	Keywords []string
+	Patterns []string
+}
+
+// Config - настройки рубрикатора.
+type Config struct {
+	Rules []Rule
+	// MinProbability - минимальная вероятность рубрики по модели (0..1).
+	MinProbability float64
+	// MinExamples - минимальное количество примеров рубрики, чтобы модель ее назначала.
+	MinExamples int
+}
+
+// rule - правило рубрики со скомпилированными выражениями.
+type rule struct {
+	category string
+	patterns []*regexp.Regexp
+}
+
+// Classifier назначает рубрики по правилам из конфигурации и по модели, обученной на новостях
+// с рубриками, проставленными вручную. Безопасен для параллельного использования.
+type Classifier struct {
+	rules          []rule
+	minProbability float64
+	minExamples    int
+
+	mu    sync.RWMutex
+	model *bayesModel
+}
+
+// New создает рубрикатор. До обучения рубрики назначаются только по правилам.
+func New(cfg Config) (*Classifier, error) {
+	rules := make([]rule, 0, len(cfg.Rules))
+	for _, r := range cfg.Rules {
+		compiled := rule{category: r.Category}
+
+		for _, keyword := range r.Keywords {
+			keyword = strings.TrimSpace(keyword)
+			if keyword == "" {
+				continue
+			}
+			// Граница слова: в regexp Go \b учитывает только ASCII.
+			re := regexp.MustCompile(`(?i)(?:^|[^\p{L}\p{N}])` + regexp.QuoteMeta(keyword) + `(?:$|[^\p{L}\p{N}])`)
+			compiled.patterns = append(compiled.patterns, re)
+		}
+
+		for _, pattern := range r.Patterns {
+			re, err := regexp.Compile(pattern)
+			if err != nil {
+				return nil, fmt.Errorf("Classifier.New: category %q: %w", r.Category, err)
+			}
+			compiled.patterns = append(compiled.patterns, re)
+		}
+
+		rules = append(rules, compiled)
+	}
+
+	return &Classifier{
+		rules:          rules,
+		minProbability: cfg.MinProbability,
+		minExamples:    cfg.MinExamples,
+		model:          newBayesModel(nil),
+	}, nil
+}
+
+// Classify возвращает рубрики новости: сначала рубрики по правилам в порядке конфигурации,
+// затем рубрики модели с вероятностью не ниже MinProbability, от более вероятных.
+func (c *Classifier) Classify(in uc.ClassifyInputDTO) []string {
+	text := strings.Join(append([]string{in.Title, in.Content}, in.Tags...), "\n")
+
+	var categories []string
+	seen := make(map[string]struct{})
+	for _, r := range c.rules {
+		for _, re := range r.patterns {
+			if re.MatchString(text) {
+				categories = append(categories, r.category)
+				seen[r.category] = struct{}{}
+				break
+			}
+		}
+	}
+
+	c.mu.RLock()
+	model := c.model
+	c.mu.RUnlock()
+
+	for _, p := range model.predict(tokens(text), c.minExamples) {
+		if p.probability < c.minProbability {
+			break
+		}
+		if _, ok := seen[p.category]; !ok {
+			categories = append(categories, p.category)
+		}
+	}
+
+	return categories
+}
+
+// Train обучает модель на новостях с рубриками, проставленными вручную, и заменяет текущую модель.
+func (c *Classifier) Train(examples []uc.TrainingExampleDTO) uc.TrainStatsDTO {
+	model := newBayesModel(examples)
+
+	c.mu.Lock()
+	c.model = model
+	c.mu.Unlock()
+
+	stats := uc.TrainStatsDTO{Examples: model.examples}
+	for category, m := range model.categories {
+		stats.Categories = append(
+			stats.Categories, uc.CategoryModelDTO{
+				Category: category,
+				Examples: m.docs,
+				Active:   model.active(m, c.minExamples),
+			},
+		)
+	}
+	sort.Slice(
+		stats.Categories, func(i, j int) bool {
+			return stats.Categories[i].Category < stats.Categories[j].Category
+		},
+	)
+
+	return stats
+}
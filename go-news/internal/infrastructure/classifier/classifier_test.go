@@ -0,0 +1,166 @@
+package classifier
+
+import (
+	"fmt"
+	"testing"
+
+	uc "github.com/ee-crocush/go-news/go-news/internal/usecase/post"
+)
+
+func newTestClassifier(t *testing.T) *Classifier {
+	t.Helper()
+
+	c, err := New(
+		Config{
+			Rules: []Rule{
+				{Category: "go", Keywords: []string{"golang", "горутина"}, Patterns: []string{`(?i)\bgo\s+1\.\d+`}},
+				{Category: "databases", Keywords: []string{"PostgreSQL", "базы данных"}},
+			},
+			MinProbability: 0.8,
+			MinExamples:    3,
+		},
+	)
+	if err != nil {
+		t.Fatalf("New() error = %v", err)
+	}
+
+	return c
+}
+
+func TestClassifier_Rules(t *testing.T) {
+	c := newTestClassifier(t)
+
+	tests := []struct {
+		name string
+		in   uc.ClassifyInputDTO
+		want []string
+	}{
+		{
+			name: "keyword ignores case",
+			in:   uc.ClassifyInputDTO{Title: "Миграция на POSTGRESQL 17"},
+			want: []string{"databases"},
+		},
+		{
+			name: "phrase keyword",
+			in:   uc.ClassifyInputDTO{Content: "Как выбрать базы данных для стартапа"},
+			want: []string{"databases"},
+		},
+		{
+			name: "keyword matches whole words only",
+			in:   uc.ClassifyInputDTO{Title: "Горутинам тесно"},
+		},
+		{
+			name: "pattern and keyword in config order",
+			in:   uc.ClassifyInputDTO{Title: "Вышел Go 1.24", Content: "И немного про PostgreSQL"},
+			want: []string{"go", "databases"},
+		},
+		{
+			name: "tags are matched",
+			in:   uc.ClassifyInputDTO{Title: "Релиз", Tags: []string{"golang"}},
+			want: []string{"go"},
+		},
+		{
+			name: "no match",
+			in:   uc.ClassifyInputDTO{Title: "Погода на выходные"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				if got := c.Classify(tt.in); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+					t.Errorf("Classify() = %v, want %v", got, tt.want)
+				}
+			},
+		)
+	}
+}
+
+func TestNew_InvalidPattern(t *testing.T) {
+	if _, err := New(Config{Rules: []Rule{{Category: "go", Patterns: []string{"(go"}}}}); err == nil {
+		t.Error("expected error for invalid pattern")
+	}
+}
+
+func TestClassifier_Train(t *testing.T) {
+	c := newTestClassifier(t)
+
+	var examples []uc.TrainingExampleDTO
+	for i := 0; i < 5; i++ {
+		examples = append(
+			examples,
+			uc.TrainingExampleDTO{
+				ClassifyInputDTO: uc.ClassifyInputDTO{
+					Title:   fmt.Sprintf("Квантовый компьютер %d", i),
+					Content: "Кубиты, квантовая запутанность и квантовые вычисления.",
+				},
+				Categories: []string{"quantum"},
+			},
+			uc.TrainingExampleDTO{
+				ClassifyInputDTO: uc.ClassifyInputDTO{
+					Title:   fmt.Sprintf("Футбольный матч %d", i),
+					Content: "Команда забила гол во втором тайме, болельщики довольны.",
+				},
+				Categories: []string{"sport"},
+			},
+		)
+	}
+	examples = append(
+		examples, uc.TrainingExampleDTO{
+			ClassifyInputDTO: uc.ClassifyInputDTO{Title: "Шахматный турнир", Content: "Партия закончилась ничьей."},
+			Categories:       []string{"chess"},
+		},
+	)
+
+	if got := c.Classify(uc.ClassifyInputDTO{Title: "Новый квантовый компьютер на кубитах"}); len(got) != 0 {
+		t.Errorf("expected no categories before training, got %v", got)
+	}
+
+	stats := c.Train(examples)
+	if stats.Examples != 11 {
+		t.Errorf("expected 11 examples, got %d", stats.Examples)
+	}
+	want := []uc.CategoryModelDTO{
+		{Category: "chess", Examples: 1, Active: false},
+		{Category: "quantum", Examples: 5, Active: true},
+		{Category: "sport", Examples: 5, Active: true},
+	}
+	if fmt.Sprint(stats.Categories) != fmt.Sprint(want) {
+		t.Errorf("Train() categories = %v, want %v", stats.Categories, want)
+	}
+
+	tests := []struct {
+		name string
+		in   uc.ClassifyInputDTO
+		want []string
+	}{
+		{
+			name: "predicted category",
+			in:   uc.ClassifyInputDTO{Title: "Новый квантовый компьютер на кубитах"},
+			want: []string{"quantum"},
+		},
+		{
+			name: "rules come first",
+			in:   uc.ClassifyInputDTO{Title: "Симулятор кубитов на golang", Content: "Квантовые вычисления и кубиты."},
+			want: []string{"go", "quantum"},
+		},
+		{
+			name: "category with too few examples is not predicted",
+			in:   uc.ClassifyInputDTO{Title: "Шахматный турнир", Content: "Партия закончилась ничьей."},
+		},
+		{
+			name: "unknown text",
+			in:   uc.ClassifyInputDTO{Title: "Рецепт борща"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				if got := c.Classify(tt.in); fmt.Sprint(got) != fmt.Sprint(tt.want) {
+					t.Errorf("Classify() = %v, want %v", got, tt.want)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,70 @@
+package classifier
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// stopWords - частые слова, не указывающие на тему новости.
+var stopWords = map[string]struct{}{
+	"это": {}, "что": {}, "как": {}, "так": {}, "для": {}, "или": {}, "его": {}, "она": {}, "они": {},
+	"при": {}, "уже": {}, "все": {}, "еще": {}, "ещё": {}, "также": {}, "который": {}, "которые": {},
+	"на": {}, "по": {}, "не": {}, "из": {}, "за": {}, "от": {}, "до": {}, "во": {}, "со": {}, "же": {},
+	"the": {}, "and": {}, "for": {}, "with": {}, "that": {}, "this": {}, "was": {}, "are": {}, "from": {},
+	"of": {}, "to": {}, "in": {}, "on": {}, "is": {}, "it": {}, "as": {}, "at": {}, "by": {}, "an": {},
+}
+
+// tokens разбивает текст на основы слов. Учитываются слова от двух символов (go, ai, k8s),
+// у длинных слов отбрасывается окончание, чтобы формы слова считались вместе.
+func tokens(text string) []string {
+	fields := strings.FieldsFunc(
+		strings.ToLower(text), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		},
+	)
+
+	out := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if utf8.RuneCountInString(f) < 2 {
+			continue
+		}
+		if _, ok := stopWords[f]; ok {
+			continue
+		}
+		out = append(out, stem(f))
+	}
+
+	return out
+}
+
+// uniqueTokens возвращает основы слов текста без повторов.
+func uniqueTokens(text string) []string {
+	return unique(tokens(text))
+}
+
+func unique(words []string) []string {
+	seen := make(map[string]struct{}, len(words))
+	out := make([]string, 0, len(words))
+	for _, w := range words {
+		if _, ok := seen[w]; ok {
+			continue
+		}
+		seen[w] = struct{}{}
+		out = append(out, w)
+	}
+
+	return out
+}
+
+func stem(word string) string {
+	runes := []rune(word)
+	switch n := len(runes); {
+	case n < 5:
+		return word
+	case n < 7:
+		return string(runes[:n-1])
+	default:
+		return string(runes[:n-2])
+	}
+}
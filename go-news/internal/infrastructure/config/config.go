@@ -36,13 +36,16 @@ type MongoConfig struct {
 }
 
 // URI формирование строки подключения к БД.
+// Подключение прямое: адреса участников replica set (например, news-mongo в docker-compose) могут быть
+// недоступны там, где запущен сервис, а транзакции на первичном узле доступны и без их обнаружения.
 func (c *MongoConfig) URI() *url.URL {
 	hostPost := fmt.Sprintf("%s:%d", c.Host, c.Port)
 
 	return &url.URL{
 		Scheme: "mongodb",
 		//User:   url.UserPassword(c.User, c.Password),
-		Host: hostPost,
+		Host:     hostPost,
+		RawQuery: "directConnection=true",
 	}
 }
 
@@ -80,13 +83,230 @@ func (r *RSSConfig) GetRequestPeriodDuration() time.Duration {
 	return time.Duration(r.RequestPeriod) * time.Minute
 }
 
+// SchedulerConfig - конфигурация планировщика опроса источников.
+// Нулевые значения заменяются значениями по умолчанию.
+type SchedulerConfig struct {
+	Workers    int `yaml:"workers" validate:"min=0"`
+	Tick       int `yaml:"tick" validate:"min=0"`
+	Jitter     int `yaml:"jitter" validate:"min=0,max=100"`
+	MaxBackoff int `yaml:"max_backoff" validate:"min=0"`
+	// RunHistory - срок хранения журнала опросов в днях.
+	RunHistory int `yaml:"run_history" validate:"min=0"`
+}
+
+const (
+	defaultSchedulerWorkers    = 4
+	defaultSchedulerTick       = 10
+	defaultSchedulerJitter     = 10
+	defaultSchedulerMaxBackoff = 60
+	defaultSchedulerRunHistory = 7
+)
+
+// GetWorkers возвращает количество одновременно опрашиваемых источников.
+func (s *SchedulerConfig) GetWorkers() int {
+	if s.Workers == 0 {
+		return defaultSchedulerWorkers
+	}
+	return s.Workers
+}
+
+// GetTick возвращает период проверки расписания как time.Duration в секундах.
+func (s *SchedulerConfig) GetTick() time.Duration {
+	if s.Tick == 0 {
+		return defaultSchedulerTick * time.Second
+	}
+	return time.Duration(s.Tick) * time.Second
+}
+
+// GetJitter возвращает долю случайного разброса интервала опроса (0..1).
+func (s *SchedulerConfig) GetJitter() float64 {
+	if s.Jitter == 0 {
+		return defaultSchedulerJitter / 100.0
+	}
+	return float64(s.Jitter) / 100
+}
+
+// GetMaxBackoff возвращает максимальную задержку повторного опроса после ошибок как time.Duration в минутах.
+func (s *SchedulerConfig) GetMaxBackoff() time.Duration {
+	if s.MaxBackoff == 0 {
+		return defaultSchedulerMaxBackoff * time.Minute
+	}
+	return time.Duration(s.MaxBackoff) * time.Minute
+}
+
+// GetRunHistoryTTL возвращает срок хранения журнала опросов как time.Duration в днях.
+func (s *SchedulerConfig) GetRunHistoryTTL() time.Duration {
+	if s.RunHistory == 0 {
+		return defaultSchedulerRunHistory * 24 * time.Hour
+	}
+	return time.Duration(s.RunHistory) * 24 * time.Hour
+}
+
+// OutboxConfig - конфигурация отправки событий из outbox в Kafka.
+type OutboxConfig struct {
+	// Interval - период проверки outbox в секундах.
+	Interval int `yaml:"interval" validate:"min=0"`
+	// BatchSize - максимальное количество событий за одну проверку.
+	BatchSize int `yaml:"batch_size" validate:"min=0"`
+	// MaxBackoff - максимальная задержка повторной отправки в минутах.
+	MaxBackoff int `yaml:"max_backoff" validate:"min=0"`
+	// Retention - срок хранения отправленных событий в днях.
+	Retention int `yaml:"retention" validate:"min=0"`
+}
+
+const (
+	defaultOutboxInterval   = 1
+	defaultOutboxBatchSize  = 100
+	defaultOutboxMaxBackoff = 5
+	defaultOutboxRetention  = 3
+)
+
+// GetInterval возвращает период проверки outbox как time.Duration в секундах.
+func (o *OutboxConfig) GetInterval() time.Duration {
+	if o.Interval == 0 {
+		return defaultOutboxInterval * time.Second
+	}
+	return time.Duration(o.Interval) * time.Second
+}
+
+// GetBatchSize возвращает максимальное количество событий за одну проверку.
+func (o *OutboxConfig) GetBatchSize() int {
+	if o.BatchSize == 0 {
+		return defaultOutboxBatchSize
+	}
+	return o.BatchSize
+}
+
+// GetMaxBackoff возвращает максимальную задержку повторной отправки как time.Duration в минутах.
+func (o *OutboxConfig) GetMaxBackoff() time.Duration {
+	if o.MaxBackoff == 0 {
+		return defaultOutboxMaxBackoff * time.Minute
+	}
+	return time.Duration(o.MaxBackoff) * time.Minute
+}
+
+// GetRetention возвращает срок хранения отправленных событий как time.Duration в днях.
+func (o *OutboxConfig) GetRetention() time.Duration {
+	if o.Retention == 0 {
+		return defaultOutboxRetention * 24 * time.Hour
+	}
+	return time.Duration(o.Retention) * 24 * time.Hour
+}
+
+// ClassifierConfig - конфигурация рубрикатора новостей.
+// Нулевые значения заменяются значениями по умолчанию.
+type ClassifierConfig struct {
+	// MinProbability - минимальная вероятность рубрики по модели в процентах.
+	MinProbability int `yaml:"min_probability" validate:"min=0,max=100"`
+	// MinExamples - минимальное количество размеченных вручную новостей рубрики, чтобы модель ее назначала.
+	MinExamples int `yaml:"min_examples" validate:"min=0"`
+	// Categories - правила рубрик.
+	Categories []CategoryRuleConfig `yaml:"categories" validate:"dive"`
+}
+
+// CategoryRuleConfig - правило рубрики: ключевые слова (целым словом, без учета регистра)
+// и регулярные выражения RE2.
+type CategoryRuleConfig struct {
+	Name     string   `yaml:"name" validate:"required"`
+	Keywords []string `yaml:"keywords"`
+	Patterns []string `yaml:"patterns"`
+}
+
+const (
+	defaultClassifierMinProbability = 80
+	defaultClassifierMinExamples    = 5
+)
+
+// GetMinProbability возвращает минимальную вероятность рубрики по модели (0..1).
+func (c *ClassifierConfig) GetMinProbability() float64 {
+	if c.MinProbability == 0 {
+		return defaultClassifierMinProbability / 100.0
+	}
+	return float64(c.MinProbability) / 100
+}
+
+// GetMinExamples возвращает минимальное количество примеров рубрики для модели.
+func (c *ClassifierConfig) GetMinExamples() int {
+	if c.MinExamples == 0 {
+		return defaultClassifierMinExamples
+	}
+	return c.MinExamples
+}
+
+// ConsumerRetryConfig - политика повторной обработки сообщений consumer'а.
+// Нулевые значения заменяются значениями по умолчанию pkg/kafka.
+type ConsumerRetryConfig struct {
+	// Attempts - количество попыток обработки сообщения, включая первую.
+	Attempts int `yaml:"attempts" validate:"min=0"`
+	// MinBackoff - начальная задержка между попытками в секундах.
+	MinBackoff int `yaml:"min_backoff" validate:"min=0"`
+	// MaxBackoff - максимальная задержка между попытками в секундах.
+	MaxBackoff int `yaml:"max_backoff" validate:"min=0"`
+}
+
+// GetMinBackoff возвращает начальную задержку между попытками как time.Duration в секундах.
+func (r *ConsumerRetryConfig) GetMinBackoff() time.Duration {
+	return time.Duration(r.MinBackoff) * time.Second
+}
+
+// GetMaxBackoff возвращает максимальную задержку между попытками как time.Duration в секундах.
+func (r *ConsumerRetryConfig) GetMaxBackoff() time.Duration {
+	return time.Duration(r.MaxBackoff) * time.Second
+}
+
+// KafkaPublisherConfig - параметры отправки сообщений в Kafka. Нулевые значения заменяются значениями
+// по умолчанию pkg/kafka.
+type KafkaPublisherConfig struct {
+	// Acks - подтверждение записи: all - всеми репликами (по умолчанию), one - лидером партиции,
+	// none - без подтверждения.
+	Acks string `yaml:"acks" validate:"omitempty,oneof=all one none"`
+	// Compression - сжатие сообщений: none (по умолчанию), gzip, snappy, lz4 или zstd.
+	Compression string `yaml:"compression" validate:"omitempty,oneof=none gzip snappy lz4 zstd"`
+	// BatchSize - максимальное количество сообщений в пакете, по умолчанию 100.
+	BatchSize int `yaml:"batch_size" validate:"min=0"`
+	// BatchTimeout - максимальное время накопления неполного пакета в миллисекундах, по умолчанию 1000.
+	BatchTimeout int `yaml:"batch_timeout" validate:"min=0"`
+}
+
+// GetBatchTimeout возвращает время накопления пакета как time.Duration в миллисекундах.
+func (p *KafkaPublisherConfig) GetBatchTimeout() time.Duration {
+	return time.Duration(p.BatchTimeout) * time.Millisecond
+}
+
+// KafkaConfig - конфигурация Kafka. Без брокеров получение событий от других сервисов
+// и публикация событий о новостях отключены.
+type KafkaConfig struct {
+	Brokers       []string            `yaml:"brokers"`
+	Topics        map[string]string   `yaml:"topics"`
+	ConsumerGroup string              `yaml:"consumer_group"`
+	Retry         ConsumerRetryConfig `yaml:"retry"`
+	// Workers - количество параллельных обработчиков сообщений consumer'а, по умолчанию 1.
+	Workers   int                  `yaml:"workers" validate:"min=0"`
+	Publisher KafkaPublisherConfig `yaml:"publisher"`
+}
+
+// Enabled сообщает, настроено ли подключение к Kafka.
+func (k *KafkaConfig) Enabled() bool {
+	for _, broker := range k.Brokers {
+		if broker != "" {
+			return true
+		}
+	}
+
+	return false
+}
+
 // Config основная конфигурация.
 type Config struct {
-	App     AppConfig     `yaml:"app"`
-	HTTP    HTTPConfig    `yaml:"http"`
-	MongoDB MongoConfig   `yaml:"mongodb"`
-	Logging LoggingConfig `yaml:"logging"`
-	RSS     RSSConfig     `json:"-"`
+	App        AppConfig        `yaml:"app"`
+	HTTP       HTTPConfig       `yaml:"http"`
+	MongoDB    MongoConfig      `yaml:"mongodb"`
+	Logging    LoggingConfig    `yaml:"logging"`
+	Scheduler  SchedulerConfig  `yaml:"scheduler"`
+	Classifier ClassifierConfig `yaml:"classifier"`
+	Kafka      KafkaConfig      `yaml:"kafka"`
+	Outbox     OutboxConfig     `yaml:"outbox"`
+	RSS        RSSConfig        `json:"-"`
 }
 
 func (c *Config) GetAppName() string {
@@ -129,6 +349,13 @@ func (c *Config) EnableCors() bool {
 	return c.App.EnableCors
 }
 
+func (c *Config) GetTopic(name string) (string, error) {
+	if topic, ok := c.Kafka.Topics[name]; ok {
+		return topic, nil
+	}
+	return "", fmt.Errorf("topic %s not found", name)
+}
+
 // Validate валидация конфига.
 func (c *Config) Validate() error {
 	validate := validator.New()
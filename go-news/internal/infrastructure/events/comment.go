@@ -0,0 +1,19 @@
+// Package events содержит события, получаемые от других сервисов и публикуемые сервисом новостей.
+package events
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// CommentPublishedEvent - событие публикации комментария к новости (сервис комментариев).
+type CommentPublishedEvent struct {
+	CommentID   int64     `json:"comment_id"`
+	NewsID      int32     `json:"news_id"`
+	PublishedAt time.Time `json:"published_at"`
+}
+
+// FromJSON создает событие из JSON.
+func (e *CommentPublishedEvent) FromJSON(data []byte) error {
+	return json.Unmarshal(data, e)
+}
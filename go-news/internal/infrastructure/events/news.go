@@ -0,0 +1,57 @@
+package events
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	dom "github.com/ee-crocush/go-news/go-news/internal/domain/post"
+)
+
+const (
+	// NewsPublishedType - тип события публикации новости.
+	NewsPublishedType = "news.published"
+	// SchemaVersion - версия схемы событий, передается в заголовке сообщения.
+	SchemaVersion = 1
+)
+
+// NewsPublishedEvent - событие публикации новой новости, сохраненной при опросе источника.
+type NewsPublishedEvent struct {
+	ID      int32       `json:"id"`
+	Title   string      `json:"title"`
+	Link    string      `json:"link"`
+	Source  *NewsSource `json:"source,omitempty"`
+	PubTime time.Time   `json:"pub_time"`
+}
+
+// NewsSource - источник новости.
+type NewsSource struct {
+	FeedURL  string `json:"feed_url"`
+	Title    string `json:"title"`
+	SiteLink string `json:"site_link"`
+}
+
+// NewNewsPublishedEvent создает экземпляр NewsPublishedEvent для сохраненной новости.
+func NewNewsPublishedEvent(post *dom.Post) *NewsPublishedEvent {
+	e := &NewsPublishedEvent{
+		ID:      post.ID().Value(),
+		Title:   post.Title().Value(),
+		Link:    post.Link().Value(),
+		PubTime: post.PubTime().Time().UTC(),
+	}
+	if source := post.Source(); !source.IsZero() {
+		e.Source = &NewsSource{FeedURL: source.FeedURL(), Title: source.Title(), SiteLink: source.SiteLink()}
+	}
+
+	return e
+}
+
+// Key возвращает ключ сообщения: события одной новости попадают в одну партицию.
+func (e *NewsPublishedEvent) Key() string {
+	return strconv.Itoa(int(e.ID))
+}
+
+// ToJSON конвертирует событие в JSON.
+func (e *NewsPublishedEvent) ToJSON() ([]byte, error) {
+	return json.Marshal(e)
+}
@@ -0,0 +1,77 @@
+// Package readability загружает страницы статей и извлекает из них основной текст и главное изображение.
+package readability
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	uc "github.com/ee-crocush/go-news/go-news/internal/usecase/post"
+	"golang.org/x/net/html/charset"
+)
+
+// MaxPageSize - максимальный размер загружаемой страницы в байтах, остаток страницы отбрасывается.
+const MaxPageSize = 5 << 20
+
+var (
+	// ErrUnexpectedStatus представляет ошибку неожиданного HTTP статуса ответа сайта.
+	ErrUnexpectedStatus = errors.New("unexpected http status")
+	// ErrNotHTML представляет ошибку загрузки страницы, которая не является HTML.
+	ErrNotHTML = errors.New("page is not html")
+)
+
+// Extractor загружает статьи по ссылкам новостей.
+type Extractor struct {
+	client *http.Client
+}
+
+// NewExtractor создает новый экземпляр загрузчика статей.
+func NewExtractor(timeout time.Duration) *Extractor {
+	return &Extractor{
+		client: &http.Client{
+			Timeout: timeout,
+		},
+	}
+}
+
+// Fetch загружает страницу по ссылке и извлекает из нее статью.
+// Относительные ссылки на изображения разрешаются относительно итогового адреса страницы (после редиректов).
+// Страница перекодируется в UTF-8 по charset заголовка Content-Type, а без него - по meta-тегу страницы.
+func (e *Extractor) Fetch(ctx context.Context, url string) (uc.ArticleDTO, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return uc.ArticleDTO{}, fmt.Errorf("Extractor.Fetch: %w", err)
+	}
+	req.Header.Set("Accept", "text/html,application/xhtml+xml")
+
+	resp, err := e.client.Do(req)
+	if err != nil {
+		return uc.ArticleDTO{}, fmt.Errorf("Extractor.Fetch: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return uc.ArticleDTO{}, fmt.Errorf("Extractor.Fetch: %w: %d", ErrUnexpectedStatus, resp.StatusCode)
+	}
+
+	ct := resp.Header.Get("Content-Type")
+	if ct != "" && !strings.Contains(ct, "html") {
+		return uc.ArticleDTO{}, fmt.Errorf("Extractor.Fetch: %w: %s", ErrNotHTML, ct)
+	}
+
+	body, err := charset.NewReader(io.LimitReader(resp.Body, MaxPageSize), ct)
+	if err != nil {
+		return uc.ArticleDTO{}, fmt.Errorf("Extractor.Fetch: %w", err)
+	}
+
+	article, err := Extract(body, resp.Request.URL)
+	if err != nil {
+		return uc.ArticleDTO{}, fmt.Errorf("Extractor.Fetch: %w", err)
+	}
+
+	return article, nil
+}
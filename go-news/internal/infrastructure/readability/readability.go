@@ -0,0 +1,478 @@
+package readability
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	uc "github.com/ee-crocush/go-news/go-news/internal/usecase/post"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// MinArticleLength - минимальная длина извлеченного текста в символах, короче - статья не найдена.
+const MinArticleLength = 250
+
+// ErrNoArticle представляет ошибку, когда на странице не найден основной текст.
+var ErrNoArticle = errors.New("article not found")
+
+var (
+	// unlikelyRe - классы и ID блоков, которые почти никогда не содержат текст статьи.
+	unlikelyRe = regexp.MustCompile(
+		`(?i)banner|breadcrumb|comment|community|cookie|disqus|footer|header|menu|modal|nav|` +
+			`popup|promo|related|remark|share|sidebar|social|sponsor|subscribe|widget|advert|\bads?\b`,
+	)
+	// maybeRe - классы и ID, которые отменяют unlikelyRe (например, "article-header-content").
+	maybeRe = regexp.MustCompile(`(?i)article|body|column|content|main|post|text|story`)
+	// positiveRe и negativeRe - признаки основного текста и второстепенных блоков для веса узла.
+	positiveRe = regexp.MustCompile(`(?i)article|blog|body|content|entry|main|page|post|story|text|tm-article`)
+	negativeRe = regexp.MustCompile(
+		`(?i)author|byline|caption|comment|footer|footnote|meta|more|outbrain|promo|related|scroll|` +
+			`share|shoutbox|sidebar|skyscraper|sponsor|tags|teaser|widget`,
+	)
+)
+
+// junkTags - элементы, которые удаляются со страницы целиком до поиска статьи.
+var junkTags = map[atom.Atom]bool{
+	atom.Script: true, atom.Style: true, atom.Noscript: true, atom.Iframe: true, atom.Form: true,
+	atom.Nav: true, atom.Header: true, atom.Footer: true, atom.Aside: true, atom.Svg: true,
+	atom.Button: true, atom.Input: true, atom.Select: true, atom.Textarea: true, atom.Object: true,
+	atom.Embed: true, atom.Template: true, atom.Dialog: true,
+}
+
+// blockTags - элементы, границы которых разделяют абзацы извлеченного текста.
+var blockTags = map[atom.Atom]bool{
+	atom.P: true, atom.Div: true, atom.Section: true, atom.Article: true, atom.Main: true,
+	atom.H1: true, atom.H2: true, atom.H3: true, atom.H4: true, atom.H5: true, atom.H6: true,
+	atom.Ul: true, atom.Ol: true, atom.Li: true, atom.Dl: true, atom.Dt: true, atom.Dd: true,
+	atom.Pre: true, atom.Blockquote: true, atom.Table: true, atom.Tr: true, atom.Figure: true,
+	atom.Figcaption: true, atom.Hr: true,
+}
+
+// Extract извлекает из HTML-страницы основной текст и главное изображение.
+// Используется упрощенный алгоритм Readability: абзацы оцениваются по длине и количеству запятых,
+// оценка передается родительским блокам, с поправкой на классы блоков и долю текста в ссылках.
+// Статьей считается блок с наибольшей оценкой вместе с подходящими соседними блоками.
+// Главное изображение берется из og:image/twitter:image, иначе - первое изображение статьи.
+func Extract(r io.Reader, base *url.URL) (uc.ArticleDTO, error) {
+	doc, err := html.Parse(r)
+	if err != nil {
+		return uc.ArticleDTO{}, fmt.Errorf("readability.Extract: %w", err)
+	}
+
+	image := metaImage(doc, base)
+
+	prune(doc)
+
+	nodes := articleNodes(doc)
+	if len(nodes) == 0 {
+		return uc.ArticleDTO{}, ErrNoArticle
+	}
+
+	var paragraphs []string
+	for _, n := range nodes {
+		paragraphs = appendParagraphs(paragraphs, n)
+	}
+
+	body := strings.Join(paragraphs, "\n\n")
+	if utf8.RuneCountInString(body) < MinArticleLength {
+		return uc.ArticleDTO{}, ErrNoArticle
+	}
+
+	if image == "" {
+		for _, n := range nodes {
+			if image = firstImage(n, base); image != "" {
+				break
+			}
+		}
+	}
+
+	return uc.ArticleDTO{Body: body, Image: image}, nil
+}
+
+// prune удаляет со страницы служебные элементы и блоки, которые вряд ли относятся к статье.
+func prune(doc *html.Node) {
+	var junk []*html.Node
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type == html.CommentNode || (c.Type == html.ElementNode && isJunk(c)) {
+				junk = append(junk, c)
+				continue
+			}
+			walk(c)
+		}
+	}
+	walk(doc)
+
+	for _, n := range junk {
+		n.Parent.RemoveChild(n)
+	}
+}
+
+// isJunk сообщает, что элемент нужно удалить до поиска статьи.
+func isJunk(n *html.Node) bool {
+	if junkTags[n.DataAtom] {
+		return true
+	}
+
+	switch n.DataAtom {
+	case atom.Html, atom.Body, atom.Article, atom.Main:
+		return false
+	}
+
+	if attr(n, "aria-hidden") == "true" || attr(n, "hidden") != "" {
+		return true
+	}
+
+	match := attr(n, "class") + " " + attr(n, "id")
+
+	return unlikelyRe.MatchString(match) && !maybeRe.MatchString(match)
+}
+
+// articleNodes находит блок статьи с наибольшей оценкой и подходящие соседние блоки в порядке документа.
+func articleNodes(doc *html.Node) []*html.Node {
+	scores := scoreCandidates(doc)
+
+	var top *html.Node
+	for n, score := range scores {
+		if top == nil || score > scores[top] {
+			top = n
+		}
+	}
+	if top == nil {
+		return nil
+	}
+
+	if top.Parent == nil {
+		return []*html.Node{top}
+	}
+
+	threshold := max(10, scores[top]*0.2)
+
+	var nodes []*html.Node
+	for s := top.Parent.FirstChild; s != nil; s = s.NextSibling {
+		if s.Type != html.ElementNode {
+			continue
+		}
+
+		if s == top {
+			nodes = append(nodes, s)
+			continue
+		}
+
+		if score, ok := scores[s]; ok && score >= threshold {
+			nodes = append(nodes, s)
+			continue
+		}
+
+		if s.DataAtom == atom.P {
+			text := collapseSpace(textContent(s))
+			if utf8.RuneCountInString(text) > 80 && linkDensity(s) < 0.25 {
+				nodes = append(nodes, s)
+			}
+		}
+	}
+
+	return nodes
+}
+
+// scoreCandidates оценивает блоки-кандидаты по содержащимся в них абзацам.
+func scoreCandidates(doc *html.Node) map[*html.Node]float64 {
+	scores := make(map[*html.Node]float64)
+
+	addScore := func(n *html.Node, score float64) {
+		if n == nil || n.Type != html.ElementNode {
+			return
+		}
+		if _, ok := scores[n]; !ok {
+			scores[n] = initialScore(n)
+		}
+		scores[n] += score
+	}
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type != html.ElementNode {
+				continue
+			}
+
+			if isParagraph(c) {
+				text := collapseSpace(textContent(c))
+				length := utf8.RuneCountInString(text)
+				if length >= 25 {
+					score := 1 + float64(strings.Count(text, ",")) + min(float64(length)/100, 3)
+					addScore(c.Parent, score)
+					if c.Parent != nil {
+						addScore(c.Parent.Parent, score/2)
+					}
+				}
+			}
+
+			walk(c)
+		}
+	}
+	walk(doc)
+
+	for n := range scores {
+		scores[n] *= 1 - linkDensity(n)
+	}
+
+	return scores
+}
+
+// isParagraph сообщает, что элемент содержит абзац текста: p, pre, td, blockquote
+// или div без вложенных блоков (часто встречается вместо p).
+func isParagraph(n *html.Node) bool {
+	switch n.DataAtom {
+	case atom.P, atom.Pre, atom.Td, atom.Blockquote:
+		return true
+	case atom.Div:
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type == html.ElementNode && blockTags[c.DataAtom] {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
+
+// initialScore возвращает начальную оценку блока по тегу и классам.
+func initialScore(n *html.Node) float64 {
+	var score float64
+
+	switch n.DataAtom {
+	case atom.Article:
+		score = 10
+	case atom.Div, atom.Section, atom.Main:
+		score = 5
+	case atom.Pre, atom.Td, atom.Blockquote:
+		score = 3
+	case atom.Address, atom.Ol, atom.Ul, atom.Dl, atom.Dd, atom.Dt, atom.Li, atom.Form:
+		score = -3
+	case atom.H1, atom.H2, atom.H3, atom.H4, atom.H5, atom.H6, atom.Th:
+		score = -5
+	}
+
+	return score + classWeight(n)
+}
+
+// classWeight возвращает поправку оценки по классу и ID блока.
+func classWeight(n *html.Node) float64 {
+	var weight float64
+
+	for _, value := range []string{attr(n, "class"), attr(n, "id")} {
+		if value == "" {
+			continue
+		}
+		if negativeRe.MatchString(value) {
+			weight -= 25
+		}
+		if positiveRe.MatchString(value) {
+			weight += 25
+		}
+	}
+
+	return weight
+}
+
+// linkDensity возвращает долю текста блока, находящегося внутри ссылок.
+func linkDensity(n *html.Node) float64 {
+	length := utf8.RuneCountInString(collapseSpace(textContent(n)))
+	if length == 0 {
+		return 0
+	}
+
+	var linkLength int
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			if c.Type == html.ElementNode && c.DataAtom == atom.A {
+				linkLength += utf8.RuneCountInString(collapseSpace(textContent(c)))
+				continue
+			}
+			walk(c)
+		}
+	}
+	walk(n)
+
+	return float64(linkLength) / float64(length)
+}
+
+// appendParagraphs добавляет абзацы текста блока: границы блочных элементов и <br> разделяют абзацы,
+// пробелы внутри абзаца схлопываются, в <pre> сохраняются переносы строк.
+func appendParagraphs(paragraphs []string, n *html.Node) []string {
+	var b strings.Builder
+
+	flush := func() {
+		if text := collapseSpace(b.String()); text != "" {
+			paragraphs = append(paragraphs, text)
+		}
+		b.Reset()
+	}
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		switch {
+		case n.Type == html.TextNode:
+			b.WriteString(n.Data)
+			return
+		case n.Type != html.ElementNode && n.Type != html.DocumentNode:
+			return
+		case n.DataAtom == atom.Br:
+			flush()
+			return
+		case n.DataAtom == atom.Pre:
+			flush()
+			if text := strings.TrimSpace(textContent(n)); text != "" {
+				paragraphs = append(paragraphs, text)
+			}
+			return
+		}
+
+		block := blockTags[n.DataAtom]
+		if block {
+			flush()
+		}
+
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+
+		if block {
+			flush()
+		}
+	}
+	walk(n)
+	flush()
+
+	return paragraphs
+}
+
+// metaImage возвращает главное изображение страницы из метаданных (Open Graph, Twitter Card).
+func metaImage(doc *html.Node, base *url.URL) string {
+	var image string
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil && image == ""; c = c.NextSibling {
+			if c.Type == html.ElementNode && c.DataAtom == atom.Meta {
+				key := attr(c, "property")
+				if key == "" {
+					key = attr(c, "name")
+				}
+
+				switch strings.ToLower(key) {
+				case "og:image", "og:image:url", "og:image:secure_url", "twitter:image", "twitter:image:src":
+					image = resolve(base, attr(c, "content"))
+				}
+				continue
+			}
+			walk(c)
+		}
+	}
+	walk(doc)
+
+	return image
+}
+
+// firstImage возвращает первое изображение блока. Изображения с явно малыми размерами (иконки,
+// счетчики) и встроенные data: изображения пропускаются.
+func firstImage(n *html.Node, base *url.URL) string {
+	var image string
+
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		for c := n.FirstChild; c != nil && image == ""; c = c.NextSibling {
+			if c.Type != html.ElementNode {
+				continue
+			}
+
+			if c.DataAtom == atom.Img && !isTiny(c) {
+				src := attr(c, "src")
+				if src == "" || strings.HasPrefix(src, "data:") {
+					src = attr(c, "data-src")
+				}
+				image = resolve(base, src)
+				continue
+			}
+			walk(c)
+		}
+	}
+	walk(n)
+
+	return image
+}
+
+// isTiny сообщает, что у изображения указан размер меньше 100 пикселей.
+func isTiny(n *html.Node) bool {
+	for _, key := range []string{"width", "height"} {
+		var size int
+		if _, err := fmt.Sscanf(attr(n, key), "%d", &size); err == nil && size > 0 && size < 100 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// resolve разрешает ссылку относительно адреса страницы. Возвращает пустую строку для
+// невалидных ссылок и ссылок не по http(s).
+func resolve(base *url.URL, ref string) string {
+	ref = strings.TrimSpace(ref)
+	if ref == "" || strings.HasPrefix(ref, "data:") {
+		return ""
+	}
+
+	u, err := url.Parse(ref)
+	if err != nil {
+		return ""
+	}
+	if base != nil {
+		u = base.ResolveReference(u)
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return ""
+	}
+
+	return u.String()
+}
+
+// textContent возвращает весь текст узла.
+func textContent(n *html.Node) string {
+	if n.Type == html.TextNode {
+		return n.Data
+	}
+
+	var b strings.Builder
+	for c := n.FirstChild; c != nil; c = c.NextSibling {
+		b.WriteString(textContent(c))
+	}
+
+	return b.String()
+}
+
+// collapseSpace заменяет последовательности пробельных символов одним пробелом.
+func collapseSpace(s string) string {
+	return strings.Join(strings.Fields(s), " ")
+}
+
+// attr возвращает значение атрибута элемента.
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+
+	return ""
+}
@@ -0,0 +1,172 @@
+package readability
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestExtract(t *testing.T) {
+	f, err := os.Open("testdata/article.html")
+	if err != nil {
+		t.Fatalf("open fixture: %v", err)
+	}
+	defer f.Close()
+
+	base, _ := url.Parse("https://habr.com/ru/articles/1/")
+
+	article, err := Extract(f, base)
+	if err != nil {
+		t.Fatalf("Extract() unexpected error: %v", err)
+	}
+
+	for _, want := range []string{
+		"Команда Go представила релиз 1.24.",
+		"реализация map переписана на основе Swiss Tables",
+		"go install golang.org/dl/go1.24@latest\ngo1.24 download",
+		"новый пакет weak, директива tool",
+	} {
+		if !strings.Contains(article.Body, want) {
+			t.Errorf("body does not contain %q:\n%s", want, article.Body)
+		}
+	}
+
+	for _, unwanted := range []string{"Главная", "Популярное за неделю", "Отличная новость", "Все права защищены", "dataLayer"} {
+		if strings.Contains(article.Body, unwanted) {
+			t.Errorf("body must not contain %q:\n%s", unwanted, article.Body)
+		}
+	}
+
+	if strings.Contains(article.Body, "обобщенных псевдонимов типов, которые теперь можно\n") {
+		t.Error("whitespace inside paragraphs must be collapsed")
+	}
+
+	if article.Image != "https://habr.com/share/images/go-1-24.png" {
+		t.Errorf("Image = %q, want og:image resolved against page URL", article.Image)
+	}
+}
+
+func TestExtract_FirstImage(t *testing.T) {
+	page := `<html><body><div class="post">
+		<img src="/pixel.gif" width="1" height="1">
+		<p>` + strings.Repeat("Текст статьи, достаточно длинный для извлечения. ", 10) + `</p>
+		<img src="lead.jpg">
+	</div></body></html>`
+
+	base, _ := url.Parse("https://example.com/news/1")
+
+	article, err := Extract(strings.NewReader(page), base)
+	if err != nil {
+		t.Fatalf("Extract() unexpected error: %v", err)
+	}
+	if article.Image != "https://example.com/news/lead.jpg" {
+		t.Errorf("Image = %q, want first non-tiny image", article.Image)
+	}
+}
+
+func TestExtract_NoArticle(t *testing.T) {
+	page := `<html><body><nav><a href="/">Главная</a></nav><p>Страница не найдена</p></body></html>`
+
+	if _, err := Extract(strings.NewReader(page), nil); !errors.Is(err, ErrNoArticle) {
+		t.Errorf("expected ErrNoArticle, got %v", err)
+	}
+}
+
+func TestExtractor_Fetch(t *testing.T) {
+	page, err := os.ReadFile("testdata/article.html")
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(
+		"/article", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html; charset=utf-8")
+			_, _ = w.Write(page)
+		},
+	)
+	mux.HandleFunc(
+		"/moved", func(w http.ResponseWriter, r *http.Request) {
+			http.Redirect(w, r, "/article", http.StatusMovedPermanently)
+		},
+	)
+	mux.HandleFunc(
+		"/file.pdf", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "application/pdf")
+			_, _ = w.Write([]byte("%PDF-1.4"))
+		},
+	)
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	e := NewExtractor(time.Second)
+
+	article, err := e.Fetch(context.Background(), srv.URL+"/moved")
+	if err != nil {
+		t.Fatalf("Fetch() unexpected error: %v", err)
+	}
+	if article.Image != srv.URL+"/share/images/go-1-24.png" {
+		t.Errorf("Image = %q, want resolved against final URL", article.Image)
+	}
+
+	if _, err = e.Fetch(context.Background(), srv.URL+"/missing"); !errors.Is(err, ErrUnexpectedStatus) {
+		t.Errorf("expected ErrUnexpectedStatus, got %v", err)
+	}
+
+	if _, err = e.Fetch(context.Background(), srv.URL+"/file.pdf"); !errors.Is(err, ErrNotHTML) {
+		t.Errorf("expected ErrNotHTML, got %v", err)
+	}
+}
+
+func TestExtractor_Fetch_Charset(t *testing.T) {
+	page, err := os.ReadFile("testdata/article_cp1251.html")
+	if err != nil {
+		t.Fatalf("read fixture: %v", err)
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(
+		"/header", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html; charset=windows-1251")
+			_, _ = w.Write(page)
+		},
+	)
+	mux.HandleFunc(
+		"/meta", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html")
+			_, _ = w.Write(page)
+		},
+	)
+
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	e := NewExtractor(time.Second)
+
+	for _, path := range []string{"/header", "/meta"} {
+		t.Run(
+			path, func(t *testing.T) {
+				article, err := e.Fetch(context.Background(), srv.URL+path)
+				if err != nil {
+					t.Fatalf("Fetch() unexpected error: %v", err)
+				}
+
+				for _, want := range []string{
+					"Команда Go представила релиз 1.24.",
+					"реализация map переписана на основе Swiss Tables",
+				} {
+					if !strings.Contains(article.Body, want) {
+						t.Errorf("body does not contain %q:\n%s", want, article.Body)
+					}
+				}
+			},
+		)
+	}
+}
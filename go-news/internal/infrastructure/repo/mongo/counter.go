@@ -0,0 +1,28 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// nextSequence возвращает следующее значение счетчика name из коллекции counters.
+func nextSequence(ctx context.Context, db *mongo.Database, name string) (int32, error) {
+	filter := bson.M{"_id": name}
+	update := bson.M{"$inc": bson.M{"seq": 1}}
+
+	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+
+	var result struct {
+		Seq int32 `bson:"seq"`
+	}
+	err := db.Collection("counters").FindOneAndUpdate(ctx, filter, update, opts).Decode(&result)
+	if err != nil {
+		return 0, fmt.Errorf("getNextID: %w", err)
+	}
+
+	return result.Seq, nil
+}
@@ -0,0 +1,152 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+// DuplicatePosts - группа новостей с одной ссылкой: Kept остается, Removed удалены.
+type DuplicatePosts struct {
+	Link    string
+	Kept    int32
+	Removed []int32
+}
+
+// DedupePosts удаляет новости с повторяющейся ссылкой, сохраненные до появления уникального индекса
+// ссылки, и возвращает обработанные группы. Если индекс уже создан, дубликатов нет и коллекция
+// не просматривается. Выполняется командой dedupe-posts, а не при запуске сервиса.
+// Из каждой группы остается новость с наименьшим ID. Учтенные комментарии удаленных новостей переносятся
+// в оставшуюся, ссылки кластеров на удаленные новости заменяются ссылкой на нее, а счетчик идентификаторов
+// не опускается ниже наибольшего ID, чтобы удаленные ID не выдавались повторно.
+// Комментарии go-comments хранятся в другой базе и не переносятся: по возвращенным группам их нужно
+// перенести отдельно.
+func DedupePosts(ctx context.Context, db *mongo.Database) ([]DuplicatePosts, error) {
+	posts := db.Collection("posts")
+
+	specs, err := posts.Indexes().ListSpecifications(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("DedupePosts.ListSpecifications: %w", err)
+	}
+	for _, spec := range specs {
+		if spec.Name == postsLinkIndex {
+			return nil, nil
+		}
+	}
+
+	cursor, err := posts.Aggregate(
+		ctx, mongo.Pipeline{
+			{{Key: "$group", Value: bson.M{
+				"_id":   "$link",
+				"ids":   bson.M{"$push": "$_id"},
+				"count": bson.M{"$sum": 1},
+			}}},
+			{{Key: "$match", Value: bson.M{"count": bson.M{"$gt": 1}}}},
+		},
+		options.Aggregate().SetAllowDiskUse(true),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("DedupePosts.Aggregate: %w", err)
+	}
+
+	var groups []struct {
+		Link string  `bson:"_id"`
+		IDs  []int32 `bson:"ids"`
+	}
+	if err = cursor.All(ctx, &groups); err != nil {
+		return nil, fmt.Errorf("DedupePosts.Decode: %w", err)
+	}
+
+	merged := make([]DuplicatePosts, 0, len(groups))
+	for _, group := range groups {
+		slices.Sort(group.IDs)
+		dup := DuplicatePosts{Link: group.Link, Kept: group.IDs[0], Removed: group.IDs[1:]}
+		if err = mergeDuplicatePosts(ctx, posts, dup.Kept, dup.Removed); err != nil {
+			return merged, fmt.Errorf("DedupePosts: %w", err)
+		}
+		merged = append(merged, dup)
+	}
+	if len(merged) == 0 {
+		return merged, nil
+	}
+
+	var last struct {
+		ID int32 `bson:"_id"`
+	}
+	err = posts.FindOne(ctx, bson.M{}, options.FindOne().SetSort(bson.M{"_id": -1})).Decode(&last)
+	if err != nil {
+		return merged, fmt.Errorf("DedupePosts.FindLast: %w", err)
+	}
+	_, err = db.Collection("counters").UpdateOne(
+		ctx, bson.M{"_id": "posts"}, bson.M{"$max": bson.M{"seq": last.ID}}, options.UpdateOne().SetUpsert(true),
+	)
+	if err != nil {
+		return merged, fmt.Errorf("DedupePosts.UpdateCounter: %w", err)
+	}
+
+	return merged, nil
+}
+
+// mergeDuplicatePosts удаляет новости removed, перенося их учтенные комментарии и кластеры в новость keep.
+// Счетчик комментариев keep пересчитывается по объединенным ID комментариев; комментарии, учтенные
+// без ID, суммируются.
+func mergeDuplicatePosts(ctx context.Context, posts *mongo.Collection, keep int32, removed []int32) error {
+	ids := append([]int32{keep}, removed...)
+
+	cursor, err := posts.Find(
+		ctx,
+		bson.M{"_id": bson.M{"$in": ids}},
+		options.Find().SetProjection(bson.M{"comment_ids": 1, "comments_count": 1}),
+	)
+	if err != nil {
+		return fmt.Errorf("mergeDuplicatePosts.Find: %w", err)
+	}
+
+	var docs []struct {
+		CommentIDs    []int64 `bson:"comment_ids"`
+		CommentsCount int64   `bson:"comments_count"`
+	}
+	if err = cursor.All(ctx, &docs); err != nil {
+		return fmt.Errorf("mergeDuplicatePosts.Decode: %w", err)
+	}
+
+	var (
+		commentIDs []int64
+		untracked  int64
+	)
+	for _, doc := range docs {
+		for _, id := range doc.CommentIDs {
+			if !slices.Contains(commentIDs, id) {
+				commentIDs = append(commentIDs, id)
+			}
+		}
+		untracked += max(doc.CommentsCount-int64(len(doc.CommentIDs)), 0)
+	}
+
+	if count := int64(len(commentIDs)) + untracked; count > 0 {
+		set := bson.M{"comments_count": count}
+		if len(commentIDs) > 0 {
+			set["comment_ids"] = commentIDs
+		}
+		if _, err = posts.UpdateByID(ctx, keep, bson.M{"$set": set}); err != nil {
+			return fmt.Errorf("mergeDuplicatePosts.UpdateComments: %w", err)
+		}
+	}
+
+	_, err = posts.UpdateMany(
+		ctx, bson.M{"cluster_id": bson.M{"$in": removed}}, bson.M{"$set": bson.M{"cluster_id": keep}},
+	)
+	if err != nil {
+		return fmt.Errorf("mergeDuplicatePosts.UpdateClusters: %w", err)
+	}
+
+	if _, err = posts.DeleteMany(ctx, bson.M{"_id": bson.M{"$in": removed}}); err != nil {
+		return fmt.Errorf("mergeDuplicatePosts.DeleteMany: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,245 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	dom "github.com/ee-crocush/go-news/go-news/internal/domain/feed"
+	"github.com/ee-crocush/go-news/go-news/internal/infrastructure/repo/mongo/mapper"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+var _ dom.Repository = (*FeedRepository)(nil)
+
+// FeedRepository представляет собой репозиторий для работы с источниками новостей в MongoDB.
+type FeedRepository struct {
+	db         *mongo.Database
+	collection *mongo.Collection
+	timeout    time.Duration
+}
+
+// NewFeedRepository создаёт новый Mongo-репозиторий с источниками новостей.
+func NewFeedRepository(db *mongo.Database, timeout time.Duration) *FeedRepository {
+	return &FeedRepository{
+		db:         db,
+		collection: db.Collection("feeds"),
+		timeout:    timeout,
+	}
+}
+
+// Save создает источник, если у него еще нет идентификатора, иначе обновляет его настройки.
+func (r *FeedRepository) Save(ctx context.Context, feed *dom.Feed) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	if feed.ID().Value() == 0 {
+		id, err := nextSequence(ctx, r.db, "feeds")
+		if err != nil {
+			return fmt.Errorf("FeedRepository.Save: %w", err)
+		}
+
+		feedID, err := dom.NewFeedID(id)
+		if err != nil {
+			return fmt.Errorf("FeedRepository.Save: %w", err)
+		}
+
+		if _, err = r.collection.InsertOne(ctx, mapper.FromFeedToDoc(feedWithID(feed, feedID))); err != nil {
+			if mongo.IsDuplicateKeyError(err) {
+				return fmt.Errorf("FeedRepository.Save: %w", dom.ErrFeedAlreadyExists)
+			}
+			return fmt.Errorf("FeedRepository.Save: %w", err)
+		}
+
+		feed.SetID(feedID)
+
+		return nil
+	}
+
+	doc := mapper.FromFeedToDoc(feed)
+
+	// Обновляются только настройки источника: результат опроса, завершившегося после загрузки
+	// источника, не откатывается. Валидаторы кэша сбрасываются только при смене адреса ленты.
+	// Строки передаются через $literal, чтобы значение, начинающееся с "$", не читалось как имя поля.
+	sameURL := bson.M{"$eq": bson.A{"$url", bson.M{"$literal": doc.URL}}}
+	update := mongo.Pipeline{
+		{{Key: "$set", Value: bson.M{
+			"url":           bson.M{"$literal": doc.URL},
+			"title":         bson.M{"$literal": doc.Title},
+			"disabled":      doc.Disabled,
+			"poll_interval": doc.PollInterval,
+			"fetch_full":    doc.FetchFull,
+			"etag":          bson.M{"$cond": bson.A{sameURL, "$etag", ""}},
+			"last_modified": bson.M{"$cond": bson.A{sameURL, "$last_modified", ""}},
+		}}},
+	}
+
+	res, err := r.collection.UpdateOne(ctx, bson.M{"_id": doc.ID}, update)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return fmt.Errorf("FeedRepository.Save: %w", dom.ErrFeedAlreadyExists)
+		}
+		return fmt.Errorf("FeedRepository.Save: %w", err)
+	}
+
+	if res.MatchedCount == 0 {
+		return fmt.Errorf("FeedRepository.Save: %w", dom.ErrFeedNotFound)
+	}
+
+	return nil
+}
+
+// SaveFetch обновляет только результат последнего опроса и счетчик новостей источника, чтобы изменения
+// настроек, сделанные во время опроса, не перезаписывались загруженной до опроса копией.
+func (r *FeedRepository) SaveFetch(ctx context.Context, feed *dom.Feed, inserted int) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	doc := mapper.FromFeedToDoc(feed)
+
+	update := bson.M{
+		"$set": bson.M{
+			"last_status":     doc.LastStatus,
+			"last_error":      doc.LastError,
+			"last_warnings":   doc.LastWarnings,
+			"last_fetched_at": doc.LastFetchedAt,
+		},
+		"$inc": bson.M{"item_count": int64(inserted)},
+	}
+
+	res, err := r.collection.UpdateOne(ctx, bson.M{"_id": doc.ID}, update)
+	if err != nil {
+		return fmt.Errorf("FeedRepository.SaveFetch: %w", err)
+	}
+
+	if res.MatchedCount == 0 {
+		return fmt.Errorf("FeedRepository.SaveFetch: %w", dom.ErrFeedNotFound)
+	}
+
+	return nil
+}
+
+// SaveValidators обновляет ETag и Last-Modified источника. Если адрес ленты изменился во время опроса,
+// валидаторы относятся к старому адресу и не сохраняются.
+func (r *FeedRepository) SaveValidators(ctx context.Context, feed *dom.Feed) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{"_id": feed.ID().Value(), "url": feed.URL().Value()}
+	update := bson.M{"$set": bson.M{"etag": feed.ETag(), "last_modified": feed.LastModified()}}
+
+	if _, err := r.collection.UpdateOne(ctx, filter, update); err != nil {
+		return fmt.Errorf("FeedRepository.SaveValidators: %w", err)
+	}
+
+	return nil
+}
+
+// feedWithID возвращает копию источника с присвоенным идентификатором, чтобы при ошибке
+// вставки исходная сущность осталась без ID.
+func feedWithID(feed *dom.Feed, id dom.FeedID) *dom.Feed {
+	cp := *feed
+	cp.SetID(id)
+	return &cp
+}
+
+// Delete удаляет источник по ID.
+func (r *FeedRepository) Delete(ctx context.Context, id dom.FeedID) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.collection.DeleteOne(ctx, bson.M{"_id": id.Value()})
+	if err != nil {
+		return fmt.Errorf("FeedRepository.Delete: %w", err)
+	}
+
+	if res.DeletedCount == 0 {
+		return fmt.Errorf("FeedRepository.Delete: %w", dom.ErrFeedNotFound)
+	}
+
+	return nil
+}
+
+// FindByID находит источник по ID.
+func (r *FeedRepository) FindByID(ctx context.Context, id dom.FeedID) (*dom.Feed, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var doc mapper.FeedDocument
+
+	if err := r.collection.FindOne(ctx, bson.M{"_id": id.Value()}).Decode(&doc); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("FeedRepository.FindByID: %w", dom.ErrFeedNotFound)
+		}
+		return nil, fmt.Errorf("FeedRepository.FindByID: %w", err)
+	}
+
+	return mapper.MapDocToFeed(doc)
+}
+
+// FindByURL находит источник по адресу ленты.
+func (r *FeedRepository) FindByURL(ctx context.Context, url dom.FeedURL) (*dom.Feed, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var doc mapper.FeedDocument
+
+	if err := r.collection.FindOne(ctx, bson.M{"url": url.Value()}).Decode(&doc); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("FeedRepository.FindByURL: %w", dom.ErrFeedNotFound)
+		}
+		return nil, fmt.Errorf("FeedRepository.FindByURL: %w", err)
+	}
+
+	return mapper.MapDocToFeed(doc)
+}
+
+// FindAll находит все источники, отсортированные по ID.
+func (r *FeedRepository) FindAll(ctx context.Context) ([]*dom.Feed, error) {
+	feeds, err := r.find(ctx, bson.M{})
+	if err != nil {
+		return nil, fmt.Errorf("FeedRepository.FindAll: %w", err)
+	}
+
+	return feeds, nil
+}
+
+// FindEnabled находит включенные источники, отсортированные по ID.
+func (r *FeedRepository) FindEnabled(ctx context.Context) ([]*dom.Feed, error) {
+	feeds, err := r.find(ctx, bson.M{"disabled": bson.M{"$ne": true}})
+	if err != nil {
+		return nil, fmt.Errorf("FeedRepository.FindEnabled: %w", err)
+	}
+
+	return feeds, nil
+}
+
+func (r *FeedRepository) find(ctx context.Context, filter bson.M) ([]*dom.Feed, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	cursor, err := r.collection.Find(ctx, filter, options.Find().SetSort(bson.D{{Key: "_id", Value: 1}}))
+	if err != nil {
+		return nil, err
+	}
+	defer cursor.Close(ctx)
+
+	var docs []mapper.FeedDocument
+	if err = cursor.All(ctx, &docs); err != nil {
+		return nil, err
+	}
+
+	feeds := make([]*dom.Feed, 0, len(docs))
+	for _, doc := range docs {
+		feed, err := mapper.MapDocToFeed(doc)
+		if err != nil {
+			return nil, err
+		}
+		feeds = append(feeds, feed)
+	}
+
+	return feeds, nil
+}
@@ -3,16 +3,44 @@ package mongo
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
 
 	"github.com/ee-crocush/go-news/go-news/internal/infrastructure/config"
+	"github.com/ee-crocush/go-news/go-news/internal/infrastructure/repo/mongo/mapper"
+	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
 	"go.mongodb.org/mongo-driver/v2/mongo/readpref"
 )
 
+// ErrDuplicatePosts - в posts есть новости с повторяющейся ссылкой, сохраненные до появления уникального
+// индекса ссылки. Их нужно удалить командой dedupe-posts до запуска сервиса.
+var ErrDuplicatePosts = errors.New(
+	"posts contain duplicate links, run `go run ./cmd/dedupe-posts` (make dedupe-posts) before starting the service",
+)
+
 // Init инициализирует БД Монго и возвращает клиент MongoDB и выбранную базу.
 func Init(cfg *config.Config) (*mongo.Client, *mongo.Database, error) {
+	client, db, err := Connect(cfg)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.MongoDB.ConnectTimeout)
+	defer cancel()
+
+	if err = ensureIndexes(ctx, db, cfg.Scheduler.GetRunHistoryTTL(), cfg.Outbox.GetRetention()); err != nil {
+		_ = client.Disconnect(context.Background())
+		return nil, nil, fmt.Errorf("Init.MongoDB.EnsureIndexes: %w", err)
+	}
+
+	return client, db, nil
+}
+
+// Connect подключается к MongoDB и возвращает клиент и выбранную базу, не создавая индексов.
+func Connect(cfg *config.Config) (*mongo.Client, *mongo.Database, error) {
 	uri := cfg.MongoDB.URI().String()
 	clientOpts := options.Client().ApplyURI(uri).SetConnectTimeout(cfg.MongoDB.ConnectTimeout)
 
@@ -21,13 +49,182 @@ func Init(cfg *config.Config) (*mongo.Client, *mongo.Database, error) {
 
 	client, err := mongo.Connect(clientOpts)
 	if err != nil {
-		return nil, nil, fmt.Errorf("Init.MongoDB.Connect: %w", err)
+		return nil, nil, fmt.Errorf("Connect.MongoDB.Connect: %w", err)
 	}
 
 	if err = client.Ping(ctx, readpref.Primary()); err != nil {
-		return nil, nil, fmt.Errorf("Init.MongoDB.Ping: %w", err)
+		_ = client.Disconnect(context.Background())
+		return nil, nil, fmt.Errorf("Connect.MongoDB.Ping: %w", err)
 	}
 
-	db := client.Database(cfg.MongoDB.Database)
-	return client, db, nil
+	return client, client.Database(cfg.MongoDB.Database), nil
+}
+
+const (
+	// runsCollection - коллекция журнала опросов источников.
+	runsCollection = "feed_runs"
+	// runsTTLIndex - имя TTL-индекса журнала опросов.
+	runsTTLIndex = "feed_runs_ttl"
+	// outboxCollection - коллекция событий, ожидающих отправки в Kafka.
+	outboxCollection = "outbox"
+	// outboxTTLIndex - имя TTL-индекса отправленных событий.
+	outboxTTLIndex = "outbox_sent_ttl"
+	// postsLinkIndex - имя уникального индекса ссылки новости.
+	postsLinkIndex = "link_1"
+	// codeIndexOptionsConflict - код ошибки MongoDB при создании индекса с другими параметрами.
+	codeIndexOptionsConflict = 85
+)
+
+// ensureIndexes создает индексы коллекций, если они еще не созданы.
+// Если уникальный индекс ссылки не создается из-за новостей, сохраненных до его появления, возвращается
+// ErrDuplicatePosts: дубликаты удаляются отдельной командой (см. DedupePosts).
+// Записи журнала опросов хранятся runsTTL, отправленные события outbox - outboxTTL.
+func ensureIndexes(ctx context.Context, db *mongo.Database, runsTTL, outboxTTL time.Duration) error {
+	_, err := db.Collection("posts").Indexes().CreateMany(
+		ctx, []mongo.IndexModel{
+			{
+				Keys:    bson.D{{Key: "link", Value: 1}},
+				Options: options.Index().SetName(postsLinkIndex).SetUnique(true),
+			},
+			{
+				// Документы, сохраненные до появления ключа дедупликации, его не содержат
+				Keys: bson.D{{Key: "key", Value: 1}},
+				Options: options.Index().SetUnique(true).
+					SetPartialFilterExpression(bson.M{"key": bson.M{"$type": "string"}}),
+			},
+			{
+				// Порядок ленты и keyset-пагинация по (pub_time, _id).
+				Keys: bson.D{{Key: "pub_time", Value: -1}, {Key: "_id", Value: -1}},
+			},
+			{
+				Keys: bson.D{{Key: "source.feed_url", Value: 1}, {Key: "pub_time", Value: -1}},
+			},
+			{
+				Keys: bson.D{{Key: "categories", Value: 1}, {Key: "pub_time", Value: -1}},
+			},
+			{
+				// Примеры для обучения рубрикатора.
+				Keys: bson.D{{Key: "categories_labeled", Value: 1}},
+				Options: options.Index().
+					SetPartialFilterExpression(bson.M{"categories_labeled": true}),
+			},
+			{
+				// Поиск почти одинаковых новостей по частям отпечатка.
+				Keys: bson.D{{Key: "simhash_bands", Value: 1}, {Key: "pub_time", Value: -1}},
+			},
+			{
+				// Полнотекстовый индекс: заголовок весомее содержания, язык стемминга берется
+				// из поля language документа (новости без него индексируются как русские).
+				Keys: bson.D{{Key: "title", Value: "text"}, {Key: "content", Value: "text"}},
+				Options: options.Index().
+					SetName("posts_text").
+					SetWeights(bson.D{{Key: "title", Value: 5}, {Key: "content", Value: 1}}).
+					SetDefaultLanguage(mapper.LanguageRussian).
+					SetLanguageOverride("language"),
+			},
+			{
+				Keys: bson.D{{Key: "source.site_link", Value: 1}, {Key: "pub_time", Value: -1}},
+			},
+			{
+				// Мультиключевой индекс: отбор по тегу и подсчет тегов.
+				Keys: bson.D{{Key: "tags", Value: 1}, {Key: "pub_time", Value: -1}},
+			},
+		},
+	)
+	if mongo.IsDuplicateKeyError(err) {
+		return fmt.Errorf("posts: %w", ErrDuplicatePosts)
+	}
+	if err != nil {
+		return fmt.Errorf("posts: %w", err)
+	}
+
+	_, err = db.Collection("feeds").Indexes().CreateOne(
+		ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: "url", Value: 1}},
+			Options: options.Index().SetUnique(true),
+		},
+	)
+	if err != nil {
+		return fmt.Errorf("feeds: %w", err)
+	}
+
+	if err = ensureRunIndexes(ctx, db, runsTTL); err != nil {
+		return fmt.Errorf("%s: %w", runsCollection, err)
+	}
+
+	if err = ensureOutboxIndexes(ctx, db, outboxTTL); err != nil {
+		return fmt.Errorf("%s: %w", outboxCollection, err)
+	}
+
+	return nil
+}
+
+// ensureRunIndexes создает индексы журнала опросов.
+func ensureRunIndexes(ctx context.Context, db *mongo.Database, ttl time.Duration) error {
+	_, err := db.Collection(runsCollection).Indexes().CreateOne(
+		ctx, mongo.IndexModel{
+			Keys: bson.D{{Key: "feed_id", Value: 1}, {Key: "started_at", Value: -1}},
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	return ensureTTLIndex(ctx, db, runsCollection, runsTTLIndex, "started_at", ttl)
+}
+
+// ensureOutboxIndexes создает индексы outbox. TTL-индекс удаляет только отправленные события:
+// у ожидающих отправки поля sent_at нет.
+func ensureOutboxIndexes(ctx context.Context, db *mongo.Database, ttl time.Duration) error {
+	_, err := db.Collection(outboxCollection).Indexes().CreateOne(
+		ctx, mongo.IndexModel{
+			// Выборка готовых к отправке событий в порядке очереди.
+			Keys: bson.D{{Key: "status", Value: 1}, {Key: "next_attempt_at", Value: 1}, {Key: "_id", Value: 1}},
+		},
+	)
+	if err != nil {
+		return err
+	}
+
+	return ensureTTLIndex(ctx, db, outboxCollection, outboxTTLIndex, "sent_at", ttl)
+}
+
+// ensureTTLIndex создает TTL-индекс name по полю field. Если срок хранения изменился в конфигурации,
+// TTL существующего индекса обновляется командой collMod: повторное создание индекса с другим
+// expireAfterSeconds MongoDB отклоняет.
+func ensureTTLIndex(ctx context.Context, db *mongo.Database, collection, name, field string, ttl time.Duration) error {
+	seconds := int32(ttl / time.Second)
+
+	_, err := db.Collection(collection).Indexes().CreateOne(
+		ctx, mongo.IndexModel{
+			Keys:    bson.D{{Key: field, Value: 1}},
+			Options: options.Index().SetName(name).SetExpireAfterSeconds(seconds),
+		},
+	)
+
+	var serverErr mongo.ServerError
+	if err == nil || !errors.As(err, &serverErr) || !serverErr.HasErrorCode(codeIndexOptionsConflict) {
+		return err
+	}
+
+	return db.RunCommand(
+		ctx, bson.D{
+			{Key: "collMod", Value: collection},
+			{Key: "index", Value: bson.M{"name": name, "expireAfterSeconds": seconds}},
+		},
+	).Err()
+}
+
+// SupportsTransactions сообщает, поддерживает ли сервер MongoDB транзакции: они доступны только
+// в replica set и шардированном кластере, но не на отдельном сервере.
+func SupportsTransactions(ctx context.Context, db *mongo.Database) (bool, error) {
+	var hello struct {
+		SetName string `bson:"setName"`
+		Msg     string `bson:"msg"`
+	}
+	if err := db.RunCommand(ctx, bson.D{{Key: "hello", Value: 1}}).Decode(&hello); err != nil {
+		return false, fmt.Errorf("SupportsTransactions: %w", err)
+	}
+
+	return hello.SetName != "" || hello.Msg == "isdbgrid", nil
 }
@@ -0,0 +1,83 @@
+package mapper
+
+import (
+	"fmt"
+	"time"
+
+	dom "github.com/ee-crocush/go-news/go-news/internal/domain/feed"
+)
+
+// FeedDocument - структура для маппинга источника новостей из Mongo.
+// Источник хранит признак disabled, а не enabled: так документы, созданные до появления
+// флага, остаются включенными.
+type FeedDocument struct {
+	ID            int32    `bson:"_id,omitempty"`
+	URL           string   `bson:"url"`
+	Title         string   `bson:"title,omitempty"`
+	Disabled      bool     `bson:"disabled,omitempty"`
+	PollInterval  int      `bson:"poll_interval,omitempty"`
+	ETag          string   `bson:"etag,omitempty"`
+	LastModified  string   `bson:"last_modified,omitempty"`
+	LastStatus    string   `bson:"last_status,omitempty"`
+	LastError     string   `bson:"last_error,omitempty"`
+	LastWarnings  []string `bson:"last_warnings,omitempty"`
+	LastFetchedAt int64    `bson:"last_fetched_at,omitempty"`
+	ItemCount     int64    `bson:"item_count"`
+	FetchFull     bool     `bson:"fetch_full,omitempty"`
+}
+
+// MapDocToFeed - функция для маппинга источника из Mongo.
+func MapDocToFeed(doc FeedDocument) (*dom.Feed, error) {
+	id, err := dom.NewFeedID(doc.ID)
+	if err != nil {
+		return nil, fmt.Errorf("MapDocToFeed.NewFeedID: %w", err)
+	}
+
+	url, err := dom.NewFeedURL(doc.URL)
+	if err != nil {
+		return nil, fmt.Errorf("MapDocToFeed.NewFeedURL: %w", err)
+	}
+
+	interval, err := dom.NewPollInterval(doc.PollInterval)
+	if err != nil {
+		return nil, fmt.Errorf("MapDocToFeed.NewPollInterval: %w", err)
+	}
+
+	var fetchedAt time.Time
+	if doc.LastFetchedAt != 0 {
+		fetchedAt = time.Unix(doc.LastFetchedAt, 0)
+	}
+
+	lastFetch := dom.RehydrateLastFetch(dom.FetchStatus(doc.LastStatus), doc.LastError, doc.LastWarnings, fetchedAt)
+
+	f := dom.RehydrateFeed(
+		id, url, doc.Title, !doc.Disabled, interval, doc.ETag, doc.LastModified, lastFetch, doc.ItemCount,
+	)
+	f.SetFetchFullArticle(doc.FetchFull)
+
+	return f, nil
+}
+
+// FromFeedToDoc маппинг доменной модели источника в MongoDB-документ.
+func FromFeedToDoc(f *dom.Feed) *FeedDocument {
+	var fetchedAt int64
+	if at := f.LastFetch().At(); !at.IsZero() {
+		fetchedAt = at.Unix()
+	}
+
+	return &FeedDocument{
+		ID:            f.ID().Value(),
+		URL:           f.URL().Value(),
+		Title:         f.Title(),
+		Disabled:      !f.Enabled(),
+		PollInterval:  f.PollInterval().Minutes(),
+		ETag:          f.ETag(),
+		LastModified:  f.LastModified(),
+		LastStatus:    string(f.LastFetch().Status()),
+		LastError:     f.LastFetch().Error(),
+		LastWarnings:  f.LastFetch().Warnings(),
+		LastFetchedAt: fetchedAt,
+		ItemCount:     f.ItemCount(),
+		FetchFull:     f.FetchFullArticle(),
+	}
+}
@@ -0,0 +1,31 @@
+package mapper
+
+import "unicode"
+
+const (
+	// LanguageRussian - язык текстового индекса MongoDB для русских текстов.
+	LanguageRussian = "russian"
+	// LanguageEnglish - язык текстового индекса MongoDB для английских текстов.
+	LanguageEnglish = "english"
+)
+
+// DetectLanguage определяет язык текста для стемминга в текстовом индексе MongoDB.
+// Текст считается русским, если кириллических букв в нем не меньше, чем латинских.
+func DetectLanguage(text string) string {
+	var cyrillic, latin int
+
+	for _, r := range text {
+		switch {
+		case unicode.Is(unicode.Cyrillic, r):
+			cyrillic++
+		case unicode.Is(unicode.Latin, r):
+			latin++
+		}
+	}
+
+	if latin > cyrillic {
+		return LanguageEnglish
+	}
+
+	return LanguageRussian
+}
@@ -0,0 +1,21 @@
+package mapper
+
+import "testing"
+
+func TestDetectLanguage(t *testing.T) {
+	testCases := []struct {
+		text string
+		want string
+	}{
+		{text: "Вышел Go 1.24 с поддержкой итераторов", want: LanguageRussian},
+		{text: "Go 1.24 is released with range-over-func", want: LanguageEnglish},
+		{text: "Kubernetes и Docker", want: LanguageEnglish},
+		{text: "", want: LanguageRussian},
+	}
+
+	for _, tc := range testCases {
+		if got := DetectLanguage(tc.text); got != tc.want {
+			t.Errorf("DetectLanguage(%q) = %q, want %q", tc.text, got, tc.want)
+		}
+	}
+}
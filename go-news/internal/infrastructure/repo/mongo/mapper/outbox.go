@@ -0,0 +1,59 @@
+package mapper
+
+import (
+	"time"
+
+	"github.com/ee-crocush/go-news/pkg/outbox"
+	"go.mongodb.org/mongo-driver/v2/bson"
+)
+
+const (
+	// OutboxStatusPending - событие ожидает отправки.
+	OutboxStatusPending = "pending"
+	// OutboxStatusSent - событие отправлено.
+	OutboxStatusSent = "sent"
+)
+
+// OutboxDocument - структура для маппинга события outbox из Mongo.
+// Время хранится в BSON Date: TTL-индекс удаляет отправленные события по sent_at.
+type OutboxDocument struct {
+	ID      bson.ObjectID `bson:"_id"`
+	Type    string        `bson:"type"`
+	Key     string        `bson:"key"`
+	Payload string        `bson:"payload"`
+	// Headers - заголовки сообщения, сохраненные при записи события (request_id запроса).
+	Headers       map[string]string `bson:"headers,omitempty"`
+	Status        string            `bson:"status"`
+	Attempts      int               `bson:"attempts"`
+	CreatedAt     time.Time         `bson:"created_at"`
+	NextAttemptAt time.Time         `bson:"next_attempt_at"`
+	SentAt        *time.Time        `bson:"sent_at,omitempty"`
+	LastError     string            `bson:"last_error,omitempty"`
+}
+
+// NewOutboxDocument создает событие outbox, готовое к отправке.
+func NewOutboxDocument(
+	eventType, key string, payload []byte, headers map[string]string, now time.Time,
+) OutboxDocument {
+	return OutboxDocument{
+		ID:            bson.NewObjectID(),
+		Type:          eventType,
+		Key:           key,
+		Payload:       string(payload),
+		Headers:       headers,
+		Status:        OutboxStatusPending,
+		CreatedAt:     now,
+		NextAttemptAt: now,
+	}
+}
+
+// MapDocToOutboxMessage - функция для маппинга события outbox из Mongo.
+func MapDocToOutboxMessage(doc OutboxDocument) outbox.Message {
+	return outbox.Message{
+		ID:       doc.ID.Hex(),
+		Key:      doc.Key,
+		Payload:  []byte(doc.Payload),
+		Headers:  doc.Headers,
+		Attempts: doc.Attempts,
+	}
+}
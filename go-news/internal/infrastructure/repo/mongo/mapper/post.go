@@ -11,8 +11,58 @@ type PostDocument struct {
 	ID      int32  `bson:"_id,omitempty"`
 	Title   string `bson:"title"`
 	Content string `bson:"content"`
-	PubTime int64  `bson:"pub_time"`
-	Link    string `bson:"link"`
+	// ContentHTML - содержание в виде очищенного HTML, Content - то же содержание простым текстом.
+	ContentHTML string `bson:"content_html,omitempty"`
+	// Summary - краткое содержание для списков новостей.
+	Summary string          `bson:"summary,omitempty"`
+	PubTime int64           `bson:"pub_time"`
+	Link    string          `bson:"link"`
+	Key     string          `bson:"key,omitempty"`
+	Source  *SourceDocument `bson:"source,omitempty"`
+	// Article - полный текст статьи и главное изображение (для источников с загрузкой полного текста).
+	Article *ArticleDocument `bson:"article,omitempty"`
+	// Media - медиа-вложения новости (enclosure, media:content, media:thumbnail, изображения описания).
+	Media []MediaDocument `bson:"media,omitempty"`
+	// Tags - нормализованные теги новости.
+	Tags []string `bson:"tags,omitempty"`
+	// Categories - рубрики новости, CategoriesLabeled - рубрики проставлены вручную.
+	Categories        []string `bson:"categories,omitempty"`
+	CategoriesLabeled bool     `bson:"categories_labeled,omitempty"`
+	// CommentsCount - количество опубликованных комментариев, ведется через AddComment.
+	CommentsCount int64 `bson:"comments_count,omitempty"`
+	// Language - язык стемминга для текстового индекса (language_override).
+	Language string `bson:"language,omitempty"`
+	// Simhash - отпечаток текста новости, SimhashBands - его части для поиска почти одинаковых новостей.
+	Simhash      int64   `bson:"simhash,omitempty"`
+	SimhashBands []int64 `bson:"simhash_bands,omitempty"`
+	// ClusterID - ID первой новости кластера почти одинаковых новостей.
+	// Новости, сохраненные до появления кластеров, его не содержат и считаются отдельными кластерами.
+	ClusterID int32 `bson:"cluster_id,omitempty"`
+	// ClusterSize - количество новостей кластера, вычисляется только при схлопывании кластеров, не хранится.
+	ClusterSize int64 `bson:"cluster_size,omitempty"`
+}
+
+// SourceDocument - структура для маппинга источника новости из Mongo.
+type SourceDocument struct {
+	FeedURL  string `bson:"feed_url"`
+	Title    string `bson:"title,omitempty"`
+	SiteLink string `bson:"site_link,omitempty"`
+}
+
+// ArticleDocument - структура для маппинга полного текста статьи из Mongo.
+type ArticleDocument struct {
+	Body  string `bson:"body,omitempty"`
+	Image string `bson:"image,omitempty"`
+}
+
+// MediaDocument - структура для маппинга медиа-вложения новости из Mongo.
+type MediaDocument struct {
+	URL       string `bson:"url"`
+	Type      string `bson:"type,omitempty"`
+	Size      int64  `bson:"size,omitempty"`
+	Width     int    `bson:"width,omitempty"`
+	Height    int    `bson:"height,omitempty"`
+	Thumbnail bool   `bson:"thumbnail,omitempty"`
 }
 
 // MapDocToPost - функция для маппинга новости из Mongo.
@@ -41,16 +91,122 @@ func MapDocToPost(doc PostDocument) (*dom.Post, error) {
 		return nil, fmt.Errorf("MapDocToPost.NewPostLink: %w", err)
 	}
 
-	return dom.RehydratePost(id, title, content, pubTime, link), nil
+	post := dom.RehydratePost(id, title, content, pubTime, link)
+
+	post.SetContentHTML(dom.NewPostContentHTML(doc.ContentHTML))
+	post.SetSummary(dom.NewPostSummary(doc.Summary))
+
+	if doc.Key != "" {
+		post.SetKey(dom.RehydratePostKey(doc.Key))
+	}
+
+	if doc.Source != nil {
+		source, err := dom.NewPostSource(doc.Source.FeedURL, doc.Source.Title, doc.Source.SiteLink)
+		if err != nil {
+			return nil, fmt.Errorf("MapDocToPost.NewPostSource: %w", err)
+		}
+		post.SetSource(source)
+	}
+
+	if doc.Article != nil {
+		post.SetArticle(dom.NewPostArticle(doc.Article.Body, doc.Article.Image))
+	}
+
+	if len(doc.Media) > 0 {
+		media := make([]dom.PostMedia, 0, len(doc.Media))
+		for _, m := range doc.Media {
+			item, err := dom.NewPostMedia(m.URL, m.Type, m.Size, m.Width, m.Height, m.Thumbnail)
+			if err != nil {
+				return nil, fmt.Errorf("MapDocToPost.NewPostMedia: %w", err)
+			}
+			media = append(media, item)
+		}
+		post.SetMedia(media)
+	}
+
+	if len(doc.Tags) > 0 {
+		post.SetTags(dom.NewPostTags(doc.Tags))
+	}
+
+	if categories := dom.NewPostCategories(doc.Categories); doc.CategoriesLabeled {
+		post.LabelCategories(categories)
+	} else {
+		post.SetCategories(categories)
+	}
+
+	post.SetCommentsCount(doc.CommentsCount)
+	post.SetFingerprint(dom.RehydratePostFingerprint(uint64(doc.Simhash)))
+
+	clusterID := doc.ClusterID
+	if clusterID == 0 {
+		clusterID = doc.ID
+	}
+	post.JoinCluster(clusterID)
+	post.SetClusterSize(doc.ClusterSize)
+
+	return post, nil
 }
 
 // FromPostToDoc маппинг доменной модели новости в MongoDB-документ.
 func FromPostToDoc(p *dom.Post) *PostDocument {
+	var source *SourceDocument
+	if s := p.Source(); !s.IsZero() {
+		source = &SourceDocument{FeedURL: s.FeedURL(), Title: s.Title(), SiteLink: s.SiteLink()}
+	}
+
+	var article *ArticleDocument
+	if a := p.Article(); !a.IsZero() {
+		article = &ArticleDocument{Body: a.Body(), Image: a.Image()}
+	}
+
+	var media []MediaDocument
+	for _, m := range p.Media() {
+		media = append(
+			media, MediaDocument{
+				URL:       m.URL(),
+				Type:      m.MIMEType(),
+				Size:      m.Size(),
+				Width:     m.Width(),
+				Height:    m.Height(),
+				Thumbnail: m.Thumbnail(),
+			},
+		)
+	}
+
+	var tags []string
+	for _, tag := range p.Tags() {
+		tags = append(tags, tag.Value())
+	}
+
+	var bands []int64
+	if fp := p.Fingerprint(); !fp.IsZero() {
+		bands = fp.Bands()
+	}
+
+	var categories []string
+	for _, category := range p.Categories() {
+		categories = append(categories, category.Value())
+	}
+
 	return &PostDocument{
-		ID:      p.ID().Value(),
-		Title:   p.Title().Value(),
-		Content: p.Content().Value(),
-		PubTime: p.PubTime().Time().Unix(),
-		Link:    p.Link().Value(),
+		ID:                p.ID().Value(),
+		Title:             p.Title().Value(),
+		Content:           p.Content().Value(),
+		ContentHTML:       p.ContentHTML().Value(),
+		Summary:           p.Summary().Value(),
+		PubTime:           p.PubTime().Time().Unix(),
+		Link:              p.Link().Value(),
+		Key:               p.Key().Value(),
+		Source:            source,
+		Article:           article,
+		Media:             media,
+		Tags:              tags,
+		Categories:        categories,
+		CategoriesLabeled: p.CategoriesLabeled(),
+		Language:          DetectLanguage(p.Title().Value() + " " + p.Content().Value()),
+		// Отпечаток хранится как int64: в BSON нет беззнаковых целых.
+		Simhash:      int64(p.Fingerprint().Value()),
+		SimhashBands: bands,
+		ClusterID:    p.ClusterID(),
 	}
 }
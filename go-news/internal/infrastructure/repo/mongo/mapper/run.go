@@ -0,0 +1,60 @@
+package mapper
+
+import (
+	"fmt"
+	"time"
+
+	dom "github.com/ee-crocush/go-news/go-news/internal/domain/feed"
+)
+
+// RunDocument - структура для маппинга записи журнала опросов из Mongo.
+// Время хранится в BSON Date, а не в секундах Unix: TTL-индекс удаляет только документы с датой.
+type RunDocument struct {
+	FeedID     int32     `bson:"feed_id"`
+	URL        string    `bson:"url"`
+	StartedAt  time.Time `bson:"started_at"`
+	FinishedAt time.Time `bson:"finished_at"`
+	Status     string    `bson:"status"`
+	HTTPStatus int       `bson:"http_status,omitempty"`
+	Seen       int       `bson:"seen"`
+	Inserted   int       `bson:"inserted"`
+	Skipped    int       `bson:"skipped"`
+	Error      string    `bson:"error,omitempty"`
+}
+
+// MapDocToRun - функция для маппинга записи журнала опросов из Mongo.
+func MapDocToRun(doc RunDocument) (*dom.Run, error) {
+	id, err := dom.NewFeedID(doc.FeedID)
+	if err != nil {
+		return nil, fmt.Errorf("MapDocToRun.NewFeedID: %w", err)
+	}
+
+	url, err := dom.NewFeedURL(doc.URL)
+	if err != nil {
+		return nil, fmt.Errorf("MapDocToRun.NewFeedURL: %w", err)
+	}
+
+	counts := dom.RunCounts{Seen: doc.Seen, Inserted: doc.Inserted, Skipped: doc.Skipped}
+
+	return dom.RehydrateRun(
+		id, url, doc.StartedAt, doc.FinishedAt, dom.FetchStatus(doc.Status), doc.HTTPStatus, counts, doc.Error,
+	), nil
+}
+
+// FromRunToDoc маппинг доменной модели записи журнала опросов в MongoDB-документ.
+func FromRunToDoc(r *dom.Run) *RunDocument {
+	counts := r.Counts()
+
+	return &RunDocument{
+		FeedID:     r.FeedID().Value(),
+		URL:        r.URL().Value(),
+		StartedAt:  r.StartedAt().UTC(),
+		FinishedAt: r.FinishedAt().UTC(),
+		Status:     string(r.Status()),
+		HTTPStatus: r.HTTPStatus(),
+		Seen:       counts.Seen,
+		Inserted:   counts.Inserted,
+		Skipped:    counts.Skipped,
+		Error:      r.Error(),
+	}
+}
@@ -0,0 +1,112 @@
+package mongo
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ee-crocush/go-news/go-news/internal/infrastructure/repo/mongo/mapper"
+	"github.com/ee-crocush/go-news/pkg/outbox"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+var _ outbox.Store = (*OutboxRepository)(nil)
+
+// OutboxRepository представляет собой хранилище событий outbox в MongoDB.
+// События записывает PostRepository вместе с новостями (см. PostRepository.EnableEvents).
+type OutboxRepository struct {
+	collection *mongo.Collection
+	timeout    time.Duration
+}
+
+// NewOutboxRepository создаёт новое Mongo-хранилище событий outbox.
+func NewOutboxRepository(db *mongo.Database, timeout time.Duration) *OutboxRepository {
+	return &OutboxRepository{
+		collection: db.Collection(outboxCollection),
+		timeout:    timeout,
+	}
+}
+
+// Claim захватывает до limit готовых к отправке событий: время следующей попытки каждого
+// сдвигается на lease, поэтому другие экземпляры сервиса его не получат.
+func (r *OutboxRepository) Claim(ctx context.Context, limit int, lease time.Duration) ([]outbox.Message, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	now := time.Now()
+	filter := bson.M{"status": mapper.OutboxStatusPending, "next_attempt_at": bson.M{"$lte": now}}
+	update := bson.M{"$set": bson.M{"next_attempt_at": now.Add(lease)}}
+	opts := options.FindOneAndUpdate().SetSort(bson.D{{Key: "next_attempt_at", Value: 1}, {Key: "_id", Value: 1}})
+
+	messages := make([]outbox.Message, 0, limit)
+	for len(messages) < limit {
+		var doc mapper.OutboxDocument
+		err := r.collection.FindOneAndUpdate(ctx, filter, update, opts).Decode(&doc)
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("OutboxRepository.Claim: %w", err)
+		}
+
+		messages = append(messages, mapper.MapDocToOutboxMessage(doc))
+	}
+
+	return messages, nil
+}
+
+// MarkSent отмечает события отправленными.
+func (r *OutboxRepository) MarkSent(ctx context.Context, ids []string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	objectIDs, err := outboxObjectIDs(ids)
+	if err != nil {
+		return fmt.Errorf("OutboxRepository.MarkSent: %w", err)
+	}
+
+	update := bson.M{"$set": bson.M{"status": mapper.OutboxStatusSent, "sent_at": time.Now()}}
+	if _, err = r.collection.UpdateMany(ctx, bson.M{"_id": bson.M{"$in": objectIDs}}, update); err != nil {
+		return fmt.Errorf("OutboxRepository.MarkSent: %w", err)
+	}
+
+	return nil
+}
+
+// MarkFailed увеличивает счетчик попыток отправки событий и откладывает следующую до retryAt.
+func (r *OutboxRepository) MarkFailed(ctx context.Context, ids []string, retryAt time.Time, reason string) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	objectIDs, err := outboxObjectIDs(ids)
+	if err != nil {
+		return fmt.Errorf("OutboxRepository.MarkFailed: %w", err)
+	}
+
+	update := bson.M{
+		"$inc": bson.M{"attempts": 1},
+		"$set": bson.M{"next_attempt_at": retryAt, "last_error": reason},
+	}
+	if _, err = r.collection.UpdateMany(ctx, bson.M{"_id": bson.M{"$in": objectIDs}}, update); err != nil {
+		return fmt.Errorf("OutboxRepository.MarkFailed: %w", err)
+	}
+
+	return nil
+}
+
+// outboxObjectIDs преобразует идентификаторы событий в ObjectID.
+func outboxObjectIDs(ids []string) ([]bson.ObjectID, error) {
+	objectIDs := make([]bson.ObjectID, 0, len(ids))
+	for _, id := range ids {
+		objectID, err := bson.ObjectIDFromHex(id)
+		if err != nil {
+			return nil, err
+		}
+		objectIDs = append(objectIDs, objectID)
+	}
+
+	return objectIDs, nil
+}
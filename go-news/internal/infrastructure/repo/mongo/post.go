@@ -2,12 +2,16 @@ package mongo
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	dom "github.com/ee-crocush/go-news/go-news/internal/domain/post"
+	"github.com/ee-crocush/go-news/go-news/internal/infrastructure/events"
 	"github.com/ee-crocush/go-news/go-news/internal/infrastructure/repo/mongo/mapper"
+	"github.com/ee-crocush/go-news/pkg/outbox"
 	"go.mongodb.org/mongo-driver/v2/bson"
 	"go.mongodb.org/mongo-driver/v2/mongo"
 	"go.mongodb.org/mongo-driver/v2/mongo/options"
+	"slices"
 	"time"
 )
 
@@ -18,6 +22,8 @@ type PostRepository struct {
 	db         *mongo.Database
 	collection *mongo.Collection
 	timeout    time.Duration
+	// events - при сохранении новости в outbox в той же транзакции записывается событие NewsPublished.
+	events bool
 }
 
 // NewPostRepository создаёт новый Mongo-репозиторий с новостями.
@@ -29,6 +35,12 @@ func NewPostRepository(db *mongo.Database, timeout time.Duration) *PostRepositor
 	}
 }
 
+// EnableEvents включает запись события NewsPublished в outbox при сохранении новой новости.
+// Новость и событие записываются в одной транзакции, поэтому нужен replica set (см. SupportsTransactions).
+func (r *PostRepository) EnableEvents() {
+	r.events = true
+}
+
 // Store сохраняет новости в MongoDB.
 func (r *PostRepository) Store(ctx context.Context, post *dom.Post) error {
 	ctx, cancel := context.WithTimeout(ctx, r.timeout)
@@ -45,10 +57,20 @@ func (r *PostRepository) Store(ctx context.Context, post *dom.Post) error {
 	}
 
 	post.SetID(postID)
+	if post.ClusterID() == 0 {
+		post.JoinCluster(postID.Value())
+	}
 
 	doc := mapper.FromPostToDoc(post)
 
-	_, err = r.collection.InsertOne(ctx, doc)
+	_, err = r.inTransaction(
+		ctx, func(ctx context.Context) (bool, error) {
+			if _, err := r.collection.InsertOne(ctx, doc); err != nil {
+				return false, err
+			}
+			return true, r.storeEvent(ctx, post)
+		},
+	)
 	if err != nil {
 		return fmt.Errorf("PostRepository.Create: %w", err)
 	}
@@ -56,6 +78,142 @@ func (r *PostRepository) Store(ctx context.Context, post *dom.Post) error {
 	return nil
 }
 
+// StoreIfNotExists сохраняет новость, если новости с таким же ключом или ссылкой еще нет.
+// Идентификатор выделяется только после проверки, поэтому пропущенные новости не расходуют его.
+// Новость, вставленная параллельным запуском между проверкой и вставкой, отклоняется уникальными
+// индексами и также считается пропуском. Вместе с новой новостью в outbox записывается событие
+// NewsPublished, если запись событий включена (EnableEvents).
+func (r *PostRepository) StoreIfNotExists(ctx context.Context, post *dom.Post) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	doc := mapper.FromPostToDoc(post)
+	clusterID := doc.ClusterID
+
+	inserted, err := r.inTransaction(
+		ctx, func(ctx context.Context) (bool, error) {
+			count, err := r.collection.CountDocuments(
+				ctx, keyFilter(post.Key(), post.Link()), options.Count().SetLimit(1),
+			)
+			if err != nil || count > 0 {
+				return false, err
+			}
+
+			id, err := r.getNextID(ctx)
+			if err != nil {
+				return false, err
+			}
+
+			postID, err := dom.NewPostID(id)
+			if err != nil {
+				return false, err
+			}
+
+			doc.ID = postID.Value()
+			doc.ClusterID = clusterID
+			if doc.ClusterID == 0 {
+				// Новость без похожих начинает собственный кластер.
+				doc.ClusterID = doc.ID
+			}
+
+			if _, err = r.collection.InsertOne(ctx, doc); err != nil {
+				return false, err
+			}
+
+			post.SetID(postID)
+			post.JoinCluster(doc.ClusterID)
+
+			return true, r.storeEvent(ctx, post)
+		},
+	)
+	if err != nil {
+		if mongo.IsDuplicateKeyError(err) {
+			return false, nil
+		}
+		return false, fmt.Errorf("PostRepository.StoreIfNotExists: %w", err)
+	}
+
+	return inserted, nil
+}
+
+// inTransaction выполняет запись новости fn в транзакции, если вместе с ней в outbox записывается событие.
+// fn может быть выполнена повторно при временной ошибке транзакции.
+func (r *PostRepository) inTransaction(ctx context.Context, fn func(ctx context.Context) (bool, error)) (
+	bool, error,
+) {
+	if !r.events {
+		return fn(ctx)
+	}
+
+	session, err := r.db.Client().StartSession()
+	if err != nil {
+		return false, err
+	}
+	defer session.EndSession(ctx)
+
+	res, err := session.WithTransaction(
+		ctx, func(ctx context.Context) (any, error) {
+			return fn(ctx)
+		},
+	)
+	if err != nil {
+		return false, err
+	}
+
+	return res.(bool), nil
+}
+
+// storeEvent записывает в outbox событие NewsPublished сохраненной новости вместе с заголовками
+// сообщения из контекста (request_id ручного опроса источника).
+func (r *PostRepository) storeEvent(ctx context.Context, post *dom.Post) error {
+	if !r.events {
+		return nil
+	}
+
+	e := events.NewNewsPublishedEvent(post)
+	payload, err := e.ToJSON()
+	if err != nil {
+		return fmt.Errorf("storeEvent.ToJSON: %w", err)
+	}
+
+	doc := mapper.NewOutboxDocument(events.NewsPublishedType, e.Key(), payload, outbox.ContextHeaders(ctx), time.Now())
+	if _, err = r.db.Collection(outboxCollection).InsertOne(ctx, doc); err != nil {
+		return fmt.Errorf("storeEvent.InsertOne: %w", err)
+	}
+
+	return nil
+}
+
+// FindByLink находит новость по ссылке на источник.
+func (r *PostRepository) FindByLink(ctx context.Context, link dom.PostLink) (*dom.Post, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	var doc mapper.PostDocument
+
+	if err := r.collection.FindOne(ctx, linkFilter(link)).Decode(&doc); err != nil {
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return nil, fmt.Errorf("PostRepository.FindByLink: %w", dom.ErrPostNotFound)
+		}
+		return nil, fmt.Errorf("PostRepository.FindByLink: %w", err)
+	}
+
+	return mapper.MapDocToPost(doc)
+}
+
+// ExistsByKey проверяет, есть ли новость с таким ключом дедупликации или ссылкой (с учетом нормализации).
+func (r *PostRepository) ExistsByKey(ctx context.Context, key dom.PostKey, link dom.PostLink) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	count, err := r.collection.CountDocuments(ctx, keyFilter(key, link), options.Count().SetLimit(1))
+	if err != nil {
+		return false, fmt.Errorf("PostRepository.ExistsByKey: %w", err)
+	}
+
+	return count > 0, nil
+}
+
 // FindByID находит новость по его ID.
 func (r *PostRepository) FindByID(ctx context.Context, postID dom.PostID) (*dom.Post, error) {
 	ctx, cancel := context.WithTimeout(ctx, r.timeout)
@@ -101,24 +259,25 @@ func (r *PostRepository) FindLatest(ctx context.Context, limit int) ([]*dom.Post
 }
 
 // FindAll получает все новости.
-func (r *PostRepository) FindAll(ctx context.Context, search string, limit, offset int) (
+func (r *PostRepository) FindAll(ctx context.Context, postFilter dom.PostFilter, limit, offset int) (
 	[]*dom.Post, int32, error,
 ) {
 	ctx, cancel := context.WithTimeout(ctx, r.timeout)
 	defer cancel()
 
-	filter := bson.M{}
-	if search != "" {
-		filter["title"] = bson.M{"$regex": search, "$options": "i"}
+	if postFilter.Collapse {
+		return r.findAllCollapsed(ctx, postFilter, limit, offset)
 	}
 
+	filter := buildFilter(postFilter)
+
 	// Получаем общее количество документов по фильтру
 	total, err := r.collection.CountDocuments(ctx, filter)
 	if err != nil {
 		return nil, 0, fmt.Errorf("PostRepository.FindAll count: %w", err)
 	}
 
-	opts := options.Find().SetSort(bson.D{{Key: "pub_time", Value: -1}}).SetLimit(int64(limit)).SetSkip(int64(offset))
+	opts := options.Find().SetSort(buildSort(postFilter)).SetLimit(int64(limit)).SetSkip(int64(offset))
 
 	cursor, err := r.collection.Find(ctx, filter, opts)
 	if err != nil {
@@ -131,22 +290,418 @@ func (r *PostRepository) FindAll(ctx context.Context, search string, limit, offs
 	return posts, int32(total), err
 }
 
-// getNextID возвращает следующее значение идентификатора.
-func (r *PostRepository) getNextID(ctx context.Context) (int32, error) {
-	filter := bson.M{"_id": "posts"}
-	update := bson.M{"$inc": bson.M{"seq": 1}}
+// FindByCursor получает новости после курсора (keyset-пагинация по pub_time и _id).
+func (r *PostRepository) FindByCursor(
+	ctx context.Context, postFilter dom.PostFilter, cursor dom.PostCursor, limit int,
+) ([]*dom.Post, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	op, order := "$lt", -1
+	if cursor.Direction() == dom.CursorPrev {
+		// Более свежие новости выбираются в прямом порядке, ближайшие к курсору - первыми.
+		op, order = "$gt", 1
+	}
+
+	keyset := bson.M{
+		"$or": bson.A{
+			bson.M{"pub_time": bson.M{op: cursor.PubTime()}},
+			bson.M{"pub_time": cursor.PubTime(), "_id": bson.M{op: cursor.ID().Value()}},
+		},
+	}
+	sort := bson.D{{Key: "pub_time", Value: order}, {Key: "_id", Value: order}}
+
+	var cursorDB *mongo.Cursor
+	var err error
+	if postFilter.Collapse {
+		// Позиция курсора задается представителем кластера, поэтому keyset применяется после схлопывания.
+		pipeline := append(
+			collapsePipeline(postFilter),
+			bson.D{{Key: "$match", Value: keyset}},
+			bson.D{{Key: "$sort", Value: sort}},
+			bson.D{{Key: "$limit", Value: int64(limit)}},
+		)
+		cursorDB, err = r.collection.Aggregate(ctx, pipeline)
+	} else {
+		filter := bson.M{"$and": bson.A{buildFilter(postFilter), keyset}}
+		cursorDB, err = r.collection.Find(ctx, filter, options.Find().SetSort(sort).SetLimit(int64(limit)))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("PostRepository.FindByCursor: %w", err)
+	}
+	defer cursorDB.Close(ctx)
+
+	posts, err := r.decodeManyPosts(ctx, cursorDB)
+	if err != nil {
+		return nil, err
+	}
+
+	if cursor.Direction() == dom.CursorPrev {
+		slices.Reverse(posts)
+	}
+
+	return posts, nil
+}
+
+// findAllCollapsed получает страницу новостей со схлопнутыми кластерами и количество кластеров.
+func (r *PostRepository) findAllCollapsed(ctx context.Context, postFilter dom.PostFilter, limit, offset int) (
+	[]*dom.Post, int32, error,
+) {
+	pipeline := append(
+		collapsePipeline(postFilter),
+		bson.D{
+			{
+				Key: "$facet", Value: bson.M{
+					"posts": bson.A{
+						bson.M{"$sort": collapsedSort(postFilter)},
+						bson.M{"$skip": int64(offset)},
+						bson.M{"$limit": int64(limit)},
+					},
+					"total": bson.A{bson.M{"$count": "count"}},
+				},
+			},
+		},
+	)
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, 0, fmt.Errorf("PostRepository.FindAll: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var res []struct {
+		Posts []mapper.PostDocument `bson:"posts"`
+		Total []struct {
+			Count int32 `bson:"count"`
+		} `bson:"total"`
+	}
+	if err = cursor.All(ctx, &res); err != nil {
+		return nil, 0, fmt.Errorf("PostRepository.FindAll.Decode: %w", err)
+	}
+	if len(res) == 0 {
+		return nil, 0, nil
+	}
 
-	opts := options.FindOneAndUpdate().SetUpsert(true).SetReturnDocument(options.After)
+	var total int32
+	if len(res[0].Total) > 0 {
+		total = res[0].Total[0].Count
+	}
 
-	var result struct {
-		Seq int32 `bson:"seq"`
+	posts := make([]*dom.Post, 0, len(res[0].Posts))
+	for _, doc := range res[0].Posts {
+		post, err := mapper.MapDocToPost(doc)
+		if err != nil {
+			return nil, 0, fmt.Errorf("PostRepository.FindAll: %w", err)
+		}
+		posts = append(posts, post)
 	}
-	err := r.db.Collection("counters").FindOneAndUpdate(ctx, filter, update, opts).Decode(&result)
+
+	return posts, total, nil
+}
+
+// FindSimilar получает новости, опубликованные в интервале, с совпадающей частью отпечатка.
+func (r *PostRepository) FindSimilar(
+	ctx context.Context, fingerprint dom.PostFingerprint, pubTime dom.PubTimeRange,
+) ([]*dom.Post, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	filter := bson.M{
+		"simhash_bands": bson.M{"$in": fingerprint.Bands()},
+		"pub_time":      bson.M{"$gte": pubTime.From().Unix(), "$lte": pubTime.To().Unix()},
+	}
+	opts := options.Find().
+		SetSort(bson.D{{Key: "pub_time", Value: -1}}).
+		SetLimit(similarCandidatesLimit)
+
+	cursor, err := r.collection.Find(ctx, filter, opts)
+	if err != nil {
+		return nil, fmt.Errorf("PostRepository.FindSimilar: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	return r.decodeManyPosts(ctx, cursor)
+}
+
+// AddComment учитывает опубликованный комментарий к новости. ID учтенных комментариев хранятся
+// в документе новости, поэтому повторная доставка события не увеличивает счетчик.
+func (r *PostRepository) AddComment(ctx context.Context, postID dom.PostID, commentID int64) (bool, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	res, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": postID.Value(), "comment_ids": bson.M{"$ne": commentID}},
+		bson.M{
+			"$push": bson.M{"comment_ids": commentID},
+			"$inc":  bson.M{"comments_count": 1},
+		},
+	)
 	if err != nil {
-		return 0, fmt.Errorf("getNextID: %w", err)
+		return false, fmt.Errorf("PostRepository.AddComment: %w", err)
 	}
 
-	return result.Seq, nil
+	if res.MatchedCount > 0 {
+		return true, nil
+	}
+
+	// Комментарий уже учтен или новости нет
+	count, err := r.collection.CountDocuments(ctx, bson.M{"_id": postID.Value()}, options.Count().SetLimit(1))
+	if err != nil {
+		return false, fmt.Errorf("PostRepository.AddComment: %w", err)
+	}
+	if count == 0 {
+		return false, fmt.Errorf("PostRepository.AddComment: %w", dom.ErrPostNotFound)
+	}
+
+	return false, nil
+}
+
+// CountTags возвращает самые частые теги новостей с количеством новостей.
+func (r *PostRepository) CountTags(ctx context.Context, limit int) ([]dom.TagCount, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"tags.0": bson.M{"$exists": true}}}},
+		{{Key: "$unwind", Value: "$tags"}},
+		{{Key: "$group", Value: bson.M{"_id": "$tags", "count": bson.M{"$sum": 1}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+		{{Key: "$limit", Value: int64(limit)}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("PostRepository.CountTags: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []struct {
+		Tag   string `bson:"_id"`
+		Count int64  `bson:"count"`
+	}
+	if err = cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("PostRepository.CountTags.Decode: %w", err)
+	}
+
+	counts := make([]dom.TagCount, 0, len(docs))
+	for _, doc := range docs {
+		tag, err := dom.NewPostTag(doc.Tag)
+		if err != nil {
+			continue
+		}
+		counts = append(counts, dom.TagCount{Tag: tag, Count: doc.Count})
+	}
+
+	return counts, nil
+}
+
+// LabelCategories сохраняет рубрики новости, проставленные вручную.
+func (r *PostRepository) LabelCategories(ctx context.Context, postID dom.PostID, categories []dom.PostCategory) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	values := make([]string, 0, len(categories))
+	for _, category := range categories {
+		values = append(values, category.Value())
+	}
+
+	res, err := r.collection.UpdateOne(
+		ctx,
+		bson.M{"_id": postID.Value()},
+		bson.M{"$set": bson.M{"categories": values, "categories_labeled": true}},
+	)
+	if err != nil {
+		return fmt.Errorf("PostRepository.LabelCategories: %w", err)
+	}
+	if res.MatchedCount == 0 {
+		return fmt.Errorf("PostRepository.LabelCategories: %w", dom.ErrPostNotFound)
+	}
+
+	return nil
+}
+
+// FindLabeled получает новости с рубриками, проставленными вручную, начиная с последних.
+func (r *PostRepository) FindLabeled(ctx context.Context, limit int) ([]*dom.Post, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	opts := options.Find().SetSort(bson.D{{Key: "_id", Value: -1}}).SetLimit(int64(limit))
+	cursor, err := r.collection.Find(ctx, bson.M{"categories_labeled": true}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("PostRepository.FindLabeled: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	return r.decodeManyPosts(ctx, cursor)
+}
+
+// CountCategories возвращает рубрики с количеством новостей.
+func (r *PostRepository) CountCategories(ctx context.Context) ([]dom.CategoryCount, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"categories.0": bson.M{"$exists": true}}}},
+		{{Key: "$unwind", Value: "$categories"}},
+		{{Key: "$group", Value: bson.M{"_id": "$categories", "count": bson.M{"$sum": 1}}}},
+		{{Key: "$sort", Value: bson.D{{Key: "count", Value: -1}, {Key: "_id", Value: 1}}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("PostRepository.CountCategories: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []struct {
+		Category string `bson:"_id"`
+		Count    int64  `bson:"count"`
+	}
+	if err = cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("PostRepository.CountCategories.Decode: %w", err)
+	}
+
+	counts := make([]dom.CategoryCount, 0, len(docs))
+	for _, doc := range docs {
+		category, err := dom.NewPostCategory(doc.Category)
+		if err != nil {
+			continue
+		}
+		counts = append(counts, dom.CategoryCount{Category: category, Count: doc.Count})
+	}
+
+	return counts, nil
+}
+
+// buildFilter формирует Mongo-фильтр по параметрам отбора новостей.
+func buildFilter(f dom.PostFilter) bson.M {
+	filter := bson.M{}
+
+	if f.Search != "" {
+		// Язык запроса определяет стемминг поисковых слов; язык документов задан полем language.
+		filter["$text"] = bson.M{"$search": f.Search, "$language": mapper.DetectLanguage(f.Search)}
+	}
+
+	if f.Source != "" {
+		filter["$or"] = bson.A{
+			bson.M{"source.feed_url": f.Source},
+			bson.M{"source.site_link": f.Source},
+		}
+	}
+
+	if !f.PubTime.IsZero() {
+		pubTime := bson.M{}
+		if from := f.PubTime.From(); !from.IsZero() {
+			pubTime["$gte"] = from.Unix()
+		}
+		if to := f.PubTime.To(); !to.IsZero() {
+			pubTime["$lte"] = to.Unix()
+		}
+		filter["pub_time"] = pubTime
+	}
+
+	if !f.Tag.IsZero() {
+		filter["tags"] = f.Tag.Value()
+	}
+
+	if !f.Category.IsZero() {
+		filter["categories"] = f.Category.Value()
+	}
+
+	switch f.Comments {
+	case dom.CommentsWith:
+		filter["comments_count"] = bson.M{"$gt": 0}
+	case dom.CommentsWithout:
+		// У новостей, сохраненных до появления счетчика, поля нет
+		filter["comments_count"] = bson.M{"$not": bson.M{"$gt": 0}}
+	}
+
+	return filter
+}
+
+// buildSort формирует порядок сортировки новостей.
+// Сортировка по релевантности возможна только при полнотекстовом поиске.
+func buildSort(f dom.PostFilter) bson.D {
+	if f.Sort == dom.SortByRelevance && f.Search != "" {
+		return bson.D{
+			{Key: "score", Value: bson.M{"$meta": "textScore"}},
+			{Key: "pub_time", Value: -1},
+			{Key: "_id", Value: -1},
+		}
+	}
+
+	return bson.D{{Key: "pub_time", Value: -1}, {Key: "_id", Value: -1}}
+}
+
+// similarCandidatesLimit - максимальное количество кандидатов при поиске почти одинаковых новостей.
+const similarCandidatesLimit = 100
+
+// collapsePipeline формирует этапы агрегации, оставляющие от каждого кластера одну новость - первую
+// в порядке сортировки ленты - с количеством новостей кластера, подходящих под фильтр (cluster_size).
+func collapsePipeline(f dom.PostFilter) mongo.Pipeline {
+	pipeline := mongo.Pipeline{{{Key: "$match", Value: buildFilter(f)}}}
+	if f.Sort == dom.SortByRelevance && f.Search != "" {
+		// Оценка релевантности недоступна после группировки, поэтому сохраняется в поле.
+		pipeline = append(pipeline, bson.D{{Key: "$addFields", Value: bson.M{"score": bson.M{"$meta": "textScore"}}}})
+	}
+
+	return append(
+		pipeline,
+		bson.D{{Key: "$sort", Value: collapsedSort(f)}},
+		bson.D{
+			{
+				Key: "$group", Value: bson.M{
+					// У новостей, сохраненных до появления кластеров, кластер - сама новость.
+					"_id":  bson.M{"$ifNull": bson.A{"$cluster_id", "$_id"}},
+					"doc":  bson.M{"$first": "$$ROOT"},
+					"size": bson.M{"$sum": 1},
+				},
+			},
+		},
+		bson.D{
+			{
+				Key: "$replaceRoot", Value: bson.M{
+					"newRoot": bson.M{"$mergeObjects": bson.A{"$doc", bson.M{"cluster_size": "$size"}}},
+				},
+			},
+		},
+	)
+}
+
+// collapsedSort формирует порядок сортировки для агрегации со схлопыванием кластеров.
+func collapsedSort(f dom.PostFilter) bson.D {
+	if f.Sort == dom.SortByRelevance && f.Search != "" {
+		return bson.D{{Key: "score", Value: -1}, {Key: "pub_time", Value: -1}, {Key: "_id", Value: -1}}
+	}
+
+	return bson.D{{Key: "pub_time", Value: -1}, {Key: "_id", Value: -1}}
+}
+
+// linkFilter формирует фильтр поиска новости по исходной или нормализованной ссылке.
+func linkFilter(link dom.PostLink) bson.M {
+	key, _ := dom.NewPostKey("", link.Value())
+
+	return bson.M{
+		"$or": bson.A{
+			bson.M{"link": link.Value()},
+			bson.M{"key": key.Value()},
+		},
+	}
+}
+
+// keyFilter формирует фильтр поиска новости по ключу дедупликации, а также по исходной или нормализованной
+// ссылке: новости, сохраненные до появления ключа, его не содержат.
+func keyFilter(key dom.PostKey, link dom.PostLink) bson.M {
+	filter := linkFilter(link)
+	filter["$or"] = append(filter["$or"].(bson.A), bson.M{"key": key.Value()})
+
+	return filter
+}
+
+// getNextID возвращает следующее значение идентификатора.
+func (r *PostRepository) getNextID(ctx context.Context) (int32, error) {
+	return nextSequence(ctx, r.db, "posts")
 }
 
 // decodeManyPosts декодирует курсор в массив новостей.
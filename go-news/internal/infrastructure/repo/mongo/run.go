@@ -0,0 +1,134 @@
+package mongo
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	dom "github.com/ee-crocush/go-news/go-news/internal/domain/feed"
+	"github.com/ee-crocush/go-news/go-news/internal/infrastructure/repo/mongo/mapper"
+	"go.mongodb.org/mongo-driver/v2/bson"
+	"go.mongodb.org/mongo-driver/v2/mongo"
+	"go.mongodb.org/mongo-driver/v2/mongo/options"
+)
+
+var _ dom.RunRepository = (*RunRepository)(nil)
+
+// RunRepository представляет собой репозиторий журнала опросов источников в MongoDB.
+// Записи удаляются TTL-индексом коллекции (см. ensureIndexes).
+type RunRepository struct {
+	collection *mongo.Collection
+	timeout    time.Duration
+}
+
+// NewRunRepository создаёт новый Mongo-репозиторий журнала опросов.
+func NewRunRepository(db *mongo.Database, timeout time.Duration) *RunRepository {
+	return &RunRepository{
+		collection: db.Collection(runsCollection),
+		timeout:    timeout,
+	}
+}
+
+// SaveRun сохраняет запись об опросе источника.
+func (r *RunRepository) SaveRun(ctx context.Context, run *dom.Run) error {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	if _, err := r.collection.InsertOne(ctx, mapper.FromRunToDoc(run)); err != nil {
+		return fmt.Errorf("RunRepository.SaveRun: %w", err)
+	}
+
+	return nil
+}
+
+// FindRuns находит последние опросы источника, новые первыми.
+func (r *RunRepository) FindRuns(ctx context.Context, id dom.FeedID, limit int) ([]*dom.Run, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	opts := options.Find().
+		SetSort(bson.D{{Key: "started_at", Value: -1}, {Key: "_id", Value: -1}}).
+		SetLimit(int64(limit))
+
+	cursor, err := r.collection.Find(ctx, bson.M{"feed_id": id.Value()}, opts)
+	if err != nil {
+		return nil, fmt.Errorf("RunRepository.FindRuns: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []mapper.RunDocument
+	if err = cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("RunRepository.FindRuns.Decode: %w", err)
+	}
+
+	runs := make([]*dom.Run, 0, len(docs))
+	for _, doc := range docs {
+		run, err := mapper.MapDocToRun(doc)
+		if err != nil {
+			return nil, fmt.Errorf("RunRepository.FindRuns: %w", err)
+		}
+		runs = append(runs, run)
+	}
+
+	return runs, nil
+}
+
+// FindRunStats находит сводку опросов каждого источника, начатых не раньше since.
+func (r *RunRepository) FindRunStats(ctx context.Context, since time.Time) ([]dom.RunStats, error) {
+	ctx, cancel := context.WithTimeout(ctx, r.timeout)
+	defer cancel()
+
+	failed := bson.M{"$eq": bson.A{"$status", string(dom.FetchStatusError)}}
+
+	pipeline := mongo.Pipeline{
+		{{Key: "$match", Value: bson.M{"started_at": bson.M{"$gte": since.UTC()}}}},
+		{{
+			Key: "$group", Value: bson.M{
+				"_id":         "$feed_id",
+				"runs":        bson.M{"$sum": 1},
+				"failed":      bson.M{"$sum": bson.M{"$cond": bson.A{failed, 1, 0}}},
+				"last_run_at": bson.M{"$max": "$started_at"},
+				// $max игнорирует null, поэтому неудачные опросы не учитываются.
+				"last_success_at": bson.M{"$max": bson.M{"$cond": bson.A{failed, nil, "$started_at"}}},
+			},
+		}},
+		{{Key: "$sort", Value: bson.M{"_id": 1}}},
+	}
+
+	cursor, err := r.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, fmt.Errorf("RunRepository.FindRunStats: %w", err)
+	}
+	defer cursor.Close(ctx)
+
+	var docs []struct {
+		FeedID        int32     `bson:"_id"`
+		Runs          int64     `bson:"runs"`
+		Failed        int64     `bson:"failed"`
+		LastRunAt     time.Time `bson:"last_run_at"`
+		LastSuccessAt time.Time `bson:"last_success_at"`
+	}
+	if err = cursor.All(ctx, &docs); err != nil {
+		return nil, fmt.Errorf("RunRepository.FindRunStats.Decode: %w", err)
+	}
+
+	stats := make([]dom.RunStats, 0, len(docs))
+	for _, doc := range docs {
+		id, err := dom.NewFeedID(doc.FeedID)
+		if err != nil {
+			continue
+		}
+
+		stats = append(
+			stats, dom.RunStats{
+				FeedID:        id,
+				Runs:          doc.Runs,
+				Failed:        doc.Failed,
+				LastRunAt:     doc.LastRunAt,
+				LastSuccessAt: doc.LastSuccessAt,
+			},
+		)
+	}
+
+	return stats, nil
+}
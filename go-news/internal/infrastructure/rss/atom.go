@@ -0,0 +1,159 @@
+package rss
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	uc "github.com/ee-crocush/go-news/go-news/internal/usecase/post"
+)
+
+// AtomFeed представляет ленту Atom 1.0.
+type AtomFeed struct {
+	Title   AtomText    `xml:"title"`
+	Links   []AtomLink  `xml:"link"`
+	Entries []AtomEntry `xml:"entry"`
+}
+
+// AtomEntry представляет запись ленты Atom.
+type AtomEntry struct {
+	MediaElements
+	ID         string         `xml:"id"`
+	Title      AtomText       `xml:"title"`
+	Links      []AtomLink     `xml:"link"`
+	Updated    string         `xml:"updated"`
+	Published  string         `xml:"published"`
+	Content    AtomText       `xml:"content"`
+	Summary    AtomText       `xml:"summary"`
+	Categories []AtomCategory `xml:"category"`
+}
+
+// AtomCategory представляет категорию записи Atom.
+type AtomCategory struct {
+	Term  string `xml:"term,attr"`
+	Label string `xml:"label,attr"`
+}
+
+// AtomLink представляет ссылку Atom.
+type AtomLink struct {
+	Href string `xml:"href,attr"`
+	Rel  string `xml:"rel,attr"`
+	Type string `xml:"type,attr"`
+	// Length - размер файла для ссылок rel="enclosure".
+	Length string `xml:"length,attr"`
+}
+
+// AtomText представляет текстовую конструкцию Atom (text, html или xhtml).
+type AtomText struct {
+	Type  string `xml:"type,attr"`
+	Text  string `xml:",chardata"`
+	Inner string `xml:",innerxml"`
+}
+
+// HTML возвращает содержимое текстовой конструкции в виде HTML с учетом её типа:
+// текст (type="text" или без типа) экранируется, html и xhtml возвращаются как есть.
+func (t AtomText) HTML() string {
+	switch t.Type {
+	case "xhtml":
+		return strings.TrimSpace(t.Inner)
+	case "html", "text/html":
+		return strings.TrimSpace(t.Text)
+	default:
+		return html.EscapeString(strings.TrimSpace(t.Text))
+	}
+}
+
+// decodeAtom раскодирует ленту в формате Atom 1.0.
+func (p *Parser) decodeAtom(body []byte) (uc.ParseResultDTO, error) {
+	var feed AtomFeed
+	if err := unmarshalXML(body, &feed); err != nil {
+		return uc.ParseResultDTO{}, fmt.Errorf("xml unmarshal atom error: %w", err)
+	}
+
+	var (
+		posts    []uc.ParsedRSSDTO
+		warnings []string
+	)
+	for _, entry := range feed.Entries {
+		post, err := p.entryToDTO(entry)
+		if err != nil {
+			warnings = append(warnings, itemWarning(post, err))
+			continue
+		}
+		posts = append(posts, post)
+	}
+
+	channel := uc.ChannelDTO{
+		Title: plainText(feed.Title.HTML()),
+		Link:  alternateLink(feed.Links),
+	}
+
+	return uc.ParseResultDTO{Items: posts, Channel: channel, Warnings: warnings}, nil
+}
+
+func (p *Parser) entryToDTO(entry AtomEntry) (uc.ParsedRSSDTO, error) {
+	raw := entry.Content.HTML()
+	if raw == "" {
+		raw = entry.Summary.HTML()
+	}
+	link := alternateLink(entry.Links)
+	content, contentHTML := richContent(raw, link)
+
+	media := newMediaCollector(link)
+	for _, l := range entry.Links {
+		if l.Rel == "enclosure" {
+			media.add(l.Href, l.Type, parseInt64(l.Length), 0, 0, false)
+		}
+	}
+	media.addElements(entry.MediaElements)
+	media.addInlineImages(raw)
+
+	// published необязателен, при его отсутствии или ошибке разбора используется updated
+	pubTime, err := p.parseTime(entry.Published)
+	if strings.TrimSpace(entry.Published) == "" || err != nil {
+		if updated, updErr := p.parseTime(entry.Updated); updErr == nil {
+			pubTime, err = updated, nil
+		}
+	}
+
+	return uc.ParsedRSSDTO{
+		GUID:        strings.TrimSpace(entry.ID),
+		Title:       plainText(entry.Title.HTML()),
+		Content:     content,
+		ContentHTML: contentHTML,
+		Link:        link,
+		PubTime:     pubTime,
+		Media:       media.media,
+		Tags:        categoryNames(entry.Categories),
+	}, err
+}
+
+// alternateLink возвращает ссылку на HTML-версию записи.
+// Предпочтение отдается rel="alternate" (или ссылке без rel), иначе берется первая ссылка.
+func alternateLink(links []AtomLink) string {
+	for _, link := range links {
+		if link.Rel == "" || link.Rel == "alternate" {
+			return link.Href
+		}
+	}
+
+	if len(links) > 0 {
+		return links[0].Href
+	}
+
+	return ""
+}
+
+// categoryNames возвращает названия категорий: label, если он задан, иначе term.
+func categoryNames(categories []AtomCategory) []string {
+	var names []string
+	for _, c := range categories {
+		if c.Label != "" {
+			names = append(names, c.Label)
+		} else {
+			names = append(names, c.Term)
+		}
+	}
+
+	return names
+}
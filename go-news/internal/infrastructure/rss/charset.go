@@ -0,0 +1,99 @@
+package rss
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/net/html/charset"
+)
+
+// ErrUnsupportedCharset представляет ошибку неизвестной кодировки ленты.
+var ErrUnsupportedCharset = errors.New("unsupported charset")
+
+var utf8BOM = []byte{0xEF, 0xBB, 0xBF}
+
+// xmlEncodingRe извлекает кодировку из XML-декларации: <?xml version="1.0" encoding="windows-1251"?>.
+var xmlEncodingRe = regexp.MustCompile(`^\s*<\?xml[^>]*?\sencoding\s*=\s*["']([A-Za-z0-9._:-]+)["']`)
+
+// toUTF8 перекодирует тело ленты в UTF-8.
+// Кодировка берется из charset заголовка Content-Type, а если он не указан - из XML-декларации.
+// Если заголовок объявляет UTF-8, но тело не является корректным UTF-8 (частая ошибка настройки сервера),
+// используется кодировка из декларации. Без объявленной кодировки тело считается UTF-8.
+func toUTF8(body []byte, contentType string) ([]byte, error) {
+	body = bytes.TrimPrefix(body, utf8BOM)
+
+	label := contentTypeCharset(contentType)
+	if label == "" || (isUTF8Label(label) && !utf8.Valid(body)) {
+		label = declaredEncoding(body)
+	}
+	if label == "" {
+		return body, nil
+	}
+
+	enc, name := charset.Lookup(label)
+	if enc == nil {
+		return nil, fmt.Errorf("%w %q", ErrUnsupportedCharset, label)
+	}
+	if name == "utf-8" {
+		return body, nil
+	}
+
+	decoded, err := enc.NewDecoder().Bytes(body)
+	if err != nil {
+		return nil, fmt.Errorf("decode %s error: %w", name, err)
+	}
+
+	return decoded, nil
+}
+
+// contentTypeCharset возвращает параметр charset заголовка Content-Type.
+func contentTypeCharset(contentType string) string {
+	if contentType == "" {
+		return ""
+	}
+
+	_, params, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return ""
+	}
+
+	return strings.TrimSpace(params["charset"])
+}
+
+// declaredEncoding возвращает кодировку из XML-декларации документа.
+func declaredEncoding(body []byte) string {
+	m := xmlEncodingRe.FindSubmatch(body)
+	if m == nil {
+		return ""
+	}
+
+	return string(m[1])
+}
+
+func isUTF8Label(label string) bool {
+	_, name := charset.Lookup(label)
+	return name == "utf-8"
+}
+
+// newXMLDecoder создает XML-декодер для тела, уже перекодированного toUTF8:
+// кодировка из декларации больше не соответствует содержимому и игнорируется.
+func newXMLDecoder(body []byte) *xml.Decoder {
+	decoder := xml.NewDecoder(bytes.NewReader(body))
+	decoder.CharsetReader = func(_ string, input io.Reader) (io.Reader, error) {
+		return input, nil
+	}
+
+	return decoder
+}
+
+// unmarshalXML раскодирует тело в UTF-8 в структуру v, аналогично xml.Unmarshal.
+func unmarshalXML(body []byte, v any) error {
+	return newXMLDecoder(body).Decode(v)
+}
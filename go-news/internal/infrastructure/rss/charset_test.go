@@ -0,0 +1,119 @@
+package rss
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	uc "github.com/ee-crocush/go-news/go-news/internal/usecase/post"
+	"golang.org/x/text/encoding/charmap"
+)
+
+func TestToUTF8(t *testing.T) {
+	const text = "Новости"
+
+	cp1251, _ := charmap.Windows1251.NewEncoder().String(text)
+	koi8r, _ := charmap.KOI8R.NewEncoder().String(text)
+
+	testCases := []struct {
+		name        string
+		body        string
+		contentType string
+		want        string
+	}{
+		{
+			name: "utf-8 without declaration",
+			body: "<title>" + text + "</title>",
+			want: "<title>" + text + "</title>",
+		},
+		{
+			name: "utf-8 with BOM",
+			body: "\xEF\xBB\xBF<title>" + text + "</title>",
+			want: "<title>" + text + "</title>",
+		},
+		{
+			name: "windows-1251 declaration",
+			body: `<?xml version="1.0" encoding="windows-1251"?><title>` + cp1251 + "</title>",
+			want: `<?xml version="1.0" encoding="windows-1251"?><title>` + text + "</title>",
+		},
+		{
+			name: "koi8-r declaration with single quotes",
+			body: `<?xml version='1.0' encoding='KOI8-R'?><title>` + koi8r + "</title>",
+			want: `<?xml version='1.0' encoding='KOI8-R'?><title>` + text + "</title>",
+		},
+		{
+			name:        "content type charset",
+			body:        "<title>" + koi8r + "</title>",
+			contentType: "application/rss+xml; charset=koi8-r",
+			want:        "<title>" + text + "</title>",
+		},
+		{
+			name:        "content type charset overrides declaration",
+			body:        `<?xml version="1.0" encoding="windows-1251"?><title>` + koi8r + "</title>",
+			contentType: `text/xml; charset="KOI8-R"`,
+			want:        `<?xml version="1.0" encoding="windows-1251"?><title>` + text + "</title>",
+		},
+		{
+			name:        "invalid utf-8 falls back to declaration",
+			body:        `<?xml version="1.0" encoding="cp1251"?><title>` + cp1251 + "</title>",
+			contentType: "text/xml; charset=utf-8",
+			want:        `<?xml version="1.0" encoding="cp1251"?><title>` + text + "</title>",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				got, err := toUTF8([]byte(tc.body), tc.contentType)
+				if err != nil {
+					t.Fatalf("toUTF8() unexpected error: %v", err)
+				}
+				if string(got) != tc.want {
+					t.Errorf("toUTF8() = %q, want %q", got, tc.want)
+				}
+			},
+		)
+	}
+
+	t.Run(
+		"unknown charset", func(t *testing.T) {
+			_, err := toUTF8([]byte(`<?xml version="1.0" encoding="x-unknown"?><rss/>`), "")
+			if !errors.Is(err, ErrUnsupportedCharset) {
+				t.Errorf("toUTF8() expected ErrUnsupportedCharset, got %v", err)
+			}
+		},
+	)
+}
+
+func TestParser_Parse_ContentTypeCharset(t *testing.T) {
+	body, _ := charmap.KOI8R.NewEncoder().String(
+		`<?xml version="1.0"?><rss version="2.0"><channel><title>Лента</title>` +
+			`<item><title>Заголовок</title><link>https://example.ru/1</link>` +
+			`<pubDate>Tue, 11 Feb 2025 18:00:00 MSK</pubDate></item></channel></rss>`,
+	)
+
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/rss+xml; charset=KOI8-R")
+				_, _ = w.Write([]byte(body))
+			},
+		),
+	)
+	defer server.Close()
+
+	result, err := NewParser(5*time.Second).Parse(context.Background(), uc.ParseRequestDTO{URL: server.URL})
+	if err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+
+	if result.Channel.Title != "Лента" {
+		t.Errorf("Channel.Title = %q, want %q", result.Channel.Title, "Лента")
+	}
+	if len(result.Items) != 1 || result.Items[0].Title != "Заголовок" {
+		t.Errorf("unexpected items: %+v", result.Items)
+	}
+}
@@ -0,0 +1,301 @@
+package rss
+
+import (
+	"net/url"
+	"slices"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// allowedTags - разрешенные теги и их атрибуты. Остальные теги удаляются с сохранением текста,
+// кроме dropTags, которые удаляются вместе с содержимым.
+var allowedTags = map[atom.Atom][]string{
+	atom.P:          nil,
+	atom.Br:         nil,
+	atom.Hr:         nil,
+	atom.A:          {"href", "title"},
+	atom.Img:        {"src", "alt", "title", "width", "height"},
+	atom.Strong:     nil,
+	atom.B:          nil,
+	atom.Em:         nil,
+	atom.I:          nil,
+	atom.U:          nil,
+	atom.S:          nil,
+	atom.Del:        nil,
+	atom.Sub:        nil,
+	atom.Sup:        nil,
+	atom.Code:       nil,
+	atom.Pre:        nil,
+	atom.Blockquote: nil,
+	atom.Ul:         nil,
+	atom.Ol:         nil,
+	atom.Li:         nil,
+	atom.H1:         nil,
+	atom.H2:         nil,
+	atom.H3:         nil,
+	atom.H4:         nil,
+	atom.H5:         nil,
+	atom.H6:         nil,
+	atom.Figure:     nil,
+	atom.Figcaption: nil,
+	atom.Table:      nil,
+	atom.Thead:      nil,
+	atom.Tbody:      nil,
+	atom.Tr:         nil,
+	atom.Th:         nil,
+	atom.Td:         nil,
+}
+
+// dropTags - теги, удаляемые вместе с содержимым.
+var dropTags = map[atom.Atom]bool{
+	atom.Script:   true,
+	atom.Style:    true,
+	atom.Iframe:   true,
+	atom.Object:   true,
+	atom.Embed:    true,
+	atom.Noscript: true,
+	atom.Template: true,
+	atom.Form:     true,
+	atom.Select:   true,
+	atom.Textarea: true,
+	atom.Svg:      true,
+	atom.Math:     true,
+}
+
+// blockTags - теги, разделяющие абзацы в текстовой версии.
+var blockTags = map[atom.Atom]bool{
+	atom.P:          true,
+	atom.Div:        true,
+	atom.Section:    true,
+	atom.Article:    true,
+	atom.Blockquote: true,
+	atom.Pre:        true,
+	atom.Ul:         true,
+	atom.Ol:         true,
+	atom.Li:         true,
+	atom.H1:         true,
+	atom.H2:         true,
+	atom.H3:         true,
+	atom.H4:         true,
+	atom.H5:         true,
+	atom.H6:         true,
+	atom.Figure:     true,
+	atom.Figcaption: true,
+	atom.Table:      true,
+	atom.Tr:         true,
+	atom.Hr:         true,
+}
+
+// sanitizeHTML оставляет в HTML только разрешенные теги и атрибуты.
+// Ссылки допускаются только http(s) и mailto, изображения - только http(s); относительные адреса
+// разрешаются относительно base. Незакрытые теги закрываются, лишние закрывающие теги отбрасываются.
+func sanitizeHTML(raw string, base *url.URL) string {
+	var (
+		b     strings.Builder
+		open  []atom.Atom
+		skip  int
+		tokzr = html.NewTokenizer(strings.NewReader(raw))
+	)
+
+	for {
+		tt := tokzr.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+
+		tok := tokzr.Token()
+		switch tt {
+		case html.StartTagToken, html.SelfClosingTagToken:
+			if dropTags[tok.DataAtom] {
+				if tt == html.StartTagToken {
+					skip++
+				}
+				continue
+			}
+			if skip > 0 {
+				continue
+			}
+
+			attrs, ok := allowedTags[tok.DataAtom]
+			if !ok {
+				continue
+			}
+
+			tok.Attr = sanitizeAttrs(tok.DataAtom, tok.Attr, attrs, base)
+			if tok.DataAtom == atom.Img && len(tok.Attr) == 0 {
+				continue
+			}
+			if tok.DataAtom == atom.A {
+				tok.Attr = append(tok.Attr, html.Attribute{Key: "rel", Val: "nofollow noopener noreferrer"})
+			}
+
+			if isVoid(tok.DataAtom) {
+				tok.Type = html.SelfClosingTagToken
+			} else {
+				tok.Type = html.StartTagToken
+				open = append(open, tok.DataAtom)
+			}
+			b.WriteString(tok.String())
+		case html.EndTagToken:
+			if dropTags[tok.DataAtom] {
+				if skip > 0 {
+					skip--
+				}
+				continue
+			}
+			if skip > 0 {
+				continue
+			}
+
+			// Закрываем тег вместе со всеми незакрытыми вложенными
+			for i := len(open) - 1; i >= 0; i-- {
+				if open[i] != tok.DataAtom {
+					continue
+				}
+				for j := len(open) - 1; j >= i; j-- {
+					b.WriteString("</" + open[j].String() + ">")
+				}
+				open = open[:i]
+				break
+			}
+		case html.TextToken:
+			if skip == 0 {
+				b.WriteString(html.EscapeString(tok.Data))
+			}
+		}
+	}
+
+	for i := len(open) - 1; i >= 0; i-- {
+		b.WriteString("</" + open[i].String() + ">")
+	}
+
+	return strings.TrimSpace(b.String())
+}
+
+// sanitizeAttrs оставляет разрешенные атрибуты тега и проверяет адреса в href и src.
+func sanitizeAttrs(tag atom.Atom, attrs []html.Attribute, allowed []string, base *url.URL) []html.Attribute {
+	var out []html.Attribute
+	for _, attr := range attrs {
+		key := strings.ToLower(attr.Key)
+		if attr.Namespace != "" || !slices.Contains(allowed, key) {
+			continue
+		}
+
+		if key == "href" || key == "src" {
+			link, ok := safeURL(attr.Val, base, tag == atom.A)
+			if !ok {
+				if key == "src" {
+					return nil
+				}
+				continue
+			}
+			attr.Val = link
+		}
+
+		out = append(out, html.Attribute{Key: key, Val: attr.Val})
+	}
+
+	return out
+}
+
+// safeURL разрешает адрес относительно base и проверяет схему.
+func safeURL(raw string, base *url.URL, allowMailto bool) (string, bool) {
+	u, err := url.Parse(strings.TrimSpace(raw))
+	if err != nil {
+		return "", false
+	}
+	if base != nil {
+		u = base.ResolveReference(u)
+	}
+
+	switch u.Scheme {
+	case "http", "https":
+		return u.String(), u.Host != ""
+	case "mailto":
+		return u.String(), allowMailto
+	default:
+		return "", false
+	}
+}
+
+// plainText возвращает текст HTML-фрагмента с раскодированными сущностями.
+// Блочные элементы разделяются пустой строкой, br - переводом строки, пробелы внутри строк схлопываются.
+func plainText(raw string) string {
+	var (
+		paragraphs []string
+		line       strings.Builder
+		lines      []string
+		skip       int
+		tokzr      = html.NewTokenizer(strings.NewReader(raw))
+	)
+
+	flushLine := func() {
+		if text := strings.Join(strings.Fields(line.String()), " "); text != "" {
+			lines = append(lines, text)
+		}
+		line.Reset()
+	}
+	flushParagraph := func() {
+		flushLine()
+		if len(lines) > 0 {
+			paragraphs = append(paragraphs, strings.Join(lines, "\n"))
+		}
+		lines = nil
+	}
+
+	for {
+		tt := tokzr.Next()
+		if tt == html.ErrorToken {
+			break
+		}
+
+		tok := tokzr.Token()
+		switch tt {
+		case html.StartTagToken, html.SelfClosingTagToken, html.EndTagToken:
+			if dropTags[tok.DataAtom] {
+				switch {
+				case tt == html.StartTagToken:
+					skip++
+				case tt == html.EndTagToken && skip > 0:
+					skip--
+				}
+				continue
+			}
+			if skip > 0 {
+				continue
+			}
+
+			switch {
+			case tok.DataAtom == atom.Br:
+				flushLine()
+			case blockTags[tok.DataAtom]:
+				flushParagraph()
+			}
+		case html.TextToken:
+			if skip == 0 {
+				line.WriteString(tok.Data)
+			}
+		}
+	}
+	flushParagraph()
+
+	return strings.Join(paragraphs, "\n\n")
+}
+
+// isVoid сообщает, что разрешенный тег не имеет содержимого.
+func isVoid(tag atom.Atom) bool {
+	return tag == atom.Br || tag == atom.Hr || tag == atom.Img
+}
+
+// richContent возвращает текстовую и очищенную HTML-версии описания записи.
+// Относительные адреса в описании разрешаются относительно ссылки на запись.
+func richContent(raw, link string) (text, safeHTML string) {
+	base, err := url.Parse(strings.TrimSpace(link))
+	if err != nil || !base.IsAbs() {
+		base = nil
+	}
+
+	return plainText(raw), sanitizeHTML(raw, base)
+}
@@ -0,0 +1,107 @@
+package rss
+
+import (
+	"net/url"
+	"testing"
+)
+
+func TestSanitizeHTML(t *testing.T) {
+	base, _ := url.Parse("https://example.com/posts/1")
+
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{
+			name: "allowed tags are kept",
+			raw:  "<p>Текст <strong>жирный</strong> и <code>код</code></p><pre>a := 1</pre>",
+			want: "<p>Текст <strong>жирный</strong> и <code>код</code></p><pre>a := 1</pre>",
+		},
+		{
+			name: "scripts and styles are dropped with content",
+			raw:  `<p>До</p><script>alert(1)</script><style>p{}</style><iframe src="https://evil"></iframe><p>После</p>`,
+			want: "<p>До</p><p>После</p>",
+		},
+		{
+			name: "unknown tags are unwrapped",
+			raw:  `<div class="x"><span style="color:red">Текст</span></div>`,
+			want: "Текст",
+		},
+		{
+			name: "event handlers and unsafe links are removed",
+			raw:  `<a href="javascript:alert(1)" onclick="x()">ссылка</a>`,
+			want: `<a rel="nofollow noopener noreferrer">ссылка</a>`,
+		},
+		{
+			name: "relative links are resolved",
+			raw:  `<a href="/about" title="О нас">о нас</a><img src="img/1.png" alt="фото" onerror="x()">`,
+			want: `<a href="https://example.com/about" title="О нас" rel="nofollow noopener noreferrer">о нас</a>` +
+				`<img src="https://example.com/posts/img/1.png" alt="фото"/>`,
+		},
+		{
+			name: "images with unsafe source are dropped",
+			raw:  `<img src="data:image/png;base64,AAAA" alt="x">`,
+			want: "",
+		},
+		{
+			name: "unclosed and stray tags are balanced",
+			raw:  "<p><b>жирный<i>курсив</p></b></ul>",
+			want: "<p><b>жирный<i>курсив</i></b></p>",
+		},
+		{
+			name: "entities are kept escaped",
+			raw:  "<p>a &lt;b&gt; &amp; &quot;c&quot;&nbsp;d</p>",
+			want: "<p>a &lt;b&gt; &amp; &#34;c&#34;\u00a0d</p>",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				if got := sanitizeHTML(tt.raw, base); got != tt.want {
+					t.Errorf("sanitizeHTML() = %q, want %q", got, tt.want)
+				}
+			},
+		)
+	}
+}
+
+func TestPlainText(t *testing.T) {
+	tests := []struct {
+		name string
+		raw  string
+		want string
+	}{
+		{
+			name: "paragraphs are separated by blank line",
+			raw:  "<p>Первый   абзац</p>\n<p>Второй<br>строка</p>",
+			want: "Первый абзац\n\nВторой\nстрока",
+		},
+		{
+			name: "entities are decoded",
+			raw:  "Tom &amp; Jerry &laquo;мульт&raquo; &#8212; &lt;b&gt;",
+			want: "Tom & Jerry «мульт» — <b>",
+		},
+		{
+			name: "scripts are dropped",
+			raw:  "<p>Текст</p><script>var a = '<p>x</p>';</script>",
+			want: "Текст",
+		},
+		{
+			name: "list items are separate paragraphs",
+			raw:  "<ul><li>один</li><li>два</li></ul>",
+			want: "один\n\nдва",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				if got := plainText(tt.raw); got != tt.want {
+					t.Errorf("plainText() = %q, want %q", got, tt.want)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,206 @@
+package rss
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// ErrInvalidDate представляет ошибку неразборчивой даты публикации записи.
+var ErrInvalidDate = errors.New("unparseable date")
+
+// isoLayouts - форматы ISO 8601 (RFC3339, Atom updated/published, Dublin Core dc:date).
+// Дробная часть секунд разбирается автоматически, даты без зоны считаются UTC.
+var isoLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05Z0700",
+	"2006-01-02T15:04Z07:00",
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05Z07:00",
+	"2006-01-02 15:04:05 -0700",
+	"2006-01-02 15:04:05 -07:00",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+}
+
+// rfc822Layouts - варианты RFC 822/1123 после нормализации: без дня недели и запятых,
+// с числовой зоной вместо названия и трехбуквенным месяцем.
+var rfc822Layouts = []string{
+	"2 Jan 2006 15:04:05 -0700",
+	"2 Jan 2006 15:04:05 -07:00",
+	"2 Jan 2006 15:04 -0700",
+	"2 Jan 06 15:04:05 -0700",
+	"2 Jan 06 15:04 -0700",
+	"2 Jan 2006 15:04:05",
+	"2 Jan 2006 15:04",
+	"Jan 2 2006 15:04:05 -0700",
+	"Jan 2 2006 15:04:05",
+	"2 Jan 2006",
+}
+
+// zoneOffsets - смещения названий часовых поясов, встречающихся в лентах.
+// Неоднозначные сокращения (IST, AST и т.п.) не поддерживаются.
+var zoneOffsets = map[string]string{
+	"UT":   "+0000",
+	"UTC":  "+0000",
+	"GMT":  "+0000",
+	"Z":    "+0000",
+	"WET":  "+0000",
+	"WEST": "+0100",
+	"BST":  "+0100",
+	"CET":  "+0100",
+	"CEST": "+0200",
+	"EET":  "+0200",
+	"EEST": "+0300",
+	"MSK":  "+0300",
+	"MSD":  "+0400",
+	"JST":  "+0900",
+	"KST":  "+0900",
+	"AEST": "+1000",
+	"AEDT": "+1100",
+	"NZST": "+1200",
+	"NZDT": "+1300",
+	"EST":  "-0500",
+	"EDT":  "-0400",
+	"CST":  "-0600",
+	"CDT":  "-0500",
+	"MST":  "-0700",
+	"MDT":  "-0600",
+	"PST":  "-0800",
+	"PDT":  "-0700",
+	"AKST": "-0900",
+	"AKDT": "-0800",
+	"HST":  "-1000",
+}
+
+// gmtOffsetRe разбирает зоны вида GMT+3, UTC-05:30.
+var gmtOffsetRe = regexp.MustCompile(`^(?:GMT|UTC)([+-])(\d{1,2}):?(\d{2})?$`)
+
+var weekdays = []string{"mon", "tue", "wed", "thu", "fri", "sat", "sun"}
+
+var months = []string{"jan", "feb", "mar", "apr", "may", "jun", "jul", "aug", "sep", "oct", "nov", "dec"}
+
+// parseDate разбирает дату публикации записи в форматах RFC 822/1123 (в том числе с названием
+// часового пояса, без дня недели, с полным названием месяца) и ISO 8601/RFC3339.
+func parseDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+
+	for _, layout := range isoLayouts {
+		if t, err := time.Parse(layout, value); err == nil {
+			return validDate(t, value)
+		}
+	}
+
+	normalized := normalizeRFC822(value)
+	for _, layout := range rfc822Layouts {
+		if t, err := time.Parse(layout, normalized); err == nil {
+			return validDate(t, value)
+		}
+	}
+
+	return time.Time{}, fmt.Errorf("%w %q", ErrInvalidDate, value)
+}
+
+// validDate отклоняет даты до начала эпохи Unix: у новости должно быть положительное время публикации.
+func validDate(t time.Time, value string) (time.Time, error) {
+	if t.Unix() <= 0 {
+		return time.Time{}, fmt.Errorf("%w %q", ErrInvalidDate, value)
+	}
+
+	return t, nil
+}
+
+// normalizeRFC822 приводит дату RFC 822 к виду, понятному rfc822Layouts: удаляет день недели,
+// запятые и комментарий в скобках, сокращает название месяца и заменяет название зоны смещением.
+func normalizeRFC822(value string) string {
+	if i := strings.Index(value, "("); i > 0 {
+		value = value[:i]
+	}
+
+	fields := strings.Fields(strings.ReplaceAll(value, ",", " "))
+	if len(fields) > 0 && isWeekday(fields[0]) {
+		fields = fields[1:]
+	}
+
+	for i, field := range fields {
+		if month, ok := shortMonth(field); ok {
+			fields[i] = month
+		}
+	}
+
+	if n := len(fields); n > 0 {
+		if offset, ok := zoneOffset(fields[n-1]); ok {
+			fields[n-1] = offset
+		}
+	}
+
+	return strings.Join(fields, " ")
+}
+
+func isWeekday(field string) bool {
+	field = strings.ToLower(strings.TrimSuffix(field, "."))
+	if len(field) < 3 || !isLetters(field) {
+		return false
+	}
+
+	for _, day := range weekdays {
+		if strings.HasPrefix(field, day) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// shortMonth сокращает название месяца до трех букв: "September", "Sept." -> "Sep".
+func shortMonth(field string) (string, bool) {
+	field = strings.ToLower(strings.TrimSuffix(field, "."))
+	if len(field) <= 3 || !isLetters(field) {
+		return "", false
+	}
+
+	for _, month := range months {
+		if strings.HasPrefix(field, month) {
+			return strings.ToUpper(month[:1]) + month[1:], true
+		}
+	}
+
+	return "", false
+}
+
+// zoneOffset возвращает числовое смещение для названия зоны или зоны вида GMT+3.
+func zoneOffset(field string) (string, bool) {
+	zone := strings.ToUpper(field)
+	if offset, ok := zoneOffsets[zone]; ok {
+		return offset, true
+	}
+
+	m := gmtOffsetRe.FindStringSubmatch(zone)
+	if m == nil {
+		return "", false
+	}
+
+	hours := m[2]
+	if len(hours) == 1 {
+		hours = "0" + hours
+	}
+	minutes := m[3]
+	if minutes == "" {
+		minutes = "00"
+	}
+
+	return m[1] + hours + minutes, true
+}
+
+func isLetters(s string) bool {
+	for _, r := range s {
+		if r < 'a' || r > 'z' {
+			return false
+		}
+	}
+
+	return true
+}
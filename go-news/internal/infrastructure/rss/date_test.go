@@ -0,0 +1,68 @@
+package rss
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	want := time.Date(2025, 2, 11, 15, 0, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name  string
+		input string
+		want  time.Time
+	}{
+		{name: "RFC1123Z", input: "Tue, 11 Feb 2025 18:00:00 +0300", want: want},
+		{name: "RFC1123 GMT", input: "Tue, 11 Feb 2025 15:00:00 GMT", want: want},
+		{name: "RFC1123 EST", input: "Tue, 11 Feb 2025 10:00:00 EST", want: want},
+		{name: "RFC822 MSK", input: "Tue, 11 Feb 2025 18:00:00 MSK", want: want},
+		{name: "PDT", input: "Tue, 11 Feb 2025 08:00:00 PDT", want: want},
+		{name: "MST lower case", input: "tue, 11 feb 2025 08:00:00 mst", want: want},
+		{name: "without weekday", input: "11 Feb 2025 18:00:00 +0300", want: want},
+		{name: "single digit day", input: "Sat, 1 Feb 2025 15:00:00 +0000", want: want.AddDate(0, 0, -10)},
+		{name: "two digit year", input: "Tue, 11 Feb 25 15:00:00 GMT", want: want},
+		{name: "without seconds", input: "Tue, 11 Feb 2025 15:00 UT", want: want},
+		{name: "full names", input: "Tuesday, 11 February 2025 18:00:00 +0300", want: want},
+		{name: "offset with colon", input: "Tue, 11 Feb 2025 18:00:00 +03:00", want: want},
+		{name: "GMT offset", input: "Tue, 11 Feb 2025 18:00:00 GMT+3", want: want},
+		{name: "zone comment", input: "Tue, 11 Feb 2025 18:00:00 +0300 (MSK)", want: want},
+		{name: "extra spaces", input: "  Tue,  11 Feb 2025   18:00:00  +0300 ", want: want},
+		{name: "US order", input: "Feb 11, 2025 15:00:00 GMT", want: want},
+		{name: "RFC3339", input: "2025-02-11T18:00:00+03:00", want: want},
+		{name: "RFC3339 fraction", input: "2025-02-11T15:00:00.123Z", want: want.Add(123 * time.Millisecond)},
+		{name: "RFC3339 without seconds", input: "2025-02-11T18:00+03:00", want: want},
+		{name: "ISO without zone", input: "2025-02-11T15:00:00", want: want},
+		{name: "ISO with space", input: "2025-02-11 15:00:00", want: want},
+		{name: "date only", input: "2025-02-11", want: want.Truncate(24 * time.Hour)},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				got, err := parseDate(tc.input)
+				if err != nil {
+					t.Fatalf("parseDate(%q) unexpected error: %v", tc.input, err)
+				}
+				if !got.Equal(tc.want) {
+					t.Errorf("parseDate(%q) = %v, want %v", tc.input, got.UTC(), tc.want)
+				}
+			},
+		)
+	}
+}
+
+func TestParseDate_Invalid(t *testing.T) {
+	for _, input := range []string{
+		"invalid date",
+		"Tue, 11 Feb 2025 18:00:00 IST",
+		"32 Feb 2025 18:00:00 GMT",
+		"1969-12-31T00:00:00Z",
+		"вчера",
+	} {
+		if _, err := parseDate(input); !errors.Is(err, ErrInvalidDate) {
+			t.Errorf("parseDate(%q) error = %v, want %v", input, err, ErrInvalidDate)
+		}
+	}
+}
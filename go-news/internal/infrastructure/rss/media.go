@@ -0,0 +1,167 @@
+package rss
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+
+	uc "github.com/ee-crocush/go-news/go-news/internal/usecase/post"
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+)
+
+// MediaElements представляет элементы Media RSS записи. Встраивается в записи всех форматов первым полем:
+// поля без пространства имен (например, content в Atom) иначе перехватили бы элементы media:content.
+type MediaElements struct {
+	MediaContents   []MediaContent   `xml:"http://search.yahoo.com/mrss/ content"`
+	MediaThumbnails []MediaThumbnail `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+	MediaGroups     []MediaGroup     `xml:"http://search.yahoo.com/mrss/ group"`
+}
+
+// MediaContent представляет элемент media:content.
+type MediaContent struct {
+	URL        string           `xml:"url,attr"`
+	Type       string           `xml:"type,attr"`
+	FileSize   string           `xml:"fileSize,attr"`
+	Width      string           `xml:"width,attr"`
+	Height     string           `xml:"height,attr"`
+	Thumbnails []MediaThumbnail `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+}
+
+// MediaThumbnail представляет элемент media:thumbnail.
+type MediaThumbnail struct {
+	URL    string `xml:"url,attr"`
+	Width  string `xml:"width,attr"`
+	Height string `xml:"height,attr"`
+}
+
+// MediaGroup представляет элемент media:group - несколько вариантов одного файла.
+type MediaGroup struct {
+	Contents   []MediaContent   `xml:"http://search.yahoo.com/mrss/ content"`
+	Thumbnails []MediaThumbnail `xml:"http://search.yahoo.com/mrss/ thumbnail"`
+}
+
+// Enclosure представляет вложение RSS 2.0.
+type Enclosure struct {
+	URL    string `xml:"url,attr"`
+	Length string `xml:"length,attr"`
+	Type   string `xml:"type,attr"`
+}
+
+// mediaCollector собирает медиа-вложения записи, разрешая адреса относительно ссылки на запись.
+// Вложения с небезопасным или некорректным адресом пропускаются.
+type mediaCollector struct {
+	base  *url.URL
+	media []uc.MediaDTO
+}
+
+func newMediaCollector(link string) *mediaCollector {
+	base, err := url.Parse(strings.TrimSpace(link))
+	if err != nil || !base.IsAbs() {
+		base = nil
+	}
+
+	return &mediaCollector{base: base}
+}
+
+func (c *mediaCollector) add(raw, mimeType string, size int64, width, height int, thumbnail bool) {
+	link, ok := safeURL(raw, c.base, false)
+	if !ok {
+		return
+	}
+
+	c.media = append(
+		c.media, uc.MediaDTO{
+			URL:       link,
+			Type:      strings.TrimSpace(mimeType),
+			Size:      size,
+			Width:     width,
+			Height:    height,
+			Thumbnail: thumbnail,
+		},
+	)
+}
+
+func (c *mediaCollector) addEnclosures(enclosures []Enclosure) {
+	for _, e := range enclosures {
+		c.add(e.URL, e.Type, parseInt64(e.Length), 0, 0, false)
+	}
+}
+
+func (c *mediaCollector) addElements(m MediaElements) {
+	c.addContents(m.MediaContents)
+	for _, group := range m.MediaGroups {
+		c.addContents(group.Contents)
+		c.addThumbnails(group.Thumbnails)
+	}
+	c.addThumbnails(m.MediaThumbnails)
+}
+
+func (c *mediaCollector) addContents(contents []MediaContent) {
+	for _, m := range contents {
+		c.add(m.URL, m.Type, parseInt64(m.FileSize), parseInt(m.Width), parseInt(m.Height), false)
+		c.addThumbnails(m.Thumbnails)
+	}
+}
+
+func (c *mediaCollector) addThumbnails(thumbnails []MediaThumbnail) {
+	for _, t := range thumbnails {
+		c.add(t.URL, "", 0, parseInt(t.Width), parseInt(t.Height), true)
+	}
+}
+
+// addInlineImages добавляет изображения из HTML описания записи.
+// Изображения размером в 1 пиксель (счетчики просмотров) пропускаются.
+func (c *mediaCollector) addInlineImages(raw string) {
+	tokzr := html.NewTokenizer(strings.NewReader(raw))
+	for {
+		tt := tokzr.Next()
+		if tt == html.ErrorToken {
+			return
+		}
+		if tt != html.StartTagToken && tt != html.SelfClosingTagToken {
+			continue
+		}
+
+		tok := tokzr.Token()
+		if tok.DataAtom != atom.Img {
+			continue
+		}
+
+		var src string
+		var width, height int
+		for _, attr := range tok.Attr {
+			switch strings.ToLower(attr.Key) {
+			case "src":
+				src = attr.Val
+			case "width":
+				width = parseInt(attr.Val)
+			case "height":
+				height = parseInt(attr.Val)
+			}
+		}
+		if width == 1 || height == 1 {
+			continue
+		}
+
+		c.add(src, "", 0, width, height, false)
+	}
+}
+
+func parseInt(value string) int {
+	n, err := strconv.Atoi(strings.TrimSpace(value))
+	if err != nil || n < 0 {
+		return 0
+	}
+
+	return n
+}
+
+func parseInt64(value string) int64 {
+	n, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+	if err != nil || n < 0 {
+		return 0
+	}
+
+	return n
+}
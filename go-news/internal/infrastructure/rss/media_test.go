@@ -0,0 +1,68 @@
+package rss
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	uc "github.com/ee-crocush/go-news/go-news/internal/usecase/post"
+)
+
+func TestParser_Parse_Media(t *testing.T) {
+	server := newFixtureServer(t)
+	parser := NewParser(5 * time.Second)
+
+	testCases := []struct {
+		name     string
+		fixture  string
+		wantText string
+		want     []uc.MediaDTO
+	}{
+		{
+			name:     "RSS 2.0",
+			fixture:  "media_rss2.xml",
+			wantText: "Обложка",
+			want: []uc.MediaDTO{
+				{URL: "https://cdn.example.com/42.mp3", Type: "audio/mpeg", Size: 12345678},
+				{URL: "https://cdn.example.com/42.mp4", Type: "video/mp4", Size: 987654, Width: 1280, Height: 720},
+				{URL: "https://cdn.example.com/42-video.jpg", Width: 320, Height: 180, Thumbnail: true},
+				{URL: "https://cdn.example.com/42-hd.mp4", Type: "video/mp4", Width: 1920, Height: 1080},
+				{URL: "https://cdn.example.com/42-thumb.jpg", Width: 160, Height: 90, Thumbnail: true},
+				{URL: "https://example.com/img/42.jpg", Width: 640, Height: 360},
+			},
+		},
+		{
+			name:     "Atom 1.0",
+			fixture:  "media_atom.xml",
+			wantText: "Описание доклада",
+			want: []uc.MediaDTO{
+				{URL: "https://video.example.com/files/1.webm", Type: "video/webm", Size: 5000},
+				{URL: "https://video.example.com/files/1.mp4", Type: "video/mp4"},
+				{URL: "https://video.example.com/thumbs/1.jpg", Width: 480, Height: 360, Thumbnail: true},
+			},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				result, err := parser.Parse(context.Background(), uc.ParseRequestDTO{URL: server.URL + "/" + tc.fixture})
+				if err != nil {
+					t.Fatalf("Parse() unexpected error: %v", err)
+				}
+				if len(result.Items) != 1 {
+					t.Fatalf("Parse() got %d posts, want 1", len(result.Items))
+				}
+
+				post := result.Items[0]
+				if post.Content != tc.wantText {
+					t.Errorf("Content = %q, want %q", post.Content, tc.wantText)
+				}
+				if !reflect.DeepEqual(post.Media, tc.want) {
+					t.Errorf("Media = %+v, want %+v", post.Media, tc.want)
+				}
+			},
+		)
+	}
+}
@@ -2,35 +2,59 @@
 package rss
 
 import (
+	"context"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	uc "github.com/ee-crocush/go-news/go-news/internal/usecase/post"
-	strip "github.com/grokify/html-strip-tags-go"
 	"io"
 	"net/http"
 	"strings"
 	"time"
 )
 
+var (
+	// ErrUnknownFormat представляет ошибку неизвестного формата ленты.
+	ErrUnknownFormat = errors.New("unknown feed format")
+	// ErrUnexpectedStatus представляет ошибку неожиданного HTTP статуса ответа источника.
+	ErrUnexpectedStatus = errors.New("unexpected http status")
+)
+
+// format - формат ленты, определяемый по корневому элементу документа.
+type format int
+
+const (
+	formatUnknown format = iota
+	formatRSS
+	formatAtom
+	formatRDF
+)
+
 // Feed представляет RSS ленту.
 type Feed struct {
 	Channel Channel `xml:"channel"`
 }
 
 // Channel представляет канал RSS.
+// AtomLinks объявлено раньше Link: элементы atom:link (rel="self") иначе перезаписали бы ссылку на сайт.
 type Channel struct {
-	Title       string `xml:"title"`
-	Description string `xml:"description"`
-	Link        string `xml:"link"`
-	Items       []Item `xml:"item"`
+	Title       string     `xml:"title"`
+	Description string     `xml:"description"`
+	AtomLinks   []AtomLink `xml:"http://www.w3.org/2005/Atom link"`
+	Link        string     `xml:"link"`
+	Items       []Item     `xml:"item"`
 }
 
 // Item представляет элемент RSS.
 type Item struct {
-	Title       string `xml:"title"`
-	Description string `xml:"description"`
-	PubDate     string `xml:"pubDate"`
-	Link        string `xml:"link"`
+	MediaElements
+	GUID        string      `xml:"guid"`
+	Title       string      `xml:"title"`
+	Description string      `xml:"description"`
+	PubDate     string      `xml:"pubDate"`
+	Link        string      `xml:"link"`
+	Enclosures  []Enclosure `xml:"enclosure"`
+	Categories  []string    `xml:"category"`
 }
 
 // Parser представляет парсер RSS ленты.
@@ -47,58 +71,192 @@ func NewParser(timeout time.Duration) *Parser {
 	}
 }
 
-// Parse парсит RSS ленту по указанному URL и возвращает слайс спарсенных DTO.
-func (p *Parser) Parse(url string) ([]uc.ParsedRSSDTO, error) {
-	resp, err := p.client.Get(url)
+// Parse парсит ленту по указанному URL и возвращает слайс спарсенных DTO.
+// Формат ленты (RSS 2.0, Atom 1.0 или RSS 1.0/RDF) определяется по корневому элементу.
+// Тело перекодируется в UTF-8 по charset заголовка Content-Type или XML-декларации.
+// Если переданы валидаторы кэша, выполняется условный запрос: при ответе 304 Not Modified
+// лента не скачивается повторно и в результате выставляется NotModified.
+func (p *Parser) Parse(ctx context.Context, in uc.ParseRequestDTO) (uc.ParseResultDTO, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, in.URL, nil)
+	if err != nil {
+		return uc.ParseResultDTO{}, fmt.Errorf("http new request error: %w", err)
+	}
+
+	if in.ETag != "" {
+		req.Header.Set("If-None-Match", in.ETag)
+	}
+	if in.LastModified != "" {
+		req.Header.Set("If-Modified-Since", in.LastModified)
+	}
+
+	resp, err := p.client.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("http parse get error: %w", err)
+		return uc.ParseResultDTO{}, fmt.Errorf("http parse get error: %w", err)
 	}
 	defer resp.Body.Close()
 
+	if resp.StatusCode == http.StatusNotModified {
+		return uc.ParseResultDTO{
+			ETag:         in.ETag,
+			LastModified: in.LastModified,
+			NotModified:  true,
+			StatusCode:   resp.StatusCode,
+		}, nil
+	}
+
+	// После получения ответа его статус возвращается и при ошибке: он попадает в журнал опросов.
+	failed := uc.ParseResultDTO{StatusCode: resp.StatusCode}
+
+	if resp.StatusCode != http.StatusOK {
+		return failed, fmt.Errorf("%w: %d", ErrUnexpectedStatus, resp.StatusCode)
+	}
+
 	body, err := io.ReadAll(resp.Body)
 	if err != nil {
-		return nil, fmt.Errorf("http read body error: %w", err)
+		return failed, fmt.Errorf("http read body error: %w", err)
 	}
 
+	body, err = toUTF8(body, resp.Header.Get("Content-Type"))
+	if err != nil {
+		return failed, fmt.Errorf("charset error: %w", err)
+	}
+
+	result, err := p.decode(body)
+	if err != nil {
+		return failed, err
+	}
+
+	result.ETag = resp.Header.Get("ETag")
+	result.LastModified = resp.Header.Get("Last-Modified")
+	result.StatusCode = resp.StatusCode
+
+	return result, nil
+}
+
+// decode раскодирует тело ленты в UTF-8 в зависимости от её формата.
+func (p *Parser) decode(body []byte) (uc.ParseResultDTO, error) {
+	f, err := detectFormat(body)
+	if err != nil {
+		return uc.ParseResultDTO{}, fmt.Errorf("detect format error: %w", err)
+	}
+
+	switch f {
+	case formatRSS:
+		return p.decodeRSS(body)
+	case formatAtom:
+		return p.decodeAtom(body)
+	case formatRDF:
+		return p.decodeRDF(body)
+	default:
+		return uc.ParseResultDTO{}, ErrUnknownFormat
+	}
+}
+
+// detectFormat определяет формат ленты по имени корневого элемента.
+func detectFormat(body []byte) (format, error) {
+	decoder := newXMLDecoder(body)
+
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return formatUnknown, ErrUnknownFormat
+			}
+			return formatUnknown, fmt.Errorf("xml token error: %w", err)
+		}
+
+		start, ok := token.(xml.StartElement)
+		if !ok {
+			continue
+		}
+
+		switch strings.ToLower(start.Name.Local) {
+		case "rss":
+			return formatRSS, nil
+		case "feed":
+			return formatAtom, nil
+		case "rdf":
+			return formatRDF, nil
+		default:
+			return formatUnknown, ErrUnknownFormat
+		}
+	}
+}
+
+// decodeRSS раскодирует ленту в формате RSS 2.0.
+func (p *Parser) decodeRSS(body []byte) (uc.ParseResultDTO, error) {
 	var feed Feed
-	if err = xml.Unmarshal(body, &feed); err != nil {
-		return nil, fmt.Errorf("xml unmarshal error: %w", err)
+	if err := unmarshalXML(body, &feed); err != nil {
+		return uc.ParseResultDTO{}, fmt.Errorf("xml unmarshal error: %w", err)
 	}
 
-	var posts []uc.ParsedRSSDTO
+	var (
+		posts    []uc.ParsedRSSDTO
+		warnings []string
+	)
 	for _, item := range feed.Channel.Items {
-		post := p.itemToDTO(item)
+		post, err := p.itemToDTO(item)
+		if err != nil {
+			warnings = append(warnings, itemWarning(post, err))
+			continue
+		}
 		posts = append(posts, post)
 	}
 
-	return posts, nil
+	channel := uc.ChannelDTO{
+		Title: strings.TrimSpace(feed.Channel.Title),
+		Link:  strings.TrimSpace(feed.Channel.Link),
+	}
+
+	return uc.ParseResultDTO{Items: posts, Channel: channel, Warnings: warnings}, nil
 }
 
-func (p *Parser) itemToDTO(item Item) uc.ParsedRSSDTO {
-	content := strip.StripTags(item.Description)
-	pubTime := p.parseTime(item.PubDate)
+func (p *Parser) itemToDTO(item Item) (uc.ParsedRSSDTO, error) {
+	content, contentHTML := richContent(item.Description, item.Link)
+
+	media := newMediaCollector(item.Link)
+	media.addEnclosures(item.Enclosures)
+	media.addElements(item.MediaElements)
+	media.addInlineImages(item.Description)
+	pubTime, err := p.parseTime(item.PubDate)
 
 	return uc.ParsedRSSDTO{
-		Title:   item.Title,
-		Content: content,
-		Link:    item.Link,
-		PubTime: pubTime,
+		GUID:        strings.TrimSpace(item.GUID),
+		Title:       item.Title,
+		Content:     content,
+		ContentHTML: contentHTML,
+		Link:        item.Link,
+		PubTime:     pubTime,
+		Media:       media.media,
+		Tags:        item.Categories,
+	}, err
+}
+
+// parseTime разбирает дату публикации записи в секунды Unix.
+// Запись без даты (элемент необязателен в RSS) получает время обнаружения, неразборчивая дата - ошибку:
+// такие записи пропускаются, чтобы выдуманное время не поднимало их в начало ленты.
+func (p *Parser) parseTime(dateStr string) (int64, error) {
+	if strings.TrimSpace(dateStr) == "" {
+		return time.Now().Unix(), nil
 	}
+
+	t, err := parseDate(dateStr)
+	if err != nil {
+		return 0, err
+	}
+
+	return t.Unix(), nil
 }
 
-func (p *Parser) parseTime(dateStr string) int64 {
-	dateStr = strings.ReplaceAll(dateStr, ",", "")
-	formats := []string{
-		"Mon 2 Jan 2006 15:04:05 -0700",
-		"Mon 2 Jan 2006 15:04:05 GMT",
-		"2 Jan 2006 15:04:05 -0700",
-		"2006-01-02T15:04:05Z07:00",
-		"2006-01-02T15:04:05Z",
-	}
-	for _, format := range formats {
-		if t, err := time.Parse(format, dateStr); err == nil {
-			return t.Unix()
-		}
+// itemWarning формирует предупреждение о пропущенной записи.
+func itemWarning(item uc.ParsedRSSDTO, err error) string {
+	ref := item.Link
+	if ref == "" {
+		ref = item.GUID
+	}
+	if ref == "" {
+		ref = item.Title
 	}
-	return time.Now().Unix()
+
+	return fmt.Sprintf("item %q skipped: %v", ref, err)
 }
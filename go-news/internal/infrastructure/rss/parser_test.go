@@ -1,29 +1,41 @@
 package rss
 
 import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
 	"testing"
 	"time"
+
+	uc "github.com/ee-crocush/go-news/go-news/internal/usecase/post"
 )
 
 func TestParser_Parse(t *testing.T) {
 	parser := NewParser(10 * time.Second)
 
-	feed, err := parser.Parse("https://habr.com/ru/rss/best/daily/?fl=ru")
+	feed, err := parser.Parse(
+		context.Background(), uc.ParseRequestDTO{URL: "https://habr.com/ru/rss/best/daily/?fl=ru"},
+	)
 	if err != nil {
 		t.Fatal(err)
 	}
-	if len(feed) == 0 {
+	if len(feed.Items) == 0 {
 		t.Fatal("данные не раскодированы")
 	}
-	t.Logf("получено %d новостей\n", len(feed))
+	t.Logf("получено %d новостей\n", len(feed.Items))
 }
 
 func TestParser_parseTime(t *testing.T) {
 	parser := NewParser(5 * time.Second)
 
 	testCases := []struct {
-		input string
-		name  string
+		input   string
+		name    string
+		wantErr bool
 	}{
 		{
 			input: "Mon, 02 Jan 2006 15:04:05 GMT",
@@ -34,19 +46,29 @@ func TestParser_parseTime(t *testing.T) {
 			name:  "ISO format",
 		},
 		{
-			input: "invalid date",
-			name:  "Invalid date fallback",
+			input: "",
+			name:  "Missing date",
+		},
+		{
+			input:   "invalid date",
+			name:    "Invalid date",
+			wantErr: true,
 		},
 	}
 
 	for _, tc := range testCases {
 		t.Run(
 			tc.name, func(t *testing.T) {
-				result := parser.parseTime(tc.input)
-				if result <= 0 {
-					t.Errorf("Expected valid timestamp, got: %d", result)
+				result, err := parser.parseTime(tc.input)
+				if tc.wantErr {
+					if !errors.Is(err, ErrInvalidDate) {
+						t.Errorf("Expected ErrInvalidDate, got: %v", err)
+					}
+					return
+				}
+				if err != nil || result <= 0 {
+					t.Errorf("Expected valid timestamp, got: %d, %v", result, err)
 				}
-				t.Logf("Input: %s, Result: %d", tc.input, result)
 			},
 		)
 	}
@@ -68,3 +90,280 @@ func TestNewParser(t *testing.T) {
 		t.Errorf("Expected timeout %v, got: %v", timeout, parser.client.Timeout)
 	}
 }
+
+// newFixtureServer поднимает HTTP сервер, отдающий файлы из testdata.
+func newFixtureServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	server := httptest.NewServer(http.FileServer(http.Dir("testdata")))
+	t.Cleanup(server.Close)
+
+	return server
+}
+
+func TestParser_Parse_Formats(t *testing.T) {
+	server := newFixtureServer(t)
+	parser := NewParser(5 * time.Second)
+
+	testCases := []struct {
+		name      string
+		fixture   string
+		wantTitle []string
+		wantLink  []string
+		wantTime  []int64
+		wantText  []string
+		wantHTML  []string
+		wantTags  [][]string
+		wantChan  uc.ChannelDTO
+	}{
+		{
+			name:      "RSS 2.0",
+			fixture:   "rss2.xml",
+			wantTitle: []string{"Вышел Go 1.24", "Generics в деталях"},
+			wantLink:  []string{"https://example.com/posts/go-1-24", "https://example.com/posts/generics"},
+			wantTime:  []int64{1739296800, 1739179800},
+			wantText:  []string{"Релиз Go 1.24 уже доступен.", "Разбираем дженерики."},
+			wantHTML:  []string{"<p>Релиз <b>Go 1.24</b> уже доступен.</p>", "Разбираем дженерики."},
+			wantTags:  [][]string{{"Go", "Релизы"}, nil},
+			wantChan:  uc.ChannelDTO{Title: "Go News", Link: "https://example.com/"},
+		},
+		{
+			name:      "Atom 1.0",
+			fixture:   "atom.xml",
+			wantTitle: []string{"Go 1.24 is released!", "Range & iterators", "Structured logging"},
+			wantLink: []string{
+				"https://go.dev/blog/go1.24",
+				"https://go.dev/blog/range-functions",
+				"https://go.dev/blog/slog",
+			},
+			wantTime: []int64{1739296800, 1724144400, 1692662400},
+			wantText: []string{
+				"Today the Go team is very happy to release Go 1.24.",
+				"Range over function types.",
+				"The new log/slog package.",
+			},
+			wantHTML: []string{
+				"<p>Today the Go team is <em>very happy</em> to release Go 1.24.</p>",
+				"Range over function types.",
+				"<p>The new <code>log/slog</code> package.</p>",
+			},
+			wantTags: [][]string{{"release", "Инструменты"}, nil, nil},
+			wantChan: uc.ChannelDTO{Title: "Go Blog", Link: "https://go.dev/blog/"},
+		},
+		{
+			name:      "RSS 1.0 (RDF)",
+			fixture:   "rdf.xml",
+			wantTitle: []string{"Первая новость", "Вторая новость"},
+			wantLink:  []string{"https://example.org/news/1", "https://example.org/news/2"},
+			wantTime:  []int64{1736917200, 1737018000},
+			wantText:  []string{"Текст первой новости", "Текст второй новости"},
+			wantHTML:  []string{"<p>Текст первой новости</p>", "Текст второй новости"},
+			wantTags:  [][]string{{"Общество"}, nil},
+			wantChan:  uc.ChannelDTO{Title: "Example RDF", Link: "https://example.org/"},
+		},
+		{
+			name:      "RSS 2.0 windows-1251",
+			fixture:   "rss2_cp1251.xml",
+			wantTitle: []string{"Открыт новый мост через Волгу"},
+			wantLink:  []string{"https://example.ru/news/most"},
+			wantTime:  []int64{1739286000},
+			wantText:  []string{"Движение по мосту «Северный» открыто — с 6 утра."},
+			wantHTML:  []string{"<p>Движение по мосту «Северный» открыто — с 6 утра.</p>"},
+			wantTags:  [][]string{{"Город"}},
+			wantChan:  uc.ChannelDTO{Title: "Новости региона", Link: "https://example.ru/"},
+		},
+		{
+			name:      "Atom 1.0 koi8-r",
+			fixture:   "atom_koi8r.xml",
+			wantTitle: []string{"Ёлочные игрушки и кодировки"},
+			wantLink:  []string{"https://example.ru/blog/koi8"},
+			wantTime:  []int64{1739286000},
+			wantText:  []string{"Почему КОИ-8 до сих пор встречается в лентах."},
+			wantHTML:  []string{"Почему КОИ-8 до сих пор встречается в лентах."},
+			wantTags:  [][]string{nil},
+			wantChan:  uc.ChannelDTO{Title: "Заметки разработчика", Link: "https://example.ru/blog/"},
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				result, err := parser.Parse(context.Background(), uc.ParseRequestDTO{URL: server.URL + "/" + tc.fixture})
+				if err != nil {
+					t.Fatalf("Parse() unexpected error: %v", err)
+				}
+
+				if result.Channel != tc.wantChan {
+					t.Errorf("Parse() channel = %+v, want %+v", result.Channel, tc.wantChan)
+				}
+
+				posts := result.Items
+				if len(posts) != len(tc.wantTitle) {
+					t.Fatalf("Parse() got %d posts, want %d", len(posts), len(tc.wantTitle))
+				}
+
+				for i, post := range posts {
+					if post.Title != tc.wantTitle[i] {
+						t.Errorf("post[%d].Title = %q, want %q", i, post.Title, tc.wantTitle[i])
+					}
+					if post.Link != tc.wantLink[i] {
+						t.Errorf("post[%d].Link = %q, want %q", i, post.Link, tc.wantLink[i])
+					}
+					if post.PubTime != tc.wantTime[i] {
+						t.Errorf("post[%d].PubTime = %d, want %d", i, post.PubTime, tc.wantTime[i])
+					}
+					if post.Content != tc.wantText[i] {
+						t.Errorf("post[%d].Content = %q, want %q", i, post.Content, tc.wantText[i])
+					}
+					if post.ContentHTML != tc.wantHTML[i] {
+						t.Errorf("post[%d].ContentHTML = %q, want %q", i, post.ContentHTML, tc.wantHTML[i])
+					}
+					if !reflect.DeepEqual(post.Tags, tc.wantTags[i]) {
+						t.Errorf("post[%d].Tags = %q, want %q", i, post.Tags, tc.wantTags[i])
+					}
+				}
+			},
+		)
+	}
+}
+
+func TestDetectFormat(t *testing.T) {
+	testCases := []struct {
+		fixture string
+		want    format
+	}{
+		{fixture: "rss2.xml", want: formatRSS},
+		{fixture: "atom.xml", want: formatAtom},
+		{fixture: "rdf.xml", want: formatRDF},
+		{fixture: "rss2_cp1251.xml", want: formatRSS},
+		{fixture: "atom_koi8r.xml", want: formatAtom},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.fixture, func(t *testing.T) {
+				body, err := os.ReadFile(filepath.Join("testdata", tc.fixture))
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				got, err := detectFormat(body)
+				if err != nil {
+					t.Fatalf("detectFormat() unexpected error: %v", err)
+				}
+				if got != tc.want {
+					t.Errorf("detectFormat() = %v, want %v", got, tc.want)
+				}
+			},
+		)
+	}
+
+	t.Run(
+		"unknown root", func(t *testing.T) {
+			_, err := detectFormat([]byte(`<?xml version="1.0"?><html><body/></html>`))
+			if !errors.Is(err, ErrUnknownFormat) {
+				t.Errorf("detectFormat() expected ErrUnknownFormat, got %v", err)
+			}
+		},
+	)
+}
+
+func TestParser_Parse_ConditionalGet(t *testing.T) {
+	const etag = `"v1"`
+	const lastModified = "Tue, 11 Feb 2025 18:00:00 GMT"
+
+	body, err := os.ReadFile(filepath.Join("testdata", "rss2.xml"))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var requests int
+	server := httptest.NewServer(
+		http.HandlerFunc(
+			func(w http.ResponseWriter, r *http.Request) {
+				requests++
+				if r.Header.Get("If-None-Match") == etag && r.Header.Get("If-Modified-Since") == lastModified {
+					w.WriteHeader(http.StatusNotModified)
+					return
+				}
+
+				w.Header().Set("ETag", etag)
+				w.Header().Set("Last-Modified", lastModified)
+				_, _ = w.Write(body)
+			},
+		),
+	)
+	defer server.Close()
+
+	parser := NewParser(5 * time.Second)
+
+	first, err := parser.Parse(context.Background(), uc.ParseRequestDTO{URL: server.URL})
+	if err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+	if first.NotModified || len(first.Items) != 2 {
+		t.Fatalf("first Parse() expected full feed, got NotModified=%v items=%d", first.NotModified, len(first.Items))
+	}
+	if first.ETag != etag || first.LastModified != lastModified {
+		t.Errorf("validators = (%q, %q), want (%q, %q)", first.ETag, first.LastModified, etag, lastModified)
+	}
+
+	second, err := parser.Parse(
+		context.Background(), uc.ParseRequestDTO{URL: server.URL, ETag: first.ETag, LastModified: first.LastModified},
+	)
+	if err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+	if !second.NotModified {
+		t.Error("second Parse() expected NotModified")
+	}
+	if len(second.Items) != 0 {
+		t.Errorf("second Parse() expected no items, got %d", len(second.Items))
+	}
+	if second.ETag != etag || second.LastModified != lastModified {
+		t.Errorf("validators must be kept on 304, got (%q, %q)", second.ETag, second.LastModified)
+	}
+	if requests != 2 {
+		t.Errorf("expected 2 requests, got %d", requests)
+	}
+}
+
+func TestParser_Parse_UnexpectedStatus(t *testing.T) {
+	server := httptest.NewServer(http.NotFoundHandler())
+	defer server.Close()
+
+	result, err := NewParser(5*time.Second).Parse(context.Background(), uc.ParseRequestDTO{URL: server.URL})
+	if !errors.Is(err, ErrUnexpectedStatus) {
+		t.Errorf("Parse() expected ErrUnexpectedStatus, got %v", err)
+	}
+	if result.StatusCode != http.StatusNotFound {
+		t.Errorf("StatusCode = %d, want %d", result.StatusCode, http.StatusNotFound)
+	}
+}
+
+func TestParser_Parse_InvalidDates(t *testing.T) {
+	server := newFixtureServer(t)
+
+	before := time.Now().Unix()
+	result, err := NewParser(5*time.Second).Parse(
+		context.Background(), uc.ParseRequestDTO{URL: server.URL + "/bad_dates.xml"},
+	)
+	if err != nil {
+		t.Fatalf("Parse() unexpected error: %v", err)
+	}
+
+	if len(result.Items) != 2 {
+		t.Fatalf("expected 2 items, got %d", len(result.Items))
+	}
+	if result.Items[0].PubTime != 1739286000 {
+		t.Errorf("PubTime = %d, want %d", result.Items[0].PubTime, 1739286000)
+	}
+	if result.Items[1].Link != "https://example.com/posts/3" || result.Items[1].PubTime < before {
+		t.Errorf("item without date must get discovery time, got %+v", result.Items[1])
+	}
+
+	want := `item "https://example.com/posts/2" skipped: unparseable date "вчера вечером"`
+	if len(result.Warnings) != 1 || result.Warnings[0] != want {
+		t.Errorf("Warnings = %q, want [%q]", result.Warnings, want)
+	}
+}
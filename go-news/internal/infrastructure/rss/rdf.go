@@ -0,0 +1,86 @@
+package rss
+
+import (
+	"fmt"
+	"strings"
+
+	uc "github.com/ee-crocush/go-news/go-news/internal/usecase/post"
+)
+
+// RDFFeed представляет ленту RSS 1.0 (RDF).
+// В отличие от RSS 2.0 элементы item находятся на одном уровне с channel.
+type RDFFeed struct {
+	Channel RDFChannel `xml:"channel"`
+	Items   []RDFItem  `xml:"item"`
+}
+
+// RDFChannel представляет канал RSS 1.0.
+type RDFChannel struct {
+	Title       string `xml:"title"`
+	Description string `xml:"description"`
+	Link        string `xml:"link"`
+}
+
+// RDFItem представляет элемент RSS 1.0.
+type RDFItem struct {
+	MediaElements
+	About       string   `xml:"about,attr"`
+	Title       string   `xml:"title"`
+	Description string   `xml:"description"`
+	Link        string   `xml:"link"`
+	Date        string   `xml:"http://purl.org/dc/elements/1.1/ date"`
+	Subjects    []string `xml:"http://purl.org/dc/elements/1.1/ subject"`
+}
+
+// decodeRDF раскодирует ленту в формате RSS 1.0 (RDF).
+func (p *Parser) decodeRDF(body []byte) (uc.ParseResultDTO, error) {
+	var feed RDFFeed
+	if err := unmarshalXML(body, &feed); err != nil {
+		return uc.ParseResultDTO{}, fmt.Errorf("xml unmarshal rdf error: %w", err)
+	}
+
+	var (
+		posts    []uc.ParsedRSSDTO
+		warnings []string
+	)
+	for _, item := range feed.Items {
+		post, err := p.rdfItemToDTO(item)
+		if err != nil {
+			warnings = append(warnings, itemWarning(post, err))
+			continue
+		}
+		posts = append(posts, post)
+	}
+
+	channel := uc.ChannelDTO{
+		Title: strings.TrimSpace(feed.Channel.Title),
+		Link:  strings.TrimSpace(feed.Channel.Link),
+	}
+
+	return uc.ParseResultDTO{Items: posts, Channel: channel, Warnings: warnings}, nil
+}
+
+func (p *Parser) rdfItemToDTO(item RDFItem) (uc.ParsedRSSDTO, error) {
+	link := strings.TrimSpace(item.Link)
+	if link == "" {
+		link = item.About
+	}
+
+	content, contentHTML := richContent(item.Description, link)
+
+	media := newMediaCollector(link)
+	media.addElements(item.MediaElements)
+	media.addInlineImages(item.Description)
+	pubTime, err := p.parseTime(item.Date)
+
+	return uc.ParsedRSSDTO{
+		GUID:        strings.TrimSpace(item.About),
+		Title:       strings.TrimSpace(item.Title),
+		Content:     content,
+		ContentHTML: contentHTML,
+		Link:        link,
+		PubTime:     pubTime,
+		Media:       media.media,
+		Tags:        item.Subjects,
+	}, err
+}
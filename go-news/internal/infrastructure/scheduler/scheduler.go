@@ -0,0 +1,448 @@
+// Package scheduler выполняет периодический опрос источников новостей.
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"math/rand/v2"
+	"sort"
+	"sync"
+	"time"
+
+	feedUC "github.com/ee-crocush/go-news/go-news/internal/usecase/feed"
+	uc "github.com/ee-crocush/go-news/go-news/internal/usecase/post"
+	"github.com/ee-crocush/go-news/pkg/logger"
+	"github.com/rs/zerolog"
+)
+
+var (
+	// ErrAlreadyStarted представляет ошибку повторного запуска планировщика.
+	ErrAlreadyStarted = errors.New("scheduler already started")
+	// ErrStopped представляет ошибку запуска опроса после остановки планировщика.
+	ErrStopped = errors.New("scheduler stopped")
+)
+
+// FeedLister — интерфейс получения включенных источников.
+type FeedLister interface {
+	Execute(ctx context.Context, in feedUC.FindEnabledInputDTO) ([]feedUC.ScheduledFeedDTO, error)
+}
+
+// Runner — интерфейс опроса одного источника.
+type Runner interface {
+	Execute(ctx context.Context, in uc.ParseAndStoreInputDTO) (uc.ParseAndStoreOutputDTO, error)
+}
+
+// Config - параметры планировщика.
+type Config struct {
+	// Workers - количество одновременно опрашиваемых источников.
+	Workers int
+	// Tick - период проверки расписания и обновления списка источников.
+	Tick time.Duration
+	// DefaultInterval - интервал опроса источников без собственного интервала.
+	DefaultInterval time.Duration
+	// Jitter - доля случайного разброса интервала опроса (0..1).
+	Jitter float64
+	// MaxBackoff - максимальная задержка повторного опроса после ошибок.
+	MaxBackoff time.Duration
+}
+
+// Scheduler опрашивает источники по расписанию ограниченным пулом воркеров.
+// Для каждого источника хранится время следующего опроса: после успеха оно сдвигается
+// на интервал источника со случайным разбросом, после ошибок - экспоненциально растет
+// вплоть до MaxBackoff. Одновременно выполняется не более одного опроса источника:
+// плановый и ручной опросы (RunNow) объединяются.
+type Scheduler struct {
+	feeds  FeedLister
+	runner Runner
+	cfg    Config
+	log    *zerolog.Logger
+
+	mu       sync.Mutex
+	entries  map[int32]*entry
+	inflight map[int32]*poll
+	started  bool
+	stopped  bool
+	polls    sync.WaitGroup
+
+	jobs   chan *entry
+	wake   chan struct{}
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	now    func() time.Time
+	random func() float64
+}
+
+// New создает новый планировщик.
+func New(feeds FeedLister, runner Runner, cfg Config, log *zerolog.Logger) *Scheduler {
+	if cfg.Workers < 1 {
+		cfg.Workers = 1
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Scheduler{
+		feeds:    feeds,
+		runner:   runner,
+		cfg:      cfg,
+		log:      log,
+		entries:  make(map[int32]*entry),
+		inflight: make(map[int32]*poll),
+		jobs:     make(chan *entry),
+		wake:     make(chan struct{}, 1),
+		ctx:      ctx,
+		cancel:   cancel,
+		done:     make(chan struct{}),
+		now:      time.Now,
+		random:   rand.Float64,
+	}
+}
+
+// Start запускает планировщик и блокируется до вызова Shutdown.
+func (s *Scheduler) Start() error {
+	s.mu.Lock()
+	if s.started {
+		s.mu.Unlock()
+		return ErrAlreadyStarted
+	}
+	s.started = true
+	s.mu.Unlock()
+
+	defer close(s.done)
+
+	var wg sync.WaitGroup
+	for range s.cfg.Workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			s.worker()
+		}()
+	}
+
+	ticker := time.NewTicker(s.cfg.Tick)
+	defer ticker.Stop()
+
+	for {
+		s.refresh()
+		s.dispatch()
+
+		select {
+		case <-s.ctx.Done():
+			close(s.jobs)
+			wg.Wait()
+
+			// Ручные опросы выполняются вне пула воркеров, их тоже нужно дождаться.
+			s.mu.Lock()
+			s.stopped = true
+			s.mu.Unlock()
+			s.polls.Wait()
+
+			return nil
+		case <-ticker.C:
+		case <-s.wake:
+		}
+	}
+}
+
+// Shutdown останавливает планировщик: новые опросы не запускаются, текущие отменяются.
+// Ожидает завершения воркеров, пока не истечет ctx.
+func (s *Scheduler) Shutdown(ctx context.Context) error {
+	s.cancel()
+
+	s.mu.Lock()
+	started := s.started
+	s.mu.Unlock()
+
+	if !started {
+		return nil
+	}
+
+	select {
+	case <-s.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// refresh синхронизирует расписание со списком включенных источников.
+func (s *Scheduler) refresh() {
+	feeds, err := s.feeds.Execute(s.ctx, feedUC.FindEnabledInputDTO{DefaultInterval: s.cfg.DefaultInterval})
+	if err != nil {
+		if s.ctx.Err() == nil {
+			s.log.Error().Err(err).Msg("scheduler: failed to load feeds")
+		}
+		return
+	}
+
+	now := s.now()
+	seen := make(map[int32]struct{}, len(feeds))
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, f := range feeds {
+		seen[f.ID] = struct{}{}
+
+		e, ok := s.entries[f.ID]
+		if !ok {
+			s.entries[f.ID] = newEntry(f, s.firstRun(f, now))
+			continue
+		}
+
+		e.url = f.URL
+		if e.interval != f.Interval {
+			e.interval = f.Interval
+			// Новый интервал применяется сразу, если следующий опрос был запланирован позже.
+			if e.failures == 0 && e.nextRun.After(now.Add(f.Interval)) {
+				e.nextRun = now.Add(s.withJitter(f.Interval))
+			}
+		}
+	}
+
+	for id, e := range s.entries {
+		if _, ok := seen[id]; !ok && !e.running {
+			delete(s.entries, id)
+		}
+	}
+}
+
+// dispatch передает свободным воркерам источники, время опроса которых наступило.
+// Канал заданий небуферизованный: если все воркеры заняты, источник будет передан на следующем тике.
+func (s *Scheduler) dispatch() {
+	now := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for _, e := range s.entries {
+		if e.running || now.Before(e.nextRun) {
+			continue
+		}
+
+		select {
+		case s.jobs <- e:
+			e.running = true
+		default:
+			return
+		}
+	}
+}
+
+// worker выполняет опросы из очереди.
+func (s *Scheduler) worker() {
+	for e := range s.jobs {
+		s.run(e)
+	}
+}
+
+// poll - выполняющийся опрос источника, результат которого получают все ожидающие его вызовы.
+type poll struct {
+	done chan struct{}
+	out  uc.ParseAndStoreOutputDTO
+	err  error
+}
+
+// run опрашивает источник по расписанию. Если источник уже опрашивается вручную,
+// дожидается завершения этого опроса.
+func (s *Scheduler) run(e *entry) {
+	s.mu.Lock()
+	url := e.url
+	p, leader := s.startPoll(e.id)
+	s.mu.Unlock()
+
+	if leader {
+		s.execute(s.ctx, p, e.id, url)
+		return
+	}
+
+	<-p.done
+}
+
+// RunNow немедленно опрашивает источник вне расписания и возвращает результат опроса.
+// Если источник уже опрашивается (по расписанию или другим вызовом), новый опрос не запускается:
+// вызов дожидается текущего и получает его результат, shared = true. Ручные опросы не занимают
+// воркеров пула; ctx ограничивает только ожидание результата, опрос продолжается до остановки планировщика.
+func (s *Scheduler) RunNow(ctx context.Context, id int32, url string) (
+	out uc.ParseAndStoreOutputDTO, shared bool, err error,
+) {
+	s.mu.Lock()
+	if s.stopped || s.ctx.Err() != nil {
+		s.mu.Unlock()
+		return out, false, ErrStopped
+	}
+	p, leader := s.startPoll(id)
+	s.mu.Unlock()
+
+	if leader {
+		// Опрос не ограничен ctx вызова, но продолжает его request_id: с ним отправляются события новостей
+		pollCtx := s.ctx
+		if requestID := logger.RequestID(ctx); requestID != "" {
+			pollCtx = logger.WithRequestID(pollCtx, requestID)
+		}
+		go s.execute(pollCtx, p, id, url)
+	}
+
+	select {
+	case <-p.done:
+		return p.out, !leader, p.err
+	case <-ctx.Done():
+		return out, !leader, ctx.Err()
+	}
+}
+
+// RunAllNow планирует немедленный опрос всех источников расписания. Источники, опрос которых уже
+// выполняется, пропускаются. Возвращает ID запланированных источников по возрастанию.
+// Опросы выполняются пулом воркеров: если все воркеры заняты, остальные источники будут опрошены
+// по мере их освобождения.
+func (s *Scheduler) RunAllNow() []int32 {
+	now := s.now()
+
+	s.mu.Lock()
+	ids := make([]int32, 0, len(s.entries))
+	for id, e := range s.entries {
+		if e.running {
+			continue
+		}
+		e.nextRun = now
+		ids = append(ids, id)
+	}
+	s.mu.Unlock()
+
+	select {
+	case s.wake <- struct{}{}:
+	default:
+	}
+
+	sort.Slice(ids, func(i, j int) bool { return ids[i] < ids[j] })
+
+	return ids
+}
+
+// startPoll регистрирует опрос источника. Если опрос уже выполняется, возвращает его и leader = false.
+// Вызывается под s.mu.
+func (s *Scheduler) startPoll(id int32) (p *poll, leader bool) {
+	if p, ok := s.inflight[id]; ok {
+		return p, false
+	}
+
+	p = &poll{done: make(chan struct{})}
+	s.inflight[id] = p
+	s.polls.Add(1)
+
+	if e, ok := s.entries[id]; ok {
+		e.running = true
+	}
+
+	return p, true
+}
+
+// execute опрашивает источник в ctx (производном от контекста планировщика), передает результат
+// ожидающим вызовам и планирует следующий опрос.
+func (s *Scheduler) execute(ctx context.Context, p *poll, id int32, url string) {
+	defer s.polls.Done()
+
+	started := s.now()
+	out, err := s.runner.Execute(ctx, uc.ParseAndStoreInputDTO{URL: url})
+	finished := s.now()
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.inflight, id)
+	p.out, p.err = out, err
+	close(p.done)
+
+	e, ok := s.entries[id]
+	if !ok {
+		// Источник не в расписании (выключен или еще не загружен): опрос выполнен вручную.
+		e = &entry{id: id, url: url}
+	}
+
+	e.running = false
+	e.lastRun = finished
+	e.lastDuration = finished.Sub(started)
+
+	if err != nil {
+		if s.ctx.Err() != nil {
+			// Опрос прерван остановкой планировщика, это не ошибка источника.
+			return
+		}
+
+		e.failures++
+		e.lastError = err.Error()
+		e.nextRun = finished.Add(s.withJitter(backoff(e.interval, e.failures, s.cfg.MaxBackoff)))
+
+		s.log.Error().
+			Err(err).
+			Str("url", url).
+			Int("failures", e.failures).
+			Time("next_run", e.nextRun).
+			Msg("rss parse failed")
+		return
+	}
+
+	e.failures = 0
+	e.lastError = ""
+	e.nextRun = finished.Add(s.withJitter(e.interval))
+
+	s.log.Debug().
+		Str("url", url).
+		Int("inserted", out.Inserted).
+		Int("skipped", out.Skipped).
+		Int("clustered", out.Clustered).
+		Bool("not_modified", out.NotModified).
+		Int("articles_failed", out.ArticlesFailed).
+		Msg("rss parsed")
+
+	if len(out.Warnings) > 0 {
+		s.log.Warn().
+			Str("url", url).
+			Strs("warnings", out.Warnings).
+			Msg("rss items skipped")
+	}
+}
+
+// firstRun вычисляет время первого опроса источника после запуска или добавления.
+// Источники, которые еще не опрашивались или опрос которых просрочен, опрашиваются сразу
+// со случайным сдвигом в пределах разброса, чтобы не запускать все опросы одновременно.
+func (s *Scheduler) firstRun(f feedUC.ScheduledFeedDTO, now time.Time) time.Time {
+	if !f.LastFetchedAt.IsZero() {
+		next := f.LastFetchedAt.Add(f.Interval)
+		if next.After(now) {
+			return next
+		}
+	}
+
+	return now.Add(time.Duration(s.random() * s.cfg.Jitter * float64(f.Interval)))
+}
+
+// withJitter добавляет к интервалу случайный разброс ±Jitter.
+func (s *Scheduler) withJitter(d time.Duration) time.Duration {
+	if s.cfg.Jitter <= 0 {
+		return d
+	}
+
+	delta := (s.random()*2 - 1) * s.cfg.Jitter * float64(d)
+
+	return d + time.Duration(delta)
+}
+
+// backoff возвращает задержку следующего опроса после failures ошибок подряд:
+// интервал удваивается после каждой ошибки, но не превышает limit (и не бывает меньше интервала).
+func backoff(interval time.Duration, failures int, limit time.Duration) time.Duration {
+	if limit < interval {
+		limit = interval
+	}
+
+	d := interval
+	for i := 0; i < failures; i++ {
+		d *= 2
+		if d >= limit {
+			return limit
+		}
+	}
+
+	return d
+}
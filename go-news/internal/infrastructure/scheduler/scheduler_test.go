@@ -0,0 +1,341 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	feedUC "github.com/ee-crocush/go-news/go-news/internal/usecase/feed"
+	uc "github.com/ee-crocush/go-news/go-news/internal/usecase/post"
+	"github.com/ee-crocush/go-news/pkg/logger"
+	"github.com/rs/zerolog"
+)
+
+type mockLister struct {
+	mu    sync.Mutex
+	feeds []feedUC.ScheduledFeedDTO
+}
+
+func (m *mockLister) Execute(ctx context.Context, in feedUC.FindEnabledInputDTO) ([]feedUC.ScheduledFeedDTO, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.feeds, nil
+}
+
+func (m *mockLister) set(feeds ...feedUC.ScheduledFeedDTO) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.feeds = feeds
+}
+
+type mockRunner struct {
+	calls atomic.Int32
+	err   error
+	// block заставляет опрос ждать отмены контекста.
+	block bool
+	// gate, если задан, задерживает опрос до закрытия канала.
+	gate chan struct{}
+	// requestID - request_id контекста последнего опроса.
+	requestID atomic.Value
+}
+
+func (m *mockRunner) Execute(ctx context.Context, in uc.ParseAndStoreInputDTO) (uc.ParseAndStoreOutputDTO, error) {
+	m.calls.Add(1)
+	m.requestID.Store(logger.RequestID(ctx))
+	if m.block {
+		<-ctx.Done()
+		return uc.ParseAndStoreOutputDTO{}, ctx.Err()
+	}
+	if m.gate != nil {
+		<-m.gate
+	}
+	return uc.ParseAndStoreOutputDTO{Inserted: 1}, m.err
+}
+
+func newTestScheduler(lister FeedLister, runner Runner, cfg Config) *Scheduler {
+	log := zerolog.Nop()
+	s := New(lister, runner, cfg, &log)
+	s.random = func() float64 { return 0.5 }
+	return s
+}
+
+func TestBackoff(t *testing.T) {
+	testCases := []struct {
+		failures int
+		max      time.Duration
+		want     time.Duration
+	}{
+		{failures: 1, max: time.Hour, want: 10 * time.Minute},
+		{failures: 2, max: time.Hour, want: 20 * time.Minute},
+		{failures: 3, max: time.Hour, want: 40 * time.Minute},
+		{failures: 4, max: time.Hour, want: time.Hour},
+		{failures: 50, max: time.Hour, want: time.Hour},
+		// Максимум меньше интервала - интервал не уменьшается.
+		{failures: 3, max: time.Minute, want: 5 * time.Minute},
+	}
+
+	for _, tc := range testCases {
+		if got := backoff(5*time.Minute, tc.failures, tc.max); got != tc.want {
+			t.Errorf("backoff(5m, %d, %v) = %v, want %v", tc.failures, tc.max, got, tc.want)
+		}
+	}
+}
+
+func TestScheduler_WithJitter(t *testing.T) {
+	s := newTestScheduler(&mockLister{}, &mockRunner{}, Config{Jitter: 0.1})
+
+	s.random = func() float64 { return 0 }
+	if got := s.withJitter(10 * time.Minute); got != 9*time.Minute {
+		t.Errorf("withJitter() lower bound = %v, want 9m", got)
+	}
+
+	s.random = func() float64 { return 1 }
+	if got := s.withJitter(10 * time.Minute); got != 11*time.Minute {
+		t.Errorf("withJitter() upper bound = %v, want 11m", got)
+	}
+}
+
+func TestScheduler_FirstRun(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	s := newTestScheduler(&mockLister{}, &mockRunner{}, Config{Jitter: 0.1})
+
+	recent := feedUC.ScheduledFeedDTO{Interval: 10 * time.Minute, LastFetchedAt: now.Add(-4 * time.Minute)}
+	if got := s.firstRun(recent, now); !got.Equal(now.Add(6 * time.Minute)) {
+		t.Errorf("firstRun() for recently fetched feed = %v, want %v", got, now.Add(6*time.Minute))
+	}
+
+	never := feedUC.ScheduledFeedDTO{Interval: 10 * time.Minute}
+	if got := s.firstRun(never, now); !got.Equal(now.Add(30 * time.Second)) {
+		t.Errorf("firstRun() for new feed = %v, want %v", got, now.Add(30*time.Second))
+	}
+}
+
+func TestScheduler_BackoffOnError(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	lister := &mockLister{}
+	lister.set(feedUC.ScheduledFeedDTO{ID: 1, URL: "https://example.com/rss", Interval: 5 * time.Minute})
+	runner := &mockRunner{err: errors.New("connection refused")}
+
+	s := newTestScheduler(lister, runner, Config{Workers: 1, MaxBackoff: time.Hour})
+	s.now = func() time.Time { return now }
+
+	s.refresh()
+	e := s.entries[1]
+
+	s.run(e)
+	s.run(e)
+
+	status := s.Status()
+	if len(status.Feeds) != 1 {
+		t.Fatalf("expected 1 feed in status, got %d", len(status.Feeds))
+	}
+
+	got := status.Feeds[0]
+	if got.Failures != 2 || got.LastError != "connection refused" {
+		t.Errorf("unexpected status after failures: %+v", got)
+	}
+	if !got.NextRun.Equal(now.Add(20 * time.Minute)) {
+		t.Errorf("NextRun = %v, want %v", got.NextRun, now.Add(20*time.Minute))
+	}
+
+	runner.err = nil
+	s.run(e)
+
+	got = s.Status().Feeds[0]
+	if got.Failures != 0 || got.LastError != "" || !got.NextRun.Equal(now.Add(5*time.Minute)) {
+		t.Errorf("unexpected status after success: %+v", got)
+	}
+}
+
+func TestScheduler_RefreshRemovesDisabledFeeds(t *testing.T) {
+	lister := &mockLister{}
+	lister.set(
+		feedUC.ScheduledFeedDTO{ID: 1, URL: "https://example.com/1", Interval: time.Minute},
+		feedUC.ScheduledFeedDTO{ID: 2, URL: "https://example.com/2", Interval: time.Minute},
+	)
+
+	s := newTestScheduler(lister, &mockRunner{}, Config{})
+	s.refresh()
+
+	lister.set(feedUC.ScheduledFeedDTO{ID: 2, URL: "https://example.com/2", Interval: time.Minute})
+	s.refresh()
+
+	status := s.Status()
+	if len(status.Feeds) != 1 || status.Feeds[0].FeedID != 2 {
+		t.Errorf("expected only feed 2 to stay scheduled, got %+v", status.Feeds)
+	}
+}
+
+func TestScheduler_StartAndShutdown(t *testing.T) {
+	lister := &mockLister{}
+	lister.set(
+		feedUC.ScheduledFeedDTO{ID: 1, URL: "https://example.com/1", Interval: time.Hour},
+		feedUC.ScheduledFeedDTO{ID: 2, URL: "https://example.com/2", Interval: time.Hour},
+		feedUC.ScheduledFeedDTO{ID: 3, URL: "https://example.com/3", Interval: time.Hour},
+	)
+	runner := &mockRunner{block: true}
+
+	s := newTestScheduler(lister, runner, Config{Workers: 2, Tick: 10 * time.Millisecond})
+	s.random = func() float64 { return 0 }
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- s.Start() }()
+
+	deadline := time.Now().Add(time.Second)
+	for s.Status().Running < 2 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected 2 running polls, status: %+v", s.Status())
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	// Пул ограничен двумя воркерами, третий источник ждет своей очереди.
+	time.Sleep(30 * time.Millisecond)
+	if calls := runner.calls.Load(); calls != 2 {
+		t.Errorf("expected 2 concurrent polls, got %d", calls)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+
+	if err := s.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() unexpected error: %v", err)
+	}
+
+	if err := <-errCh; err != nil {
+		t.Fatalf("Start() unexpected error: %v", err)
+	}
+}
+
+func TestScheduler_RunNowDeduplicates(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	lister := &mockLister{}
+	lister.set(feedUC.ScheduledFeedDTO{ID: 1, URL: "https://example.com/rss", Interval: 5 * time.Minute})
+	runner := &mockRunner{gate: make(chan struct{})}
+
+	s := newTestScheduler(lister, runner, Config{Workers: 1})
+	s.now = func() time.Time { return now }
+	s.refresh()
+
+	type result struct {
+		out    uc.ParseAndStoreOutputDTO
+		shared bool
+		err    error
+	}
+	results := make(chan result, 2)
+	for range 2 {
+		go func() {
+			out, shared, err := s.RunNow(context.Background(), 1, "https://example.com/rss")
+			results <- result{out: out, shared: shared, err: err}
+		}()
+	}
+
+	deadline := time.Now().Add(time.Second)
+	for s.Status().Running != 1 {
+		if time.Now().After(deadline) {
+			t.Fatalf("expected running poll, status: %+v", s.Status())
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// Плановый опрос того же источника присоединяется к ручному.
+	scheduled := make(chan struct{})
+	go func() {
+		s.run(s.entries[1])
+		close(scheduled)
+	}()
+
+	time.Sleep(20 * time.Millisecond)
+	close(runner.gate)
+	<-scheduled
+
+	var shared int
+	for range 2 {
+		r := <-results
+		if r.err != nil || r.out.Inserted != 1 {
+			t.Errorf("unexpected result: %+v", r)
+		}
+		if r.shared {
+			shared++
+		}
+	}
+
+	if calls := runner.calls.Load(); calls != 1 {
+		t.Errorf("expected 1 poll, got %d", calls)
+	}
+	if shared != 1 {
+		t.Errorf("expected 1 shared result, got %d", shared)
+	}
+
+	got := s.Status().Feeds[0]
+	if got.Running || !got.NextRun.Equal(now.Add(5*time.Minute)) {
+		t.Errorf("manual poll must reschedule feed, got %+v", got)
+	}
+}
+
+func TestScheduler_RunNowUnscheduledFeed(t *testing.T) {
+	runner := &mockRunner{err: errors.New("connection refused")}
+	s := newTestScheduler(&mockLister{}, runner, Config{})
+
+	_, shared, err := s.RunNow(context.Background(), 7, "https://example.com/disabled")
+	if err == nil || shared {
+		t.Fatalf("expected runner error, got err = %v, shared = %v", err, shared)
+	}
+	if len(s.Status().Feeds) != 0 {
+		t.Errorf("manual poll must not add feed to schedule, got %+v", s.Status().Feeds)
+	}
+
+	if err = s.Shutdown(context.Background()); err != nil {
+		t.Fatalf("Shutdown() unexpected error: %v", err)
+	}
+	if _, _, err = s.RunNow(context.Background(), 7, "https://example.com/disabled"); !errors.Is(err, ErrStopped) {
+		t.Errorf("expected ErrStopped after shutdown, got %v", err)
+	}
+}
+
+func TestScheduler_RunNowKeepsRequestID(t *testing.T) {
+	runner := &mockRunner{}
+	s := newTestScheduler(&mockLister{}, runner, Config{})
+
+	ctx := logger.WithRequestID(context.Background(), "req-1")
+	if _, _, err := s.RunNow(ctx, 7, "https://example.com/rss"); err != nil {
+		t.Fatalf("RunNow() unexpected error: %v", err)
+	}
+	if got := runner.requestID.Load(); got != "req-1" {
+		t.Errorf("expected poll with request id of the call, got %v", got)
+	}
+}
+
+func TestScheduler_RunAllNow(t *testing.T) {
+	now := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	lister := &mockLister{}
+	lister.set(
+		feedUC.ScheduledFeedDTO{ID: 1, URL: "https://example.com/1", Interval: time.Hour, LastFetchedAt: now},
+		feedUC.ScheduledFeedDTO{ID: 2, URL: "https://example.com/2", Interval: time.Hour, LastFetchedAt: now},
+	)
+
+	s := newTestScheduler(lister, &mockRunner{}, Config{})
+	s.now = func() time.Time { return now }
+	s.refresh()
+	s.entries[2].running = true
+
+	ids := s.RunAllNow()
+	if len(ids) != 1 || ids[0] != 1 {
+		t.Errorf("RunAllNow() = %v, want [1]", ids)
+	}
+	if !s.entries[1].nextRun.Equal(now) {
+		t.Errorf("nextRun = %v, want %v", s.entries[1].nextRun, now)
+	}
+	if !s.entries[2].nextRun.Equal(now.Add(time.Hour)) {
+		t.Errorf("running feed must keep schedule, got %v", s.entries[2].nextRun)
+	}
+
+	select {
+	case <-s.wake:
+	default:
+		t.Error("RunAllNow() must wake the scheduler loop")
+	}
+}
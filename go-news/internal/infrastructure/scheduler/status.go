@@ -0,0 +1,90 @@
+package scheduler
+
+import (
+	"sort"
+	"time"
+
+	feedUC "github.com/ee-crocush/go-news/go-news/internal/usecase/feed"
+)
+
+// entry - состояние расписания одного источника.
+type entry struct {
+	id           int32
+	url          string
+	interval     time.Duration
+	nextRun      time.Time
+	running      bool
+	failures     int
+	lastError    string
+	lastRun      time.Time
+	lastDuration time.Duration
+}
+
+func newEntry(f feedUC.ScheduledFeedDTO, nextRun time.Time) *entry {
+	return &entry{
+		id:       f.ID,
+		url:      f.URL,
+		interval: f.Interval,
+		nextRun:  nextRun,
+		lastRun:  f.LastFetchedAt,
+	}
+}
+
+// FeedStatus - состояние расписания источника.
+type FeedStatus struct {
+	FeedID       int32
+	URL          string
+	Interval     time.Duration
+	NextRun      time.Time
+	Running      bool
+	Failures     int
+	LastError    string
+	LastRun      time.Time
+	LastDuration time.Duration
+}
+
+// Status - состояние планировщика.
+type Status struct {
+	Workers int
+	Running int
+	Feeds   []FeedStatus
+}
+
+// Status возвращает снимок состояния планировщика, источники отсортированы по ID.
+func (s *Scheduler) Status() Status {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	status := Status{
+		Workers: s.cfg.Workers,
+		Feeds:   make([]FeedStatus, 0, len(s.entries)),
+	}
+
+	for _, e := range s.entries {
+		if e.running {
+			status.Running++
+		}
+
+		status.Feeds = append(
+			status.Feeds, FeedStatus{
+				FeedID:       e.id,
+				URL:          e.url,
+				Interval:     e.interval,
+				NextRun:      e.nextRun,
+				Running:      e.running,
+				Failures:     e.failures,
+				LastError:    e.lastError,
+				LastRun:      e.lastRun,
+				LastDuration: e.lastDuration,
+			},
+		)
+	}
+
+	sort.Slice(
+		status.Feeds, func(i, j int) bool {
+			return status.Feeds[i].FeedID < status.Feeds[j].FeedID
+		},
+	)
+
+	return status
+}
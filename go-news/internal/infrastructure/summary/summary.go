@@ -0,0 +1,268 @@
+// Package summary составляет краткое содержание новостей извлечением предложений.
+package summary
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	uc "github.com/ee-crocush/go-news/go-news/internal/usecase/post"
+)
+
+var _ uc.Summarizer = (*Summarizer)(nil)
+
+const (
+	// minSentenceWords - предложения с меньшим количеством значимых слов (подписи, «Читать далее» и т.п.)
+	// в краткое содержание не попадают, если в тексте есть другие.
+	minSentenceWords = 3
+	// leadWeight - вес позиции предложения: в новостях главное обычно в начале.
+	leadWeight = 0.3
+	// minWordLength - слова короче не учитываются при оценке.
+	minWordLength = 3
+	ellipsis      = "..."
+)
+
+// stopWords - частые слова, не несущие смысла новости.
+var stopWords = map[string]struct{}{
+	"это": {}, "что": {}, "как": {}, "так": {}, "для": {}, "или": {}, "его": {}, "она": {}, "они": {},
+	"был": {}, "была": {}, "были": {}, "быть": {}, "при": {}, "уже": {}, "все": {}, "еще": {}, "ещё": {},
+	"также": {}, "который": {}, "которые": {}, "которая": {}, "которого": {}, "этот": {}, "эти": {},
+	"the": {}, "and": {}, "for": {}, "with": {}, "that": {}, "this": {}, "was": {}, "are": {}, "from": {},
+	"has": {}, "have": {}, "will": {}, "its": {}, "but": {}, "not": {}, "you": {}, "can": {},
+}
+
+// abbreviations - сокращения, после точки в которых предложение не заканчивается.
+var abbreviations = map[string]struct{}{
+	"г": {}, "гг": {}, "т": {}, "д": {}, "др": {}, "пр": {}, "им": {}, "ул": {}, "см": {}, "тыс": {},
+	"млн": {}, "млрд": {}, "руб": {}, "mr": {}, "mrs": {}, "ms": {}, "dr": {}, "st": {}, "vs": {},
+	"inc": {}, "e.g": {}, "i.e": {}, "etc": {},
+}
+
+// Summarizer составляет краткое содержание: предложения оцениваются по частоте их слов в тексте
+// и по позиции, лучшие выбираются в пределах ограничения длины и выводятся в исходном порядке.
+type Summarizer struct {
+	maxLength int
+}
+
+// NewSummarizer создает Summarizer, краткое содержание не длиннее maxLength символов.
+func NewSummarizer(maxLength int) *Summarizer {
+	return &Summarizer{maxLength: maxLength}
+}
+
+// sentence - предложение текста с оценкой.
+type sentence struct {
+	text  string
+	words []string
+	index int
+	score float64
+}
+
+// Summarize возвращает краткое содержание текста. Текст не длиннее ограничения возвращается без изменений.
+// Если ни одно предложение не помещается целиком, первое обрезается по границе слова.
+func (s *Summarizer) Summarize(text string) string {
+	text = strings.TrimSpace(text)
+	if utf8.RuneCountInString(text) <= s.maxLength {
+		return text
+	}
+
+	sentences := splitSentences(text)
+	if len(sentences) == 0 {
+		return ""
+	}
+
+	scoreSentences(sentences)
+
+	ranked := make([]*sentence, 0, len(sentences))
+	for i := range sentences {
+		if len(sentences[i].words) >= minSentenceWords {
+			ranked = append(ranked, &sentences[i])
+		}
+	}
+	if len(ranked) == 0 {
+		for i := range sentences {
+			ranked = append(ranked, &sentences[i])
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool { return ranked[i].score > ranked[j].score })
+
+	var picked []*sentence
+	length := 0
+	for _, sent := range ranked {
+		n := utf8.RuneCountInString(sent.text)
+		if len(picked) > 0 {
+			n++ // пробел между предложениями
+		}
+		if length+n > s.maxLength {
+			continue
+		}
+		picked = append(picked, sent)
+		length += n
+	}
+
+	if len(picked) == 0 {
+		return truncate(ranked[0].text, s.maxLength)
+	}
+
+	sort.Slice(picked, func(i, j int) bool { return picked[i].index < picked[j].index })
+
+	parts := make([]string, 0, len(picked))
+	for _, sent := range picked {
+		parts = append(parts, sent.text)
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// scoreSentences оценивает предложения: средняя частота значимых слов предложения в тексте,
+// нормированная на частоту самого частого слова, плюс вес позиции.
+func scoreSentences(sentences []sentence) {
+	freq := make(map[string]int)
+	maxFreq := 0
+	for i := range sentences {
+		sentences[i].words = sentenceWords(sentences[i].text)
+		for _, w := range sentences[i].words {
+			freq[w]++
+			maxFreq = max(maxFreq, freq[w])
+		}
+	}
+
+	for i := range sentences {
+		sent := &sentences[i]
+		if len(sent.words) > 0 {
+			sum := 0
+			for _, w := range sent.words {
+				sum += freq[w]
+			}
+			sent.score = float64(sum) / float64(maxFreq*len(sent.words))
+		}
+		sent.score += leadWeight / float64(sent.index+1)
+	}
+}
+
+// sentenceWords возвращает основы значимых слов предложения.
+func sentenceWords(text string) []string {
+	fields := strings.FieldsFunc(
+		strings.ToLower(text), func(r rune) bool {
+			return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+		},
+	)
+
+	words := make([]string, 0, len(fields))
+	for _, f := range fields {
+		if utf8.RuneCountInString(f) < minWordLength {
+			continue
+		}
+		if _, ok := stopWords[f]; ok {
+			continue
+		}
+		words = append(words, stem(f))
+	}
+
+	return words
+}
+
+// stem отбрасывает окончание слова - грубое приближение стемминга, чтобы формы слова считались вместе.
+func stem(word string) string {
+	runes := []rune(word)
+	switch n := len(runes); {
+	case n < 4:
+		return word
+	case n < 6:
+		return string(runes[:n-1])
+	default:
+		return string(runes[:n-2])
+	}
+}
+
+// splitSentences разбивает текст на предложения. Граница - конец абзаца либо знак конца предложения,
+// за которым после пробела идет заглавная буква, цифра или открывающая кавычка/тире.
+// Точка после инициала или известного сокращения границей не считается.
+func splitSentences(text string) []sentence {
+	var sentences []sentence
+	add := func(s string) {
+		if s = strings.Join(strings.Fields(s), " "); s != "" {
+			sentences = append(sentences, sentence{text: s, index: len(sentences)})
+		}
+	}
+
+	for _, paragraph := range strings.Split(text, "\n") {
+		runes := []rune(paragraph)
+		start := 0
+		for i := 0; i < len(runes); i++ {
+			if !isTerminal(runes[i]) {
+				continue
+			}
+
+			end := i + 1
+			for end < len(runes) && (isTerminal(runes[end]) || isClosing(runes[end])) {
+				end++
+			}
+			if end == len(runes) || !unicode.IsSpace(runes[end]) {
+				i = end - 1
+				continue
+			}
+
+			next := end
+			for next < len(runes) && unicode.IsSpace(runes[next]) {
+				next++
+			}
+			abbreviation := runes[i] == '.' && isAbbreviation(runes[start:i])
+			if next == len(runes) || !startsSentence(runes[next]) || abbreviation {
+				i = end - 1
+				continue
+			}
+
+			add(string(runes[start:end]))
+			start, i = next, next-1
+		}
+		add(string(runes[start:]))
+	}
+
+	return sentences
+}
+
+func isTerminal(r rune) bool { return r == '.' || r == '!' || r == '?' || r == '…' }
+
+func isClosing(r rune) bool { return r == '"' || r == '»' || r == ')' || r == '\'' || r == '”' }
+
+func startsSentence(r rune) bool {
+	return unicode.IsUpper(r) || unicode.IsDigit(r) || r == '«' || r == '"' || r == '—' || r == '-' || r == '“'
+}
+
+// isAbbreviation сообщает, что текст перед точкой заканчивается инициалом или сокращением.
+func isAbbreviation(before []rune) bool {
+	i := len(before)
+	for i > 0 && (unicode.IsLetter(before[i-1]) || before[i-1] == '.') {
+		i--
+	}
+
+	word := strings.ToLower(string(before[i:]))
+	if utf8.RuneCountInString(word) == 1 {
+		return true
+	}
+	_, ok := abbreviations[word]
+
+	return ok
+}
+
+// truncate обрезает текст до maxLength символов по границе слова.
+func truncate(text string, maxLength int) string {
+	limit := maxLength - utf8.RuneCountInString(ellipsis)
+	runes := []rune(text)
+	if len(runes) <= maxLength {
+		return text
+	}
+
+	cut := limit
+	for cut > 0 && !unicode.IsSpace(runes[cut]) {
+		cut--
+	}
+	if cut == 0 {
+		cut = limit
+	}
+
+	return strings.TrimRightFunc(string(runes[:cut]), func(r rune) bool {
+		return unicode.IsSpace(r) || unicode.IsPunct(r)
+	}) + ellipsis
+}
@@ -0,0 +1,107 @@
+package summary
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+const article = "Банк России сохранил ключевую ставку на уровне 16% годовых. " +
+	"Совет директоров отметил, что инфляция замедляется, но ставка останется высокой до конца года. " +
+	"Заседание прошло в Москве. На встрече присутствовали журналисты. " +
+	"Аналитики ожидали, что ключевая ставка будет снижена, однако регулятор указал на риски инфляции. " +
+	"Следующее заседание по ставке запланировано на декабрь.\n\nЧитать далее"
+
+func TestSummarizer_Summarize(t *testing.T) {
+	s := NewSummarizer(200)
+
+	got := s.Summarize(article)
+
+	if n := utf8.RuneCountInString(got); n > 200 {
+		t.Fatalf("summary is %d runes, want <= 200: %q", n, got)
+	}
+	if !strings.HasPrefix(got, "Банк России сохранил ключевую ставку") {
+		t.Errorf("expected lead sentence first, got %q", got)
+	}
+	if !strings.HasSuffix(got, ".") {
+		t.Errorf("expected summary of whole sentences, got %q", got)
+	}
+	for _, unwanted := range []string{"Читать далее", "журналисты"} {
+		if strings.Contains(got, unwanted) {
+			t.Errorf("expected %q to be left out, got %q", unwanted, got)
+		}
+	}
+}
+
+func TestSummarizer_Summarize_ShortText(t *testing.T) {
+	text := "Короткая новость. Всего два предложения."
+	if got := NewSummarizer(300).Summarize("  " + text + "\n"); got != text {
+		t.Errorf("expected text unchanged, got %q", got)
+	}
+}
+
+func TestSummarizer_Summarize_LongSentence(t *testing.T) {
+	text := strings.Repeat("очень длинное предложение без точки ", 20)
+
+	got := NewSummarizer(100).Summarize(text)
+
+	if n := utf8.RuneCountInString(got); n > 100 {
+		t.Fatalf("summary is %d runes, want <= 100", n)
+	}
+	if !strings.HasSuffix(got, "...") || strings.HasSuffix(got, " ...") {
+		t.Errorf("expected word-boundary truncation with ellipsis, got %q", got)
+	}
+}
+
+func TestSplitSentences(t *testing.T) {
+	tests := []struct {
+		name string
+		text string
+		want []string
+	}{
+		{
+			name: "terminal punctuation",
+			text: "Первое предложение! Второе? Третье… «Четвертое» — пятое.",
+			want: []string{"Первое предложение!", "Второе?", "Третье…", "«Четвертое» — пятое."},
+		},
+		{
+			name: "initials and abbreviations",
+			text: "Книгу написал А. С. Пушкин в 1830 г. Москва ждала. Рост составил 5 млн. Рублей нет.",
+			want: []string{"Книгу написал А. С. Пушкин в 1830 г. Москва ждала.", "Рост составил 5 млн. Рублей нет."},
+		},
+		{
+			name: "lowercase after period and decimals",
+			text: "Версия 1.2 вышла, см. сайт. Цена выросла на 2.5%.",
+			want: []string{"Версия 1.2 вышла, см. сайт.", "Цена выросла на 2.5%."},
+		},
+		{
+			name: "paragraphs and quotes",
+			text: "Он сказал: «Готово.» Потом ушел\n\nНовый абзац",
+			want: []string{"Он сказал: «Готово.»", "Потом ушел", "Новый абзац"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				got := splitSentences(tt.text)
+				if len(got) != len(tt.want) {
+					t.Fatalf("got %d sentences %q, want %d", len(got), texts(got), len(tt.want))
+				}
+				for i := range got {
+					if got[i].text != tt.want[i] {
+						t.Errorf("sentence %d = %q, want %q", i, got[i].text, tt.want[i])
+					}
+				}
+			},
+		)
+	}
+}
+
+func texts(sentences []sentence) []string {
+	out := make([]string, 0, len(sentences))
+	for _, s := range sentences {
+		out = append(out, s.text)
+	}
+	return out
+}
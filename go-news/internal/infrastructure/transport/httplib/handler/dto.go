@@ -4,20 +4,111 @@ import uc "github.com/ee-crocush/go-news/go-news/internal/usecase/post"
 
 // PostDTO представляет пост в массиве постов.
 type PostDTO struct {
-	ID      int32  `json:"id"`
-	Title   string `json:"title"`
-	Content string `json:"content"`
-	Link    string `json:"link"`
-	PubTime string `json:"pub_time"`
+	ID      int32      `json:"id"`
+	Title   string     `json:"title"`
+	Content string     `json:"content"`
+	Link    string     `json:"link"`
+	PubTime string     `json:"pub_time"`
+	Source  *SourceDTO `json:"source,omitempty"`
+	// Image - главное изображение статьи, Body - полный текст (только для одной новости).
+	Image string `json:"image,omitempty"`
+	Body  string `json:"body,omitempty"`
+	// Media - медиа-вложения: файлы, видео, миниатюры и изображения из описания.
+	Media []MediaDTO `json:"media,omitempty"`
+	Tags  []string   `json:"tags,omitempty"`
+	// Categories - тематические рубрики новости.
+	Categories []string `json:"categories,omitempty"`
+	// CommentsCount - количество опубликованных комментариев.
+	CommentsCount int64 `json:"comments_count"`
+	// ClusterID - ID первой новости кластера почти одинаковых новостей,
+	// ClusterSize - количество новостей кластера в выдаче (только с collapse=true).
+	ClusterID   int32         `json:"cluster_id,omitempty"`
+	ClusterSize int64         `json:"cluster_size,omitempty"`
+	Highlights  *HighlightDTO `json:"highlights,omitempty"`
+}
+
+// HighlightDTO представляет фрагменты новости с подсветкой совпадений.
+type HighlightDTO struct {
+	Title   string `json:"title,omitempty"`
+	Content string `json:"content,omitempty"`
+}
+
+// MediaDTO представляет медиа-вложение новости.
+type MediaDTO struct {
+	URL       string `json:"url"`
+	Type      string `json:"type,omitempty"`
+	Size      int64  `json:"size,omitempty"`
+	Width     int    `json:"width,omitempty"`
+	Height    int    `json:"height,omitempty"`
+	Thumbnail bool   `json:"thumbnail,omitempty"`
+}
+
+// SourceDTO представляет источник новости.
+type SourceDTO struct {
+	FeedURL  string `json:"feed_url"`
+	Title    string `json:"title"`
+	SiteLink string `json:"site_link"`
 }
 
 func MapPostToPostDTO(post uc.PostDTO) PostDTO {
 	return PostDTO{
-		ID:      post.ID,
-		Title:   post.Title,
-		Content: post.Content,
-		Link:    post.Link,
-		PubTime: post.PubTime,
+		ID:            post.ID,
+		Title:         post.Title,
+		Content:       post.Content,
+		Link:          post.Link,
+		PubTime:       post.PubTime,
+		Source:        mapSourceToSourceDTO(post.Source),
+		Image:         post.Image,
+		Body:          post.Body,
+		Media:         mapMediaToMediaDTO(post.Media),
+		Tags:          post.Tags,
+		Categories:    post.Categories,
+		CommentsCount: post.CommentsCount,
+		ClusterID:     post.ClusterID,
+		ClusterSize:   post.ClusterSize,
+		Highlights:    mapHighlightToHighlightDTO(post.Highlights),
+	}
+}
+
+func mapHighlightToHighlightDTO(h *uc.HighlightDTO) *HighlightDTO {
+	if h == nil {
+		return nil
+	}
+
+	return &HighlightDTO{Title: h.Title, Content: h.Content}
+}
+
+func mapMediaToMediaDTO(media []uc.MediaDTO) []MediaDTO {
+	if len(media) == 0 {
+		return nil
+	}
+
+	out := make([]MediaDTO, 0, len(media))
+	for _, m := range media {
+		out = append(
+			out, MediaDTO{
+				URL:       m.URL,
+				Type:      m.Type,
+				Size:      m.Size,
+				Width:     m.Width,
+				Height:    m.Height,
+				Thumbnail: m.Thumbnail,
+			},
+		)
+	}
+
+	return out
+}
+
+func mapSourceToSourceDTO(source *uc.SourceDTO) *SourceDTO {
+	if source == nil {
+		return nil
+	}
+
+	return &SourceDTO{
+		FeedURL:  source.FeedURL,
+		Title:    source.Title,
+		SiteLink: source.SiteLink,
 	}
 }
 
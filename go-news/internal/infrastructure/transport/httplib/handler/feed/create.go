@@ -0,0 +1,42 @@
+package feed
+
+import (
+	uc "github.com/ee-crocush/go-news/go-news/internal/usecase/feed"
+	"github.com/ee-crocush/go-news/pkg/api"
+	"github.com/gofiber/fiber/v2"
+)
+
+// CreateRequest - входные данные из тела запроса для добавления источника.
+// PollInterval задается в минутах, 0 - интервал по умолчанию из конфигурации.
+type CreateRequest struct {
+	URL          string `json:"url"`
+	Title        string `json:"title"`
+	Enabled      *bool  `json:"enabled,omitempty"`
+	PollInterval int    `json:"poll_interval"`
+	// FetchFull - загружать полный текст статей по ссылкам записей ленты.
+	FetchFull bool `json:"fetch_full"`
+}
+
+// CreateHandler обрабатывает запрос на добавление источника (POST /feeds).
+func (h *Handler) CreateHandler(c *fiber.Ctx) error {
+	req, err := api.Req[CreateRequest](c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).
+			JSON(api.ErrWithCode("invalid-body", "Invalid request body"))
+	}
+
+	in := uc.CreateInputDTO{
+		URL:          req.URL,
+		Title:        req.Title,
+		Enabled:      req.Enabled,
+		PollInterval: req.PollInterval,
+		FetchFull:    req.FetchFull,
+	}
+
+	out, err := h.createUC.Execute(c.Context(), in)
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	return c.Status(fiber.StatusCreated).JSON(api.Resp(FeedResponse{Feed: MapFeedToFeedDTO(out)}))
+}
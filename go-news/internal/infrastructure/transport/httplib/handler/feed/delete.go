@@ -0,0 +1,20 @@
+package feed
+
+import (
+	uc "github.com/ee-crocush/go-news/go-news/internal/usecase/feed"
+	"github.com/gofiber/fiber/v2"
+)
+
+// DeleteHandler обрабатывает запрос на удаление источника (DELETE /feeds/<id>).
+func (h *Handler) DeleteHandler(c *fiber.Ctx) error {
+	id, ok := parseID(c)
+	if !ok {
+		return invalidID(c)
+	}
+
+	if err := h.deleteUC.Execute(c.Context(), uc.DeleteInputDTO{ID: id}); err != nil {
+		return writeError(c, err)
+	}
+
+	return c.SendStatus(fiber.StatusNoContent)
+}
@@ -0,0 +1,50 @@
+package feed
+
+import uc "github.com/ee-crocush/go-news/go-news/internal/usecase/feed"
+
+// FeedDTO представляет источник новостей в ответе.
+type FeedDTO struct {
+	ID            int32    `json:"id"`
+	URL           string   `json:"url"`
+	Title         string   `json:"title"`
+	Enabled       bool     `json:"enabled"`
+	PollInterval  int      `json:"poll_interval"`
+	FetchFull     bool     `json:"fetch_full"`
+	LastStatus    string   `json:"last_status,omitempty"`
+	LastError     string   `json:"last_error,omitempty"`
+	LastWarnings  []string `json:"last_warnings,omitempty"`
+	LastFetchedAt string   `json:"last_fetched_at,omitempty"`
+	ItemCount     int64    `json:"item_count"`
+}
+
+// MapFeedToFeedDTO маппинг DTO use case в DTO ответа.
+func MapFeedToFeedDTO(f uc.FeedDTO) FeedDTO {
+	return FeedDTO{
+		ID:            f.ID,
+		URL:           f.URL,
+		Title:         f.Title,
+		Enabled:       f.Enabled,
+		PollInterval:  f.PollInterval,
+		FetchFull:     f.FetchFull,
+		LastStatus:    f.LastStatus,
+		LastError:     f.LastError,
+		LastWarnings:  f.LastWarnings,
+		LastFetchedAt: f.LastFetchedAt,
+		ItemCount:     f.ItemCount,
+	}
+}
+
+// MapFeedsToFeedsDTO маппинг слайса DTO use case в DTO ответа.
+func MapFeedsToFeedsDTO(feeds []uc.FeedDTO) []FeedDTO {
+	result := make([]FeedDTO, 0, len(feeds))
+	for _, f := range feeds {
+		result = append(result, MapFeedToFeedDTO(f))
+	}
+
+	return result
+}
+
+// FeedResponse представляет ответ с одним источником.
+type FeedResponse struct {
+	Feed FeedDTO `json:"feed"`
+}
@@ -0,0 +1,21 @@
+package feed
+
+import (
+	"github.com/ee-crocush/go-news/pkg/api"
+	"github.com/gofiber/fiber/v2"
+)
+
+// FindAllResponse представляет ответ на запрос получения всех источников.
+type FindAllResponse struct {
+	Feeds []FeedDTO `json:"feeds"`
+}
+
+// FindAllHandler обрабатывает запрос (GET /feeds).
+func (h *Handler) FindAllHandler(c *fiber.Ctx) error {
+	out, err := h.findAllUC.Execute(c.Context())
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(api.Resp(FindAllResponse{Feeds: MapFeedsToFeedsDTO(out)}))
+}
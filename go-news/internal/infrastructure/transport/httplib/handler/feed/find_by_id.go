@@ -0,0 +1,22 @@
+package feed
+
+import (
+	uc "github.com/ee-crocush/go-news/go-news/internal/usecase/feed"
+	"github.com/ee-crocush/go-news/pkg/api"
+	"github.com/gofiber/fiber/v2"
+)
+
+// FindByIDHandler обрабатывает запрос (GET /feeds/<id>).
+func (h *Handler) FindByIDHandler(c *fiber.Ctx) error {
+	id, ok := parseID(c)
+	if !ok {
+		return invalidID(c)
+	}
+
+	out, err := h.findByIDUC.Execute(c.Context(), uc.FindByIDInputDTO{ID: id})
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(api.Resp(FeedResponse{Feed: MapFeedToFeedDTO(out)}))
+}
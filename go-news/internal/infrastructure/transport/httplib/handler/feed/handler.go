@@ -0,0 +1,120 @@
+// Package feed содержит обработчики HTTP запросов для управления источниками новостей.
+package feed
+
+import (
+	"context"
+	"errors"
+	"strconv"
+
+	dom "github.com/ee-crocush/go-news/go-news/internal/domain/feed"
+	uc "github.com/ee-crocush/go-news/go-news/internal/usecase/feed"
+	"github.com/ee-crocush/go-news/pkg/api"
+	"github.com/gofiber/fiber/v2"
+)
+
+// CreateExecutor интерфейс для добавления источника.
+type CreateExecutor interface {
+	Execute(ctx context.Context, in uc.CreateInputDTO) (uc.FeedDTO, error)
+}
+
+// UpdateExecutor интерфейс для изменения источника.
+type UpdateExecutor interface {
+	Execute(ctx context.Context, in uc.UpdateInputDTO) (uc.FeedDTO, error)
+}
+
+// DeleteExecutor интерфейс для удаления источника.
+type DeleteExecutor interface {
+	Execute(ctx context.Context, in uc.DeleteInputDTO) error
+}
+
+// FindByIDExecutor интерфейс для поиска источника по ID.
+type FindByIDExecutor interface {
+	Execute(ctx context.Context, in uc.FindByIDInputDTO) (uc.FeedDTO, error)
+}
+
+// FindAllExecutor интерфейс для поиска всех источников.
+type FindAllExecutor interface {
+	Execute(ctx context.Context) ([]uc.FeedDTO, error)
+}
+
+// FindRunsExecutor интерфейс для получения журнала опросов источника.
+type FindRunsExecutor interface {
+	Execute(ctx context.Context, in uc.FindRunsInputDTO) ([]uc.RunDTO, error)
+}
+
+// FindHealthExecutor интерфейс для получения сводки здоровья источников.
+type FindHealthExecutor interface {
+	Execute(ctx context.Context, in uc.FindHealthInputDTO) (uc.FindHealthOutputDTO, error)
+}
+
+// Handler представляет HTTP-handler для работы с источниками новостей.
+type Handler struct {
+	createUC     CreateExecutor
+	updateUC     UpdateExecutor
+	deleteUC     DeleteExecutor
+	findByIDUC   FindByIDExecutor
+	findAllUC    FindAllExecutor
+	findRunsUC   FindRunsExecutor
+	findHealthUC FindHealthExecutor
+	schedule     ScheduleStatusProvider
+	refresher    Refresher
+}
+
+// NewHandler создает новый экземпляр HTTP-handler.
+func NewHandler(
+	createUC CreateExecutor,
+	updateUC UpdateExecutor,
+	deleteUC DeleteExecutor,
+	findByIDUC FindByIDExecutor,
+	findAllUC FindAllExecutor,
+	findRunsUC FindRunsExecutor,
+	findHealthUC FindHealthExecutor,
+	schedule ScheduleStatusProvider,
+	refresher Refresher,
+) *Handler {
+	return &Handler{
+		createUC:     createUC,
+		updateUC:     updateUC,
+		deleteUC:     deleteUC,
+		findByIDUC:   findByIDUC,
+		findAllUC:    findAllUC,
+		findRunsUC:   findRunsUC,
+		findHealthUC: findHealthUC,
+		schedule:     schedule,
+		refresher:    refresher,
+	}
+}
+
+// parseID извлекает ID источника из URL.
+func parseID(c *fiber.Ctx) (int32, bool) {
+	id, err := strconv.Atoi(c.Params("id"))
+	if err != nil || id <= 0 {
+		return 0, false
+	}
+
+	return int32(id), true
+}
+
+// invalidID возвращает ответ о невалидном ID источника.
+func invalidID(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusBadRequest).
+		JSON(api.ErrWithCode("invalid-id", "feed ID must be positive integer"))
+}
+
+// writeError сопоставляет доменные ошибки с HTTP статусами.
+func writeError(c *fiber.Ctx, err error) error {
+	switch {
+	case errors.Is(err, dom.ErrFeedNotFound):
+		return c.Status(fiber.StatusNotFound).JSON(api.ErrWithCode("not-found", dom.ErrFeedNotFound.Error()))
+	case errors.Is(err, dom.ErrFeedAlreadyExists):
+		return c.Status(fiber.StatusConflict).
+			JSON(api.ErrWithCode("already-exists", dom.ErrFeedAlreadyExists.Error()))
+	case errors.Is(err, dom.ErrEmptyFeedURL),
+		errors.Is(err, dom.ErrInvalidFeedURL),
+		errors.Is(err, dom.ErrInvalidFeedID),
+		errors.Is(err, dom.ErrInvalidPollInterval):
+		return c.Status(fiber.StatusBadRequest).JSON(api.ErrWithCode("validation-error", api.Err(err).Message))
+	default:
+		return c.Status(fiber.StatusInternalServerError).JSON(api.Err(err))
+	}
+}
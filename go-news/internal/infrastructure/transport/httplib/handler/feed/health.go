@@ -0,0 +1,75 @@
+package feed
+
+import (
+	"strconv"
+
+	uc "github.com/ee-crocush/go-news/go-news/internal/usecase/feed"
+	"github.com/ee-crocush/go-news/pkg/api"
+	"github.com/gofiber/fiber/v2"
+)
+
+// FeedHealthDTO представляет состояние источника в сводке здоровья.
+type FeedHealthDTO struct {
+	ID            int32  `json:"id"`
+	URL           string `json:"url"`
+	Title         string `json:"title"`
+	Health        string `json:"health"`
+	LastStatus    string `json:"last_status,omitempty"`
+	LastError     string `json:"last_error,omitempty"`
+	LastFetchedAt string `json:"last_fetched_at,omitempty"`
+	LastSuccessAt string `json:"last_success_at,omitempty"`
+	Runs          int64  `json:"runs"`
+	Failed        int64  `json:"failed"`
+}
+
+// HealthResponse представляет ответ на запрос сводки здоровья источников.
+type HealthResponse struct {
+	Hours    int             `json:"hours"`
+	Healthy  int             `json:"healthy"`
+	Degraded int             `json:"degraded"`
+	Failing  int             `json:"failing"`
+	Feeds    []FeedHealthDTO `json:"feeds"`
+}
+
+// HealthHandler обрабатывает запрос сводки здоровья источников (GET /feeds/health).
+// Параметр hours задает период журнала опросов (по умолчанию 24, максимум 720).
+func (h *Handler) HealthHandler(c *fiber.Ctx) error {
+	hours, err := strconv.Atoi(c.Query("hours", "0"))
+	if err != nil || hours < 0 {
+		return c.Status(fiber.StatusBadRequest).
+			JSON(api.ErrWithCode("invalid-hours", "hours must be non-negative integer"))
+	}
+
+	out, err := h.findHealthUC.Execute(c.Context(), uc.FindHealthInputDTO{Hours: hours})
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	feeds := make([]FeedHealthDTO, 0, len(out.Feeds))
+	for _, f := range out.Feeds {
+		feeds = append(
+			feeds, FeedHealthDTO{
+				ID:            f.ID,
+				URL:           f.URL,
+				Title:         f.Title,
+				Health:        f.Health,
+				LastStatus:    f.LastStatus,
+				LastError:     f.LastError,
+				LastFetchedAt: f.LastFetchedAt,
+				LastSuccessAt: f.LastSuccessAt,
+				Runs:          f.Runs,
+				Failed:        f.Failed,
+			},
+		)
+	}
+
+	resp := HealthResponse{
+		Hours:    out.Hours,
+		Healthy:  out.Healthy,
+		Degraded: out.Degraded,
+		Failing:  out.Failing,
+		Feeds:    feeds,
+	}
+
+	return c.Status(fiber.StatusOK).JSON(api.Resp(resp))
+}
@@ -0,0 +1,84 @@
+package feed
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ee-crocush/go-news/go-news/internal/infrastructure/scheduler"
+	uc "github.com/ee-crocush/go-news/go-news/internal/usecase/feed"
+	postUC "github.com/ee-crocush/go-news/go-news/internal/usecase/post"
+	"github.com/ee-crocush/go-news/pkg/api"
+	"github.com/gofiber/fiber/v2"
+)
+
+// Refresher интерфейс немедленного опроса источников вне расписания.
+type Refresher interface {
+	RunNow(ctx context.Context, id int32, url string) (out postUC.ParseAndStoreOutputDTO, shared bool, err error)
+	RunAllNow() []int32
+}
+
+// RefreshResultDTO представляет результат ручного опроса источника.
+type RefreshResultDTO struct {
+	Seen           int      `json:"seen"`
+	Inserted       int      `json:"inserted"`
+	Skipped        int      `json:"skipped"`
+	NotModified    bool     `json:"not_modified"`
+	ArticlesFailed int      `json:"articles_failed"`
+	Warnings       []string `json:"warnings,omitempty"`
+}
+
+// RefreshResponse представляет ответ на запрос ручного опроса источника.
+// Shared выставляется, если источник уже опрашивался и возвращен результат этого опроса.
+type RefreshResponse struct {
+	FeedID int32            `json:"feed_id"`
+	Shared bool             `json:"shared"`
+	Result RefreshResultDTO `json:"result"`
+}
+
+// RefreshAllResponse представляет ответ на запрос опроса всех источников.
+type RefreshAllResponse struct {
+	Queued []int32 `json:"queued"`
+}
+
+// RefreshHandler обрабатывает запрос немедленного опроса источника (POST /feeds/<id>/refresh).
+// Опрос выполняется синхронно, в ответе - его результат. Выключенный источник тоже опрашивается.
+func (h *Handler) RefreshHandler(c *fiber.Ctx) error {
+	id, ok := parseID(c)
+	if !ok {
+		return invalidID(c)
+	}
+
+	f, err := h.findByIDUC.Execute(c.Context(), uc.FindByIDInputDTO{ID: id})
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	out, shared, err := h.refresher.RunNow(c.Context(), f.ID, f.URL)
+	if err != nil {
+		if errors.Is(err, scheduler.ErrStopped) {
+			return c.Status(fiber.StatusServiceUnavailable).JSON(api.ErrWithCode("stopped", err.Error()))
+		}
+		return c.Status(fiber.StatusBadGateway).JSON(api.ErrWithCode("refresh-failed", err.Error()))
+	}
+
+	resp := RefreshResponse{
+		FeedID: f.ID,
+		Shared: shared,
+		Result: RefreshResultDTO{
+			Seen:           out.Seen,
+			Inserted:       out.Inserted,
+			Skipped:        out.Skipped,
+			NotModified:    out.NotModified,
+			ArticlesFailed: out.ArticlesFailed,
+			Warnings:       out.Warnings,
+		},
+	}
+
+	return c.Status(fiber.StatusOK).JSON(api.Resp(resp))
+}
+
+// RefreshAllHandler обрабатывает запрос немедленного опроса всех включенных источников (POST /feeds/refresh).
+// Опросы ставятся в очередь планировщика, ответ возвращается сразу со списком запланированных источников.
+func (h *Handler) RefreshAllHandler(c *fiber.Ctx) error {
+	return c.Status(fiber.StatusAccepted).JSON(api.Resp(RefreshAllResponse{Queued: h.refresher.RunAllNow()}))
+}
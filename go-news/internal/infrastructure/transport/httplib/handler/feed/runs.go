@@ -0,0 +1,66 @@
+package feed
+
+import (
+	"strconv"
+
+	uc "github.com/ee-crocush/go-news/go-news/internal/usecase/feed"
+	"github.com/ee-crocush/go-news/pkg/api"
+	"github.com/gofiber/fiber/v2"
+)
+
+// RunDTO представляет запись журнала опросов источника в ответе.
+type RunDTO struct {
+	StartedAt  string `json:"started_at"`
+	FinishedAt string `json:"finished_at"`
+	DurationMs int64  `json:"duration_ms"`
+	Status     string `json:"status"`
+	HTTPStatus int    `json:"http_status,omitempty"`
+	Seen       int    `json:"seen"`
+	Inserted   int    `json:"inserted"`
+	Skipped    int    `json:"skipped"`
+	Error      string `json:"error,omitempty"`
+}
+
+// FindRunsResponse представляет ответ на запрос журнала опросов источника.
+type FindRunsResponse struct {
+	Runs []RunDTO `json:"runs"`
+}
+
+// FindRunsHandler обрабатывает запрос (GET /feeds/<id>/runs).
+// Параметр limit ограничивает количество записей (по умолчанию 20, максимум 100).
+func (h *Handler) FindRunsHandler(c *fiber.Ctx) error {
+	id, ok := parseID(c)
+	if !ok {
+		return invalidID(c)
+	}
+
+	limit, err := strconv.Atoi(c.Query("limit", "0"))
+	if err != nil || limit < 0 {
+		return c.Status(fiber.StatusBadRequest).
+			JSON(api.ErrWithCode("invalid-limit", "limit must be non-negative integer"))
+	}
+
+	out, err := h.findRunsUC.Execute(c.Context(), uc.FindRunsInputDTO{ID: id, Limit: limit})
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	runs := make([]RunDTO, 0, len(out))
+	for _, r := range out {
+		runs = append(
+			runs, RunDTO{
+				StartedAt:  r.StartedAt,
+				FinishedAt: r.FinishedAt,
+				DurationMs: r.DurationMs,
+				Status:     r.Status,
+				HTTPStatus: r.HTTPStatus,
+				Seen:       r.Seen,
+				Inserted:   r.Inserted,
+				Skipped:    r.Skipped,
+				Error:      r.Error,
+			},
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(api.Resp(FindRunsResponse{Runs: runs}))
+}
@@ -0,0 +1,73 @@
+package feed
+
+import (
+	"time"
+
+	"github.com/ee-crocush/go-news/go-news/internal/infrastructure/scheduler"
+	"github.com/ee-crocush/go-news/pkg/api"
+	"github.com/gofiber/fiber/v2"
+)
+
+// ScheduleStatusProvider интерфейс получения состояния планировщика опроса.
+type ScheduleStatusProvider interface {
+	Status() scheduler.Status
+}
+
+// ScheduleFeedDTO представляет состояние расписания источника.
+type ScheduleFeedDTO struct {
+	FeedID         int32  `json:"feed_id"`
+	URL            string `json:"url"`
+	IntervalSec    int64  `json:"interval_sec"`
+	NextRunAt      string `json:"next_run_at,omitempty"`
+	Running        bool   `json:"running"`
+	Failures       int    `json:"failures"`
+	LastError      string `json:"last_error,omitempty"`
+	LastRunAt      string `json:"last_run_at,omitempty"`
+	LastDurationMs int64  `json:"last_duration_ms"`
+}
+
+// ScheduleResponse представляет ответ на запрос состояния планировщика.
+type ScheduleResponse struct {
+	Workers int               `json:"workers"`
+	Running int               `json:"running"`
+	Feeds   []ScheduleFeedDTO `json:"feeds"`
+}
+
+// ScheduleHandler обрабатывает запрос состояния планировщика опроса (GET /feeds/schedule).
+func (h *Handler) ScheduleHandler(c *fiber.Ctx) error {
+	status := h.schedule.Status()
+
+	feeds := make([]ScheduleFeedDTO, 0, len(status.Feeds))
+	for _, f := range status.Feeds {
+		feeds = append(
+			feeds, ScheduleFeedDTO{
+				FeedID:         f.FeedID,
+				URL:            f.URL,
+				IntervalSec:    int64(f.Interval / time.Second),
+				NextRunAt:      formatTime(f.NextRun),
+				Running:        f.Running,
+				Failures:       f.Failures,
+				LastError:      f.LastError,
+				LastRunAt:      formatTime(f.LastRun),
+				LastDurationMs: f.LastDuration.Milliseconds(),
+			},
+		)
+	}
+
+	resp := ScheduleResponse{
+		Workers: status.Workers,
+		Running: status.Running,
+		Feeds:   feeds,
+	}
+
+	return c.Status(fiber.StatusOK).JSON(api.Resp(resp))
+}
+
+// formatTime форматирует время в RFC3339 (UTC), для нулевого времени возвращает пустую строку.
+func formatTime(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+
+	return t.UTC().Format(time.RFC3339)
+}
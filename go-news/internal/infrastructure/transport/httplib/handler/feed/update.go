@@ -0,0 +1,47 @@
+package feed
+
+import (
+	uc "github.com/ee-crocush/go-news/go-news/internal/usecase/feed"
+	"github.com/ee-crocush/go-news/pkg/api"
+	"github.com/gofiber/fiber/v2"
+)
+
+// UpdateRequest - входные данные из тела запроса для изменения источника.
+type UpdateRequest struct {
+	URL          string `json:"url"`
+	Title        string `json:"title"`
+	Enabled      *bool  `json:"enabled,omitempty"`
+	PollInterval int    `json:"poll_interval"`
+	// FetchFull - загружать полный текст статей по ссылкам записей ленты.
+	FetchFull bool `json:"fetch_full"`
+}
+
+// UpdateHandler обрабатывает запрос на изменение источника (PUT /feeds/<id>).
+func (h *Handler) UpdateHandler(c *fiber.Ctx) error {
+	id, ok := parseID(c)
+	if !ok {
+		return invalidID(c)
+	}
+
+	req, err := api.Req[UpdateRequest](c)
+	if err != nil {
+		return c.Status(fiber.StatusBadRequest).
+			JSON(api.ErrWithCode("invalid-body", "Invalid request body"))
+	}
+
+	in := uc.UpdateInputDTO{
+		ID:           id,
+		URL:          req.URL,
+		Title:        req.Title,
+		Enabled:      req.Enabled,
+		PollInterval: req.PollInterval,
+		FetchFull:    req.FetchFull,
+	}
+
+	out, err := h.updateUC.Execute(c.Context(), in)
+	if err != nil {
+		return writeError(c, err)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(api.Resp(FeedResponse{Feed: MapFeedToFeedDTO(out)}))
+}
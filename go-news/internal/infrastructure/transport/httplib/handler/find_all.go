@@ -1,21 +1,37 @@
 package handler
 
 import (
+	"errors"
+	"strconv"
+
+	dom "github.com/ee-crocush/go-news/go-news/internal/domain/post"
 	uc "github.com/ee-crocush/go-news/go-news/internal/usecase/post"
 	"github.com/ee-crocush/go-news/pkg/api"
 	"github.com/gofiber/fiber/v2"
-	"strconv"
 )
 
 // FindAllResponse представляет ответ на запрос получения всех постов.
 type FindAllResponse struct {
-	News  []PostDTO `json:"news"`
-	Total int32     `json:"total"`
+	News []PostDTO `json:"news"`
+	// Total возвращается только при постраничной пагинации (без cursor).
+	Total      *int32 `json:"total,omitempty"`
+	NextCursor string `json:"next_cursor,omitempty"`
+	PrevCursor string `json:"prev_cursor,omitempty"`
 }
 
 // FindAllHandler обрабатывает запрос (GET /news).
 func (h *Handler) FindAllHandler(c *fiber.Ctx) error {
 	search := c.Query("search", "")
+	source := c.Query("source", "")
+	sort := c.Query("sort", "")
+	highlight := c.QueryBool("highlight", false)
+	collapse := c.QueryBool("collapse", false)
+	cursor := c.Query("cursor", "")
+	from := c.Query("from", "")
+	to := c.Query("to", "")
+	hasComments := c.Query("has_comments", "")
+	tag := c.Query("tag", "")
+	category := c.Query("category", "")
 	pageStr := c.Query("page", "1")
 	limitStr := c.Query("limit", "10")
 
@@ -30,21 +46,56 @@ func (h *Handler) FindAllHandler(c *fiber.Ctx) error {
 	}
 
 	in := uc.FindAllInputDTO{
-		Search: search,
-		Limit:  limit,
-		Page:   page,
+		Search:      search,
+		Source:      source,
+		From:        from,
+		To:          to,
+		HasComments: hasComments,
+		Tag:         tag,
+		Category:    category,
+		Sort:        sort,
+		Highlight:   highlight,
+		Collapse:    collapse,
+		Cursor:      cursor,
+		Limit:       limit,
+		Page:        page,
 	}
-	out, total, err := h.findAllUC.Execute(c.Context(), in)
+	out, err := h.findAllUC.Execute(c.Context(), in)
 
 	if err != nil {
+		if errors.Is(err, dom.ErrInvalidPostSort) {
+			return c.Status(fiber.StatusBadRequest).
+				JSON(api.ErrWithCode("invalid-sort", "sort must be one of: relevance, date"))
+		}
+		if errors.Is(err, dom.ErrInvalidPubTimeRange) {
+			return c.Status(fiber.StatusBadRequest).
+				JSON(api.ErrWithCode("invalid-filter", "from/to must be RFC3339 or YYYY-MM-DD, from not after to"))
+		}
+		if errors.Is(err, dom.ErrInvalidCommentsFilter) {
+			return c.Status(fiber.StatusBadRequest).
+				JSON(api.ErrWithCode("invalid-filter", "has_comments must be true or false"))
+		}
+		if errors.Is(err, dom.ErrInvalidPostTag) {
+			return c.Status(fiber.StatusBadRequest).JSON(api.ErrWithCode("invalid-filter", "tag is invalid"))
+		}
+		if errors.Is(err, dom.ErrInvalidPostCategory) {
+			return c.Status(fiber.StatusBadRequest).JSON(api.ErrWithCode("invalid-filter", "category is invalid"))
+		}
+		if errors.Is(err, dom.ErrInvalidCursor) {
+			return c.Status(fiber.StatusBadRequest).JSON(api.ErrWithCode("invalid-cursor", "cursor is invalid"))
+		}
+		if errors.Is(err, dom.ErrCursorSort) {
+			return c.Status(fiber.StatusBadRequest).
+				JSON(api.ErrWithCode("invalid-cursor", "cursor pagination supports date sort only"))
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(api.Err(err))
 	}
 
-	news := MapNewsToNewsDTO(out)
-
 	resp := FindAllResponse{
-		News:  news,
-		Total: total,
+		News:       MapNewsToNewsDTO(out.Posts),
+		Total:      out.Total,
+		NextCursor: out.NextCursor,
+		PrevCursor: out.PrevCursor,
 	}
 
 	return c.Status(fiber.StatusOK).JSON(api.Resp(resp))
@@ -1,6 +1,8 @@
 package handler
 
 import (
+	"errors"
+	dom "github.com/ee-crocush/go-news/go-news/internal/domain/post"
 	uc "github.com/ee-crocush/go-news/go-news/internal/usecase/post"
 	"github.com/ee-crocush/go-news/pkg/api"
 	"github.com/gofiber/fiber/v2"
@@ -18,6 +20,7 @@ type FindByIdRequestResponse struct {
 }
 
 // FindByIDHandler обрабатывает запрос (GET /news/<id>).
+// Параметр format задает формат содержания: text (по умолчанию) или html.
 func (h *Handler) FindByIDHandler(c *fiber.Ctx) error {
 	idParam := c.Params("id")
 	if idParam == "" {
@@ -31,10 +34,14 @@ func (h *Handler) FindByIDHandler(c *fiber.Ctx) error {
 			JSON(api.ErrWithCode("invalid-id", "post ID must be positive integer"))
 	}
 
-	in := uc.FindByIDInputDTO{ID: int32(id)}
+	in := uc.FindByIDInputDTO{ID: int32(id), Format: c.Query("format")}
 	out, err := h.findByIDUC.Execute(c.Context(), in)
 
 	if err != nil {
+		if errors.Is(err, dom.ErrInvalidContentFormat) {
+			return c.Status(fiber.StatusBadRequest).
+				JSON(api.ErrWithCode("invalid-format", "format must be text or html"))
+		}
 		return c.Status(fiber.StatusInternalServerError).JSON(api.Err(err))
 	}
 
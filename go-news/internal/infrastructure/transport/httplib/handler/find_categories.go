@@ -0,0 +1,32 @@
+package handler
+
+import (
+	"github.com/ee-crocush/go-news/pkg/api"
+	"github.com/gofiber/fiber/v2"
+)
+
+// FindCategoriesResponse представляет ответ на запрос получения рубрик.
+type FindCategoriesResponse struct {
+	Categories []CategoryDTO `json:"categories"`
+}
+
+// CategoryDTO представляет рубрику и количество новостей в ней.
+type CategoryDTO struct {
+	Category string `json:"category"`
+	Count    int64  `json:"count"`
+}
+
+// FindCategoriesHandler обрабатывает запрос (GET /categories).
+func (h *Handler) FindCategoriesHandler(c *fiber.Ctx) error {
+	out, err := h.findCatsUC.Execute(c.Context())
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(api.Err(err))
+	}
+
+	categories := make([]CategoryDTO, 0, len(out))
+	for _, cat := range out {
+		categories = append(categories, CategoryDTO{Category: cat.Category, Count: cat.Count})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(api.Resp(FindCategoriesResponse{Categories: categories}))
+}
@@ -0,0 +1,41 @@
+package handler
+
+import (
+	"fmt"
+	uc "github.com/ee-crocush/go-news/go-news/internal/usecase/post"
+	"github.com/ee-crocush/go-news/pkg/api"
+	"github.com/gofiber/fiber/v2"
+	"strconv"
+)
+
+// FindTagsResponse представляет ответ на запрос получения тегов.
+type FindTagsResponse struct {
+	Tags []TagDTO `json:"tags"`
+}
+
+// TagDTO представляет тег и количество новостей с ним.
+type TagDTO struct {
+	Tag   string `json:"tag"`
+	Count int64  `json:"count"`
+}
+
+// FindTagsHandler обрабатывает запрос (GET /tags).
+// Параметр limit ограничивает количество тегов (по умолчанию 50, максимум 500).
+func (h *Handler) FindTagsHandler(c *fiber.Ctx) error {
+	limit, err := strconv.Atoi(c.Query("limit", "0"))
+	if err != nil || limit < 0 {
+		return c.Status(fiber.StatusBadRequest).JSON(api.Err(fmt.Errorf("invalid limit parameter")))
+	}
+
+	out, err := h.findTagsUC.Execute(c.Context(), uc.FindTagsInputDTO{Limit: limit})
+	if err != nil {
+		return c.Status(fiber.StatusInternalServerError).JSON(api.Err(err))
+	}
+
+	tags := make([]TagDTO, 0, len(out))
+	for _, t := range out {
+		tags = append(tags, TagDTO{Tag: t.Tag, Count: t.Count})
+	}
+
+	return c.Status(fiber.StatusOK).JSON(api.Resp(FindTagsResponse{Tags: tags}))
+}
@@ -23,7 +23,22 @@ type FindLatestPostExecutor interface {
 
 // FindAllPostExecutor интерфейс для поиска всех новостей.
 type FindAllPostExecutor interface {
-	Execute(ctx context.Context, in uc.FindAllInputDTO) ([]uc.PostDTO, int32, error)
+	Execute(ctx context.Context, in uc.FindAllInputDTO) (uc.FindAllOutputDTO, error)
+}
+
+// FindTagsExecutor интерфейс для получения самых частых тегов.
+type FindTagsExecutor interface {
+	Execute(ctx context.Context, in uc.FindTagsInputDTO) ([]uc.TagDTO, error)
+}
+
+// FindCategoriesExecutor интерфейс для получения рубрик.
+type FindCategoriesExecutor interface {
+	Execute(ctx context.Context) ([]uc.CategoryDTO, error)
+}
+
+// TrainCategoriesExecutor интерфейс для ручной разметки рубрик и обучения рубрикатора.
+type TrainCategoriesExecutor interface {
+	Execute(ctx context.Context, in uc.TrainCategoriesInputDTO) (uc.TrainCategoriesOutputDTO, error)
 }
 
 // Handler представляет HTTP-handler для работы с новостями.
@@ -32,6 +47,9 @@ type Handler struct {
 	findLastUC   FindLastPostExecutor
 	findLatestUC FindLatestPostExecutor
 	findAllUC    FindAllPostExecutor
+	findTagsUC   FindTagsExecutor
+	findCatsUC   FindCategoriesExecutor
+	trainCatsUC  TrainCategoriesExecutor
 }
 
 // NewHandler создает новый экземпляр HTTP-handler.
@@ -40,11 +58,17 @@ func NewHandler(
 	findLastUC FindLastPostExecutor,
 	findLatestUC FindLatestPostExecutor,
 	findAllUC FindAllPostExecutor,
+	findTagsUC FindTagsExecutor,
+	findCatsUC FindCategoriesExecutor,
+	trainCatsUC TrainCategoriesExecutor,
 ) *Handler {
 	return &Handler{
 		findByIDUC:   findByIDUC,
 		findLastUC:   findLastUC,
 		findLatestUC: findLatestUC,
 		findAllUC:    findAllUC,
+		findTagsUC:   findTagsUC,
+		findCatsUC:   findCatsUC,
+		trainCatsUC:  trainCatsUC,
 	}
 }
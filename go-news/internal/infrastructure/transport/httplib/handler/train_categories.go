@@ -0,0 +1,81 @@
+package handler
+
+import (
+	"errors"
+	dom "github.com/ee-crocush/go-news/go-news/internal/domain/post"
+	uc "github.com/ee-crocush/go-news/go-news/internal/usecase/post"
+	"github.com/ee-crocush/go-news/pkg/api"
+	"github.com/gofiber/fiber/v2"
+)
+
+// TrainCategoriesRequest - входные данные из тела запроса для обучения рубрикатора.
+// Без разметок модель переобучается на уже размеченных новостях.
+type TrainCategoriesRequest struct {
+	Labels []CategoryLabelRequest `json:"labels"`
+}
+
+// CategoryLabelRequest - рубрики новости, проставленные вручную. Пустой список снимает рубрики.
+type CategoryLabelRequest struct {
+	PostID     int32    `json:"post_id"`
+	Categories []string `json:"categories"`
+}
+
+// TrainCategoriesResponse представляет результат обучения рубрикатора.
+type TrainCategoriesResponse struct {
+	Labeled    int                `json:"labeled"`
+	Examples   int                `json:"examples"`
+	Categories []CategoryModelDTO `json:"categories"`
+}
+
+// CategoryModelDTO представляет рубрику модели и количество примеров для нее.
+type CategoryModelDTO struct {
+	Category string `json:"category"`
+	Examples int    `json:"examples"`
+	Active   bool   `json:"active"`
+}
+
+// TrainCategoriesHandler обрабатывает запрос (POST /categories/train).
+func (h *Handler) TrainCategoriesHandler(c *fiber.Ctx) error {
+	req := TrainCategoriesRequest{}
+	if len(c.Body()) > 0 {
+		parsed, err := api.Req[TrainCategoriesRequest](c)
+		if err != nil {
+			return c.Status(fiber.StatusBadRequest).
+				JSON(api.ErrWithCode("invalid-body", "Invalid request body"))
+		}
+		req = parsed
+	}
+
+	in := uc.TrainCategoriesInputDTO{Labels: make([]uc.CategoryLabelDTO, 0, len(req.Labels))}
+	for _, l := range req.Labels {
+		in.Labels = append(in.Labels, uc.CategoryLabelDTO{PostID: l.PostID, Categories: l.Categories})
+	}
+
+	out, err := h.trainCatsUC.Execute(c.Context(), in)
+	if err != nil {
+		switch {
+		case errors.Is(err, dom.ErrInvalidPostID):
+			return c.Status(fiber.StatusBadRequest).
+				JSON(api.ErrWithCode("invalid-id", "post ID must be positive integer"))
+		case errors.Is(err, dom.ErrInvalidPostCategory):
+			return c.Status(fiber.StatusBadRequest).
+				JSON(api.ErrWithCode("invalid-category", err.Error()))
+		case errors.Is(err, dom.ErrPostNotFound):
+			return c.Status(fiber.StatusNotFound).JSON(api.ErrWithCode("not-found", "post not found"))
+		}
+		return c.Status(fiber.StatusInternalServerError).JSON(api.Err(err))
+	}
+
+	categories := make([]CategoryModelDTO, 0, len(out.Categories))
+	for _, cat := range out.Categories {
+		categories = append(
+			categories, CategoryModelDTO{Category: cat.Category, Examples: cat.Examples, Active: cat.Active},
+		)
+	}
+
+	return c.Status(fiber.StatusOK).JSON(
+		api.Resp(
+			TrainCategoriesResponse{Labeled: out.Labeled, Examples: out.Examples, Categories: categories},
+		),
+	)
+}
@@ -3,14 +3,29 @@ package httplib
 
 import (
 	"github.com/ee-crocush/go-news/go-news/internal/infrastructure/transport/httplib/handler"
+	"github.com/ee-crocush/go-news/go-news/internal/infrastructure/transport/httplib/handler/feed"
 	"github.com/gofiber/fiber/v2"
 )
 
 // SetupRoutes регистрирует маршруты для Fiber приложения.
-func SetupRoutes(app *fiber.App, h *handler.Handler) {
+func SetupRoutes(app *fiber.App, h *handler.Handler, fh *feed.Handler) {
 	app.Get("/health", h.HealthCheckHandler)
 	app.Get("/news", h.FindAllHandler)
 	app.Get("/news/last", h.FindLastHandler)
 	app.Get("/news/latest/:limit?", h.FindLatestHandler)
 	app.Get("/news/:id", h.FindByIDHandler)
+	app.Get("/tags", h.FindTagsHandler)
+	app.Get("/categories", h.FindCategoriesHandler)
+	app.Post("/categories/train", h.TrainCategoriesHandler)
+
+	app.Get("/feeds", fh.FindAllHandler)
+	app.Post("/feeds", fh.CreateHandler)
+	app.Post("/feeds/refresh", fh.RefreshAllHandler)
+	app.Get("/feeds/schedule", fh.ScheduleHandler)
+	app.Get("/feeds/health", fh.HealthHandler)
+	app.Get("/feeds/:id", fh.FindByIDHandler)
+	app.Get("/feeds/:id/runs", fh.FindRunsHandler)
+	app.Post("/feeds/:id/refresh", fh.RefreshHandler)
+	app.Put("/feeds/:id", fh.UpdateHandler)
+	app.Delete("/feeds/:id", fh.DeleteHandler)
 }
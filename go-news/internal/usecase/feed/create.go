@@ -0,0 +1,47 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+
+	dom "github.com/ee-crocush/go-news/go-news/internal/domain/feed"
+)
+
+var _ CreateContract = (*CreateUseCase)(nil)
+
+// CreateUseCase представляет структуру, реализующую бизнес-логику добавления источника.
+type CreateUseCase struct {
+	repo dom.Repository
+}
+
+// NewCreateUseCase создает новый экземпляр use case для добавления источника.
+func NewCreateUseCase(repo dom.Repository) *CreateUseCase {
+	return &CreateUseCase{repo: repo}
+}
+
+// Execute выполняет бизнес-логику добавления источника. По умолчанию источник включен.
+func (uc *CreateUseCase) Execute(ctx context.Context, in CreateInputDTO) (FeedDTO, error) {
+	f, err := dom.NewFeed(in.URL)
+	if err != nil {
+		return FeedDTO{}, fmt.Errorf("CreateUseCase.NewFeed: %w", err)
+	}
+
+	interval, err := dom.NewPollInterval(in.PollInterval)
+	if err != nil {
+		return FeedDTO{}, fmt.Errorf("CreateUseCase.NewPollInterval: %w", err)
+	}
+
+	f.Rename(in.Title)
+	f.SetPollInterval(interval)
+	f.SetFetchFullArticle(in.FetchFull)
+
+	if in.Enabled != nil && !*in.Enabled {
+		f.Disable()
+	}
+
+	if err = uc.repo.Save(ctx, f); err != nil {
+		return FeedDTO{}, fmt.Errorf("CreateUseCase.Save: %w", err)
+	}
+
+	return MapFeedToDTO(f), nil
+}
@@ -0,0 +1,170 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	dom "github.com/ee-crocush/go-news/go-news/internal/domain/feed"
+)
+
+// mockFeedRepository реализует интерфейс dom.Repository для тестирования
+type mockFeedRepository struct {
+	feeds   map[int32]*dom.Feed
+	nextID  int32
+	saveErr error
+}
+
+func newMockFeedRepository(feeds ...*dom.Feed) *mockFeedRepository {
+	m := &mockFeedRepository{feeds: make(map[int32]*dom.Feed)}
+	for _, f := range feeds {
+		_ = m.Save(context.Background(), f)
+	}
+	return m
+}
+
+func (m *mockFeedRepository) Save(ctx context.Context, f *dom.Feed) error {
+	if m.saveErr != nil {
+		return m.saveErr
+	}
+	for id, existing := range m.feeds {
+		if existing.URL() == f.URL() && id != f.ID().Value() {
+			return dom.ErrFeedAlreadyExists
+		}
+	}
+	if f.ID().Value() == 0 {
+		m.nextID++
+		id, _ := dom.NewFeedID(m.nextID)
+		f.SetID(id)
+	}
+	m.feeds[f.ID().Value()] = f
+	return nil
+}
+
+func (m *mockFeedRepository) SaveFetch(ctx context.Context, f *dom.Feed, inserted int) error {
+	return m.saveErr
+}
+
+func (m *mockFeedRepository) SaveValidators(ctx context.Context, f *dom.Feed) error {
+	return m.saveErr
+}
+
+func (m *mockFeedRepository) Delete(ctx context.Context, id dom.FeedID) error {
+	if _, ok := m.feeds[id.Value()]; !ok {
+		return dom.ErrFeedNotFound
+	}
+	delete(m.feeds, id.Value())
+	return nil
+}
+
+func (m *mockFeedRepository) FindByID(ctx context.Context, id dom.FeedID) (*dom.Feed, error) {
+	if f, ok := m.feeds[id.Value()]; ok {
+		return f, nil
+	}
+	return nil, dom.ErrFeedNotFound
+}
+
+func (m *mockFeedRepository) FindByURL(ctx context.Context, url dom.FeedURL) (*dom.Feed, error) {
+	for _, f := range m.feeds {
+		if f.URL() == url {
+			return f, nil
+		}
+	}
+	return nil, dom.ErrFeedNotFound
+}
+
+func (m *mockFeedRepository) FindAll(ctx context.Context) ([]*dom.Feed, error) {
+	result := make([]*dom.Feed, 0, len(m.feeds))
+	for id := int32(1); id <= m.nextID; id++ {
+		if f, ok := m.feeds[id]; ok {
+			result = append(result, f)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockFeedRepository) FindEnabled(ctx context.Context) ([]*dom.Feed, error) {
+	all, _ := m.FindAll(ctx)
+	result := make([]*dom.Feed, 0, len(all))
+	for _, f := range all {
+		if f.Enabled() {
+			result = append(result, f)
+		}
+	}
+	return result, nil
+}
+
+func TestCreateUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run(
+		"successful creation", func(t *testing.T) {
+			repo := newMockFeedRepository()
+			useCase := NewCreateUseCase(repo)
+
+			result, err := useCase.Execute(
+				ctx, CreateInputDTO{URL: "https://example.com/rss", Title: "Example", PollInterval: 15},
+			)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.ID != 1 {
+				t.Errorf("expected ID 1, got %d", result.ID)
+			}
+			if !result.Enabled {
+				t.Error("expected feed to be enabled by default")
+			}
+			if result.Title != "Example" || result.PollInterval != 15 {
+				t.Errorf("unexpected result: %+v", result)
+			}
+		},
+	)
+
+	t.Run(
+		"disabled on creation", func(t *testing.T) {
+			enabled := false
+			result, err := NewCreateUseCase(newMockFeedRepository()).Execute(
+				ctx, CreateInputDTO{URL: "https://example.com/rss", Enabled: &enabled},
+			)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result.Enabled {
+				t.Error("expected feed to be disabled")
+			}
+		},
+	)
+
+	t.Run(
+		"invalid url", func(t *testing.T) {
+			_, err := NewCreateUseCase(newMockFeedRepository()).Execute(ctx, CreateInputDTO{URL: "ftp://example.com"})
+			if !errors.Is(err, dom.ErrInvalidFeedURL) {
+				t.Errorf("expected ErrInvalidFeedURL, got %v", err)
+			}
+		},
+	)
+
+	t.Run(
+		"invalid poll interval", func(t *testing.T) {
+			_, err := NewCreateUseCase(newMockFeedRepository()).Execute(
+				ctx, CreateInputDTO{URL: "https://example.com/rss", PollInterval: -5},
+			)
+			if !errors.Is(err, dom.ErrInvalidPollInterval) {
+				t.Errorf("expected ErrInvalidPollInterval, got %v", err)
+			}
+		},
+	)
+
+	t.Run(
+		"duplicate url", func(t *testing.T) {
+			existing, _ := dom.NewFeed("https://example.com/rss")
+			_, err := NewCreateUseCase(newMockFeedRepository(existing)).Execute(
+				ctx, CreateInputDTO{URL: "https://example.com/rss"},
+			)
+			if !errors.Is(err, dom.ErrFeedAlreadyExists) {
+				t.Errorf("expected ErrFeedAlreadyExists, got %v", err)
+			}
+		},
+	)
+}
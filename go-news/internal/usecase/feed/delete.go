@@ -0,0 +1,35 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+
+	dom "github.com/ee-crocush/go-news/go-news/internal/domain/feed"
+)
+
+var _ DeleteContract = (*DeleteUseCase)(nil)
+
+// DeleteUseCase представляет структуру, реализующую бизнес-логику удаления источника.
+// Ранее сохраненные новости источника не удаляются.
+type DeleteUseCase struct {
+	repo dom.Repository
+}
+
+// NewDeleteUseCase создает новый экземпляр use case для удаления источника.
+func NewDeleteUseCase(repo dom.Repository) *DeleteUseCase {
+	return &DeleteUseCase{repo: repo}
+}
+
+// Execute выполняет бизнес-логику удаления источника.
+func (uc *DeleteUseCase) Execute(ctx context.Context, in DeleteInputDTO) error {
+	id, err := dom.NewFeedID(in.ID)
+	if err != nil {
+		return fmt.Errorf("DeleteUseCase.NewFeedID: %w", err)
+	}
+
+	if err = uc.repo.Delete(ctx, id); err != nil {
+		return fmt.Errorf("DeleteUseCase.Delete: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,202 @@
+// Package feed выполняет бизнес-логику управления источниками новостей.
+package feed
+
+import (
+	"time"
+
+	dom "github.com/ee-crocush/go-news/go-news/internal/domain/feed"
+)
+
+// CreateInputDTO представляет входной DTO для добавления источника.
+type CreateInputDTO struct {
+	URL          string `json:"url"`
+	Title        string `json:"title"`
+	Enabled      *bool  `json:"enabled,omitempty"`
+	PollInterval int    `json:"poll_interval"`
+	FetchFull    bool   `json:"fetch_full"`
+}
+
+// UpdateInputDTO представляет входной DTO для изменения источника.
+type UpdateInputDTO struct {
+	ID           int32  `json:"-"`
+	URL          string `json:"url"`
+	Title        string `json:"title"`
+	Enabled      *bool  `json:"enabled,omitempty"`
+	PollInterval int    `json:"poll_interval"`
+	FetchFull    bool   `json:"fetch_full"`
+}
+
+// FindByIDInputDTO представляет входной DTO для поиска источника по ID.
+type FindByIDInputDTO struct {
+	ID int32 `json:"id"`
+}
+
+// DeleteInputDTO представляет входной DTO для удаления источника.
+type DeleteInputDTO struct {
+	ID int32 `json:"id"`
+}
+
+// FindEnabledInputDTO представляет входной DTO для получения включенных источников.
+type FindEnabledInputDTO struct {
+	DefaultInterval time.Duration
+}
+
+// ScheduledFeedDTO представляет источник для планировщика опроса.
+type ScheduledFeedDTO struct {
+	ID            int32
+	URL           string
+	Interval      time.Duration
+	LastFetchedAt time.Time
+}
+
+// FeedDTO представляет выходной DTO источника.
+type FeedDTO struct {
+	ID            int32    `json:"id"`
+	URL           string   `json:"url"`
+	Title         string   `json:"title"`
+	Enabled       bool     `json:"enabled"`
+	PollInterval  int      `json:"poll_interval"`
+	FetchFull     bool     `json:"fetch_full"`
+	LastStatus    string   `json:"last_status,omitempty"`
+	LastError     string   `json:"last_error,omitempty"`
+	LastWarnings  []string `json:"last_warnings,omitempty"`
+	LastFetchedAt string   `json:"last_fetched_at,omitempty"`
+	ItemCount     int64    `json:"item_count"`
+}
+
+// MapFeedToDTO маппинг доменной модели источника в DTO.
+func MapFeedToDTO(f *dom.Feed) FeedDTO {
+	dto := FeedDTO{
+		ID:           f.ID().Value(),
+		URL:          f.URL().Value(),
+		Title:        f.Title(),
+		Enabled:      f.Enabled(),
+		PollInterval: f.PollInterval().Minutes(),
+		FetchFull:    f.FetchFullArticle(),
+		LastStatus:   string(f.LastFetch().Status()),
+		LastError:    f.LastFetch().Error(),
+		LastWarnings: f.LastFetch().Warnings(),
+		ItemCount:    f.ItemCount(),
+	}
+
+	if at := f.LastFetch().At(); !at.IsZero() {
+		dto.LastFetchedAt = at.UTC().Format(time.RFC3339)
+	}
+
+	return dto
+}
+
+// MapFeedsToDTO маппинг слайса источников в DTO.
+func MapFeedsToDTO(feeds []*dom.Feed) []FeedDTO {
+	result := make([]FeedDTO, 0, len(feeds))
+	for _, f := range feeds {
+		result = append(result, MapFeedToDTO(f))
+	}
+
+	return result
+}
+
+// FindRunsInputDTO представляет входной DTO для получения журнала опросов источника.
+type FindRunsInputDTO struct {
+	ID    int32 `json:"id"`
+	Limit int   `json:"limit"`
+}
+
+// Validate проверяет входные данные для получения журнала опросов.
+func (f *FindRunsInputDTO) Validate() {
+	if f.Limit <= 0 {
+		f.Limit = 20
+	}
+	if f.Limit > 100 {
+		f.Limit = 100
+	}
+}
+
+// RunDTO представляет запись журнала опросов источника.
+type RunDTO struct {
+	StartedAt  string `json:"started_at"`
+	FinishedAt string `json:"finished_at"`
+	DurationMs int64  `json:"duration_ms"`
+	Status     string `json:"status"`
+	HTTPStatus int    `json:"http_status,omitempty"`
+	Seen       int    `json:"seen"`
+	Inserted   int    `json:"inserted"`
+	Skipped    int    `json:"skipped"`
+	Error      string `json:"error,omitempty"`
+}
+
+// MapRunsToDTO маппинг записей журнала опросов в DTO.
+func MapRunsToDTO(runs []*dom.Run) []RunDTO {
+	result := make([]RunDTO, 0, len(runs))
+	for _, r := range runs {
+		counts := r.Counts()
+		result = append(
+			result, RunDTO{
+				StartedAt:  r.StartedAt().UTC().Format(time.RFC3339),
+				FinishedAt: r.FinishedAt().UTC().Format(time.RFC3339),
+				DurationMs: r.Duration().Milliseconds(),
+				Status:     string(r.Status()),
+				HTTPStatus: r.HTTPStatus(),
+				Seen:       counts.Seen,
+				Inserted:   counts.Inserted,
+				Skipped:    counts.Skipped,
+				Error:      r.Error(),
+			},
+		)
+	}
+
+	return result
+}
+
+// Состояния источника в сводке здоровья.
+const (
+	// HealthHealthy - последний опрос успешен, ошибок за период не было.
+	HealthHealthy = "healthy"
+	// HealthDegraded - последний опрос успешен, но за период были ошибки.
+	HealthDegraded = "degraded"
+	// HealthFailing - последний опрос завершился ошибкой.
+	HealthFailing = "failing"
+	// HealthUnknown - источник еще не опрашивался.
+	HealthUnknown = "unknown"
+	// HealthDisabled - источник выключен и не опрашивается.
+	HealthDisabled = "disabled"
+)
+
+// FindHealthInputDTO представляет входной DTO для получения сводки здоровья источников.
+type FindHealthInputDTO struct {
+	// Hours - период журнала опросов в часах.
+	Hours int `json:"hours"`
+}
+
+// Validate проверяет входные данные для получения сводки здоровья.
+func (f *FindHealthInputDTO) Validate() {
+	if f.Hours <= 0 {
+		f.Hours = 24
+	}
+	if f.Hours > 720 {
+		f.Hours = 720
+	}
+}
+
+// FeedHealthDTO представляет состояние источника и сводку его опросов за период.
+type FeedHealthDTO struct {
+	ID            int32  `json:"id"`
+	URL           string `json:"url"`
+	Title         string `json:"title"`
+	Health        string `json:"health"`
+	LastStatus    string `json:"last_status,omitempty"`
+	LastError     string `json:"last_error,omitempty"`
+	LastFetchedAt string `json:"last_fetched_at,omitempty"`
+	LastSuccessAt string `json:"last_success_at,omitempty"`
+	Runs          int64  `json:"runs"`
+	Failed        int64  `json:"failed"`
+}
+
+// FindHealthOutputDTO представляет сводку здоровья источников.
+type FindHealthOutputDTO struct {
+	Hours    int             `json:"hours"`
+	Healthy  int             `json:"healthy"`
+	Degraded int             `json:"degraded"`
+	Failing  int             `json:"failing"`
+	Feeds    []FeedHealthDTO `json:"feeds"`
+}
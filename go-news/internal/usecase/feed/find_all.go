@@ -0,0 +1,30 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+
+	dom "github.com/ee-crocush/go-news/go-news/internal/domain/feed"
+)
+
+var _ FindAllContract = (*FindAllUseCase)(nil)
+
+// FindAllUseCase представляет структуру, реализующую бизнес-логику поиска всех источников.
+type FindAllUseCase struct {
+	repo dom.Repository
+}
+
+// NewFindAllUseCase создает новый экземпляр use case для поиска всех источников.
+func NewFindAllUseCase(repo dom.Repository) *FindAllUseCase {
+	return &FindAllUseCase{repo: repo}
+}
+
+// Execute выполняет бизнес-логику поиска всех источников.
+func (uc *FindAllUseCase) Execute(ctx context.Context) ([]FeedDTO, error) {
+	feeds, err := uc.repo.FindAll(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("FindAllUseCase.FindAll: %w", err)
+	}
+
+	return MapFeedsToDTO(feeds), nil
+}
@@ -0,0 +1,35 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+
+	dom "github.com/ee-crocush/go-news/go-news/internal/domain/feed"
+)
+
+var _ FindByIDContract = (*FindByIDUseCase)(nil)
+
+// FindByIDUseCase представляет структуру, реализующую бизнес-логику поиска источника по ID.
+type FindByIDUseCase struct {
+	repo dom.Repository
+}
+
+// NewFindByIDUseCase создает новый экземпляр use case для поиска источника по ID.
+func NewFindByIDUseCase(repo dom.Repository) *FindByIDUseCase {
+	return &FindByIDUseCase{repo: repo}
+}
+
+// Execute выполняет бизнес-логику поиска источника по ID.
+func (uc *FindByIDUseCase) Execute(ctx context.Context, in FindByIDInputDTO) (FeedDTO, error) {
+	id, err := dom.NewFeedID(in.ID)
+	if err != nil {
+		return FeedDTO{}, fmt.Errorf("FindByIDUseCase.NewFeedID: %w", err)
+	}
+
+	f, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return FeedDTO{}, fmt.Errorf("FindByIDUseCase.FindByID: %w", err)
+	}
+
+	return MapFeedToDTO(f), nil
+}
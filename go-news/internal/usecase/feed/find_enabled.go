@@ -0,0 +1,43 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+
+	dom "github.com/ee-crocush/go-news/go-news/internal/domain/feed"
+)
+
+var _ FindEnabledContract = (*FindEnabledUseCase)(nil)
+
+// FindEnabledUseCase представляет структуру, реализующую бизнес-логику получения включенных
+// источников для планировщика опроса.
+type FindEnabledUseCase struct {
+	repo dom.Repository
+}
+
+// NewFindEnabledUseCase создает новый экземпляр use case для получения включенных источников.
+func NewFindEnabledUseCase(repo dom.Repository) *FindEnabledUseCase {
+	return &FindEnabledUseCase{repo: repo}
+}
+
+// Execute возвращает включенные источники с эффективным интервалом опроса.
+func (uc *FindEnabledUseCase) Execute(ctx context.Context, in FindEnabledInputDTO) ([]ScheduledFeedDTO, error) {
+	feeds, err := uc.repo.FindEnabled(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("FindEnabledUseCase.FindEnabled: %w", err)
+	}
+
+	result := make([]ScheduledFeedDTO, 0, len(feeds))
+	for _, f := range feeds {
+		result = append(
+			result, ScheduledFeedDTO{
+				ID:            f.ID().Value(),
+				URL:           f.URL().Value(),
+				Interval:      f.PollInterval().Or(in.DefaultInterval),
+				LastFetchedAt: f.LastFetch().At(),
+			},
+		)
+	}
+
+	return result, nil
+}
@@ -0,0 +1,42 @@
+package feed
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	dom "github.com/ee-crocush/go-news/go-news/internal/domain/feed"
+)
+
+func TestFindEnabledUseCase_Execute(t *testing.T) {
+	fetchedAt := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+
+	withDefault, _ := dom.NewFeed("https://example.com/default")
+	withDefault.RecordSuccess(fetchedAt, 0, false)
+
+	custom, _ := dom.NewFeed("https://example.com/custom")
+	interval, _ := dom.NewPollInterval(30)
+	custom.SetPollInterval(interval)
+
+	disabled, _ := dom.NewFeed("https://example.com/disabled")
+	disabled.Disable()
+
+	repo := newMockFeedRepository(withDefault, custom, disabled)
+
+	result, err := NewFindEnabledUseCase(repo).Execute(
+		context.Background(), FindEnabledInputDTO{DefaultInterval: 5 * time.Minute},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result) != 2 {
+		t.Fatalf("expected 2 enabled feeds, got %d: %+v", len(result), result)
+	}
+	if result[0].Interval != 5*time.Minute || !result[0].LastFetchedAt.Equal(fetchedAt) {
+		t.Errorf("unexpected feed with default interval: %+v", result[0])
+	}
+	if result[1].Interval != 30*time.Minute || !result[1].LastFetchedAt.IsZero() {
+		t.Errorf("unexpected feed with custom interval: %+v", result[1])
+	}
+}
@@ -0,0 +1,106 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	dom "github.com/ee-crocush/go-news/go-news/internal/domain/feed"
+)
+
+var _ FindHealthContract = (*FindHealthUseCase)(nil)
+
+// FindHealthUseCase представляет структуру, реализующую бизнес-логику получения сводки здоровья источников.
+type FindHealthUseCase struct {
+	repo dom.Repository
+	runs dom.RunFinder
+	now  func() time.Time
+}
+
+// NewFindHealthUseCase создает новый экземпляр use case для получения сводки здоровья источников.
+func NewFindHealthUseCase(repo dom.Repository, runs dom.RunFinder) *FindHealthUseCase {
+	return &FindHealthUseCase{repo: repo, runs: runs, now: time.Now}
+}
+
+// Execute возвращает состояние каждого источника и сводку его опросов за последние Hours часов.
+// Состояние определяется по последнему опросу источника и ошибкам за период.
+func (uc *FindHealthUseCase) Execute(ctx context.Context, in FindHealthInputDTO) (FindHealthOutputDTO, error) {
+	in.Validate()
+
+	feeds, err := uc.repo.FindAll(ctx)
+	if err != nil {
+		return FindHealthOutputDTO{}, fmt.Errorf("FindHealthUseCase.FindAll: %w", err)
+	}
+
+	since := uc.now().Add(-time.Duration(in.Hours) * time.Hour)
+
+	stats, err := uc.runs.FindRunStats(ctx, since)
+	if err != nil {
+		return FindHealthOutputDTO{}, fmt.Errorf("FindHealthUseCase.FindRunStats: %w", err)
+	}
+
+	byFeed := make(map[int32]dom.RunStats, len(stats))
+	for _, s := range stats {
+		byFeed[s.FeedID.Value()] = s
+	}
+
+	out := FindHealthOutputDTO{
+		Hours: in.Hours,
+		Feeds: make([]FeedHealthDTO, 0, len(feeds)),
+	}
+
+	for _, f := range feeds {
+		h := mapFeedHealth(f, byFeed[f.ID().Value()])
+
+		switch h.Health {
+		case HealthHealthy:
+			out.Healthy++
+		case HealthDegraded:
+			out.Degraded++
+		case HealthFailing:
+			out.Failing++
+		}
+
+		out.Feeds = append(out.Feeds, h)
+	}
+
+	return out, nil
+}
+
+func mapFeedHealth(f *dom.Feed, stats dom.RunStats) FeedHealthDTO {
+	dto := FeedHealthDTO{
+		ID:         f.ID().Value(),
+		URL:        f.URL().Value(),
+		Title:      f.Title(),
+		Health:     feedHealth(f, stats),
+		LastStatus: string(f.LastFetch().Status()),
+		LastError:  f.LastFetch().Error(),
+		Runs:       stats.Runs,
+		Failed:     stats.Failed,
+	}
+
+	if at := f.LastFetch().At(); !at.IsZero() {
+		dto.LastFetchedAt = at.UTC().Format(time.RFC3339)
+	}
+	if !stats.LastSuccessAt.IsZero() {
+		dto.LastSuccessAt = stats.LastSuccessAt.UTC().Format(time.RFC3339)
+	}
+
+	return dto
+}
+
+// feedHealth определяет состояние источника.
+func feedHealth(f *dom.Feed, stats dom.RunStats) string {
+	switch {
+	case !f.Enabled():
+		return HealthDisabled
+	case f.LastFetch().Status() == dom.FetchStatusNone:
+		return HealthUnknown
+	case f.LastFetch().Status() == dom.FetchStatusError:
+		return HealthFailing
+	case stats.Failed > 0:
+		return HealthDegraded
+	default:
+		return HealthHealthy
+	}
+}
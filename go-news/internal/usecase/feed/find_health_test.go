@@ -0,0 +1,71 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	dom "github.com/ee-crocush/go-news/go-news/internal/domain/feed"
+)
+
+func TestFindHealthUseCase_Execute(t *testing.T) {
+	now := time.Date(2025, 1, 2, 12, 0, 0, 0, time.UTC)
+
+	healthy, _ := dom.NewFeed("https://example.com/healthy")
+	healthy.RecordSuccess(now.Add(-time.Hour), 1, false)
+
+	degraded, _ := dom.NewFeed("https://example.com/degraded")
+	degraded.RecordSuccess(now.Add(-time.Hour), 0, true)
+
+	failing, _ := dom.NewFeed("https://example.com/failing")
+	failing.RecordFailure(now.Add(-time.Hour), errors.New("unexpected http status: 503"))
+
+	unknown, _ := dom.NewFeed("https://example.com/new")
+
+	disabled, _ := dom.NewFeed("https://example.com/disabled")
+	disabled.RecordFailure(now.Add(-48*time.Hour), errors.New("timeout"))
+	disabled.Disable()
+
+	repo := newMockFeedRepository(healthy, degraded, failing, unknown, disabled)
+	runs := &mockRunRepository{
+		stats: []dom.RunStats{
+			{FeedID: healthy.ID(), Runs: 24, LastRunAt: now.Add(-time.Hour), LastSuccessAt: now.Add(-time.Hour)},
+			{FeedID: degraded.ID(), Runs: 24, Failed: 3, LastRunAt: now.Add(-time.Hour), LastSuccessAt: now.Add(-time.Hour)},
+			{FeedID: failing.ID(), Runs: 5, Failed: 5, LastRunAt: now.Add(-time.Hour)},
+		},
+	}
+
+	uc := NewFindHealthUseCase(repo, runs)
+	uc.now = func() time.Time { return now }
+
+	out, err := uc.Execute(context.Background(), FindHealthInputDTO{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if out.Hours != 24 || !runs.since.Equal(now.Add(-24*time.Hour)) {
+		t.Errorf("default period: hours = %d, since = %v", out.Hours, runs.since)
+	}
+	if out.Healthy != 1 || out.Degraded != 1 || out.Failing != 1 {
+		t.Errorf("unexpected summary: %+v", out)
+	}
+
+	wantHealth := []string{HealthHealthy, HealthDegraded, HealthFailing, HealthUnknown, HealthDisabled}
+	if len(out.Feeds) != len(wantHealth) {
+		t.Fatalf("expected %d feeds, got %d", len(wantHealth), len(out.Feeds))
+	}
+	for i, want := range wantHealth {
+		if out.Feeds[i].Health != want {
+			t.Errorf("feeds[%d].Health = %q, want %q", i, out.Feeds[i].Health, want)
+		}
+	}
+
+	f := out.Feeds[2]
+	if f.Runs != 5 || f.Failed != 5 || f.LastSuccessAt != "" || f.LastError != "unexpected http status: 503" {
+		t.Errorf("unexpected failing feed: %+v", f)
+	}
+	if out.Feeds[0].LastSuccessAt != "2025-01-02T11:00:00Z" {
+		t.Errorf("LastSuccessAt = %q", out.Feeds[0].LastSuccessAt)
+	}
+}
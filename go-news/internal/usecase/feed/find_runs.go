@@ -0,0 +1,42 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+
+	dom "github.com/ee-crocush/go-news/go-news/internal/domain/feed"
+)
+
+var _ FindRunsContract = (*FindRunsUseCase)(nil)
+
+// FindRunsUseCase представляет структуру, реализующую бизнес-логику получения журнала опросов источника.
+type FindRunsUseCase struct {
+	repo dom.Repository
+	runs dom.RunFinder
+}
+
+// NewFindRunsUseCase создает новый экземпляр use case для получения журнала опросов источника.
+func NewFindRunsUseCase(repo dom.Repository, runs dom.RunFinder) *FindRunsUseCase {
+	return &FindRunsUseCase{repo: repo, runs: runs}
+}
+
+// Execute возвращает последние опросы источника, новые первыми.
+func (uc *FindRunsUseCase) Execute(ctx context.Context, in FindRunsInputDTO) ([]RunDTO, error) {
+	in.Validate()
+
+	id, err := dom.NewFeedID(in.ID)
+	if err != nil {
+		return nil, fmt.Errorf("FindRunsUseCase.NewFeedID: %w", err)
+	}
+
+	if _, err = uc.repo.FindByID(ctx, id); err != nil {
+		return nil, fmt.Errorf("FindRunsUseCase.FindByID: %w", err)
+	}
+
+	runs, err := uc.runs.FindRuns(ctx, id, in.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("FindRunsUseCase.FindRuns: %w", err)
+	}
+
+	return MapRunsToDTO(runs), nil
+}
@@ -0,0 +1,88 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	dom "github.com/ee-crocush/go-news/go-news/internal/domain/feed"
+)
+
+// mockRunRepository реализует интерфейс dom.RunRepository для тестирования
+type mockRunRepository struct {
+	runs  []*dom.Run
+	stats []dom.RunStats
+	since time.Time
+	limit int
+}
+
+func (m *mockRunRepository) SaveRun(ctx context.Context, run *dom.Run) error {
+	m.runs = append(m.runs, run)
+	return nil
+}
+
+func (m *mockRunRepository) FindRuns(ctx context.Context, id dom.FeedID, limit int) ([]*dom.Run, error) {
+	m.limit = limit
+	var result []*dom.Run
+	for i := len(m.runs) - 1; i >= 0 && len(result) < limit; i-- {
+		if m.runs[i].FeedID() == id {
+			result = append(result, m.runs[i])
+		}
+	}
+	return result, nil
+}
+
+func (m *mockRunRepository) FindRunStats(ctx context.Context, since time.Time) ([]dom.RunStats, error) {
+	m.since = since
+	return m.stats, nil
+}
+
+func TestFindRunsUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+	source, _ := dom.NewFeed("https://example.com/rss")
+	repo := newMockFeedRepository(source)
+
+	started := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	runs := &mockRunRepository{}
+	for i := 0; i < 3; i++ {
+		run := dom.NewRun(source.ID(), source.URL(), started.Add(time.Duration(i)*time.Hour))
+		run.SetHTTPStatus(200)
+		run.Succeed(run.StartedAt().Add(2*time.Second), dom.RunCounts{Seen: 10, Inserted: i, Skipped: 10 - i}, false)
+		_ = runs.SaveRun(ctx, run)
+	}
+
+	out, err := NewFindRunsUseCase(repo, runs).Execute(ctx, FindRunsInputDTO{ID: source.ID().Value(), Limit: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(out) != 2 {
+		t.Fatalf("expected 2 runs, got %d", len(out))
+	}
+	want := RunDTO{
+		StartedAt:  "2025-01-01T14:00:00Z",
+		FinishedAt: "2025-01-01T14:00:02Z",
+		DurationMs: 2000,
+		Status:     "ok",
+		HTTPStatus: 200,
+		Seen:       10,
+		Inserted:   2,
+		Skipped:    8,
+	}
+	if out[0] != want {
+		t.Errorf("out[0] = %+v, want %+v", out[0], want)
+	}
+
+	if _, err = NewFindRunsUseCase(repo, runs).Execute(ctx, FindRunsInputDTO{ID: source.ID().Value()}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if runs.limit != 20 {
+		t.Errorf("default limit = %d, want 20", runs.limit)
+	}
+
+	_, err = NewFindRunsUseCase(repo, runs).Execute(ctx, FindRunsInputDTO{ID: 42})
+	if !errors.Is(err, dom.ErrFeedNotFound) {
+		t.Errorf("expected ErrFeedNotFound, got %v", err)
+	}
+}
@@ -0,0 +1,53 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	dom "github.com/ee-crocush/go-news/go-news/internal/domain/feed"
+)
+
+var _ ImportContract = (*ImportUseCase)(nil)
+
+// ImportUseCase представляет структуру, реализующую бизнес-логику импорта источников из конфигурации.
+type ImportUseCase struct {
+	repo dom.Repository
+}
+
+// NewImportUseCase создает новый экземпляр use case для импорта источников.
+func NewImportUseCase(repo dom.Repository) *ImportUseCase {
+	return &ImportUseCase{repo: repo}
+}
+
+// Execute добавляет в хранилище источники, которых там еще нет, и возвращает количество добавленных.
+// Уже существующие источники не изменяются, поэтому правки, сделанные через API, не перезаписываются.
+func (uc *ImportUseCase) Execute(ctx context.Context, urls []string) (int, error) {
+	var added int
+
+	for _, raw := range urls {
+		f, err := dom.NewFeed(raw)
+		if err != nil {
+			return added, fmt.Errorf("ImportUseCase.NewFeed: %w", err)
+		}
+
+		_, err = uc.repo.FindByURL(ctx, f.URL())
+		if err == nil {
+			continue
+		}
+		if !errors.Is(err, dom.ErrFeedNotFound) {
+			return added, fmt.Errorf("ImportUseCase.FindByURL: %w", err)
+		}
+
+		if err = uc.repo.Save(ctx, f); err != nil {
+			if errors.Is(err, dom.ErrFeedAlreadyExists) {
+				continue
+			}
+			return added, fmt.Errorf("ImportUseCase.Save: %w", err)
+		}
+
+		added++
+	}
+
+	return added, nil
+}
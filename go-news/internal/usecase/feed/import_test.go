@@ -0,0 +1,31 @@
+package feed
+
+import (
+	"context"
+	"testing"
+
+	dom "github.com/ee-crocush/go-news/go-news/internal/domain/feed"
+)
+
+func TestImportUseCase_Execute(t *testing.T) {
+	existing, _ := dom.NewFeed("https://example.com/rss")
+	existing.Disable()
+	repo := newMockFeedRepository(existing)
+
+	added, err := NewImportUseCase(repo).Execute(
+		context.Background(), []string{"https://example.com/rss", "https://example.org/feed"},
+	)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if added != 1 {
+		t.Errorf("expected 1 added feed, got %d", added)
+	}
+	if existing.Enabled() {
+		t.Error("import must not change existing feeds")
+	}
+	if len(repo.feeds) != 2 {
+		t.Errorf("expected 2 feeds in repository, got %d", len(repo.feeds))
+	}
+}
@@ -0,0 +1,48 @@
+package feed
+
+import "context"
+
+// CreateContract интерфейс для добавления источника.
+type CreateContract interface {
+	Execute(ctx context.Context, in CreateInputDTO) (FeedDTO, error)
+}
+
+// UpdateContract интерфейс для изменения источника.
+type UpdateContract interface {
+	Execute(ctx context.Context, in UpdateInputDTO) (FeedDTO, error)
+}
+
+// DeleteContract интерфейс для удаления источника.
+type DeleteContract interface {
+	Execute(ctx context.Context, in DeleteInputDTO) error
+}
+
+// FindByIDContract интерфейс для поиска источника по ID.
+type FindByIDContract interface {
+	Execute(ctx context.Context, in FindByIDInputDTO) (FeedDTO, error)
+}
+
+// FindAllContract интерфейс для поиска всех источников.
+type FindAllContract interface {
+	Execute(ctx context.Context) ([]FeedDTO, error)
+}
+
+// FindEnabledContract интерфейс для получения включенных источников.
+type FindEnabledContract interface {
+	Execute(ctx context.Context, in FindEnabledInputDTO) ([]ScheduledFeedDTO, error)
+}
+
+// ImportContract интерфейс для импорта источников из конфигурации.
+type ImportContract interface {
+	Execute(ctx context.Context, urls []string) (int, error)
+}
+
+// FindRunsContract интерфейс для получения журнала опросов источника.
+type FindRunsContract interface {
+	Execute(ctx context.Context, in FindRunsInputDTO) ([]RunDTO, error)
+}
+
+// FindHealthContract интерфейс для получения сводки здоровья источников.
+type FindHealthContract interface {
+	Execute(ctx context.Context, in FindHealthInputDTO) (FindHealthOutputDTO, error)
+}
@@ -0,0 +1,63 @@
+package feed
+
+import (
+	"context"
+	"fmt"
+
+	dom "github.com/ee-crocush/go-news/go-news/internal/domain/feed"
+)
+
+var _ UpdateContract = (*UpdateUseCase)(nil)
+
+// UpdateUseCase представляет структуру, реализующую бизнес-логику изменения источника.
+type UpdateUseCase struct {
+	repo dom.Repository
+}
+
+// NewUpdateUseCase создает новый экземпляр use case для изменения источника.
+func NewUpdateUseCase(repo dom.Repository) *UpdateUseCase {
+	return &UpdateUseCase{repo: repo}
+}
+
+// Execute выполняет бизнес-логику изменения источника.
+func (uc *UpdateUseCase) Execute(ctx context.Context, in UpdateInputDTO) (FeedDTO, error) {
+	id, err := dom.NewFeedID(in.ID)
+	if err != nil {
+		return FeedDTO{}, fmt.Errorf("UpdateUseCase.NewFeedID: %w", err)
+	}
+
+	url, err := dom.NewFeedURL(in.URL)
+	if err != nil {
+		return FeedDTO{}, fmt.Errorf("UpdateUseCase.NewFeedURL: %w", err)
+	}
+
+	interval, err := dom.NewPollInterval(in.PollInterval)
+	if err != nil {
+		return FeedDTO{}, fmt.Errorf("UpdateUseCase.NewPollInterval: %w", err)
+	}
+
+	f, err := uc.repo.FindByID(ctx, id)
+	if err != nil {
+		return FeedDTO{}, fmt.Errorf("UpdateUseCase.FindByID: %w", err)
+	}
+
+	f.ChangeURL(url)
+	f.Rename(in.Title)
+	f.SetPollInterval(interval)
+	f.SetFetchFullArticle(in.FetchFull)
+
+	// Если признак не передан, источник остается включенным или выключенным, как был.
+	if in.Enabled != nil {
+		if *in.Enabled {
+			f.Enable()
+		} else {
+			f.Disable()
+		}
+	}
+
+	if err = uc.repo.Save(ctx, f); err != nil {
+		return FeedDTO{}, fmt.Errorf("UpdateUseCase.Save: %w", err)
+	}
+
+	return MapFeedToDTO(f), nil
+}
@@ -0,0 +1,83 @@
+package feed
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	dom "github.com/ee-crocush/go-news/go-news/internal/domain/feed"
+)
+
+func TestUpdateUseCase_Execute(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run(
+		"successful update", func(t *testing.T) {
+			existing, _ := dom.NewFeed("https://example.com/rss")
+			existing.SetValidators(`"v1"`, "")
+			repo := newMockFeedRepository(existing)
+
+			result, err := NewUpdateUseCase(repo).Execute(
+				ctx, UpdateInputDTO{
+					ID: existing.ID().Value(), URL: "https://example.com/atom", Title: "Renamed", PollInterval: 60,
+				},
+			)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.URL != "https://example.com/atom" || result.Title != "Renamed" || result.PollInterval != 60 {
+				t.Errorf("unexpected result: %+v", result)
+			}
+			if !result.Enabled {
+				t.Error("expected feed to stay enabled when enabled is omitted")
+			}
+			if existing.ETag() != "" {
+				t.Error("expected validators to be reset after URL change")
+			}
+		},
+	)
+
+	t.Run(
+		"disable", func(t *testing.T) {
+			existing, _ := dom.NewFeed("https://example.com/rss")
+			repo := newMockFeedRepository(existing)
+			enabled := false
+
+			result, err := NewUpdateUseCase(repo).Execute(
+				ctx, UpdateInputDTO{
+					ID: existing.ID().Value(), URL: "https://example.com/rss", Enabled: &enabled, PollInterval: 60,
+				},
+			)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if result.Enabled || existing.Enabled() {
+				t.Error("expected feed to be disabled")
+			}
+		},
+	)
+
+	t.Run(
+		"not found", func(t *testing.T) {
+			_, err := NewUpdateUseCase(newMockFeedRepository()).Execute(
+				ctx, UpdateInputDTO{ID: 42, URL: "https://example.com/rss"},
+			)
+			if !errors.Is(err, dom.ErrFeedNotFound) {
+				t.Errorf("expected ErrFeedNotFound, got %v", err)
+			}
+		},
+	)
+
+	t.Run(
+		"invalid id", func(t *testing.T) {
+			_, err := NewUpdateUseCase(newMockFeedRepository()).Execute(
+				ctx, UpdateInputDTO{ID: 0, URL: "https://example.com/rss"},
+			)
+			if !errors.Is(err, dom.ErrInvalidFeedID) {
+				t.Errorf("expected ErrInvalidFeedID, got %v", err)
+			}
+		},
+	)
+}
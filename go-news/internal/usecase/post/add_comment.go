@@ -0,0 +1,40 @@
+package post
+
+import (
+	"context"
+	"fmt"
+	dom "github.com/ee-crocush/go-news/go-news/internal/domain/post"
+	"github.com/ee-crocush/go-news/go-news/internal/infrastructure/events"
+	"github.com/segmentio/kafka-go"
+)
+
+var _ AddCommentContract = (*AddCommentUseCase)(nil)
+
+// AddCommentUseCase представляет структуру, реализующую бизнес-логику учета опубликованных комментариев.
+type AddCommentUseCase struct {
+	repo dom.PostCommentsCounter
+}
+
+// NewAddCommentUseCase создает новый экземпляр adapter для учета опубликованных комментариев.
+func NewAddCommentUseCase(repo dom.PostCommentsCounter) *AddCommentUseCase {
+	return &AddCommentUseCase{repo: repo}
+}
+
+// Execute выполняет бизнес-логику учета опубликованного комментария из события сервиса комментариев.
+func (uc *AddCommentUseCase) Execute(ctx context.Context, msg kafka.Message) error {
+	var in events.CommentPublishedEvent
+	if err := in.FromJSON(msg.Value); err != nil {
+		return fmt.Errorf("AddCommentUseCase.FromJSON: %w", err)
+	}
+
+	postID, err := dom.NewPostID(in.NewsID)
+	if err != nil {
+		return fmt.Errorf("AddCommentUseCase.NewPostID: %w", err)
+	}
+
+	if _, err = uc.repo.AddComment(ctx, postID, in.CommentID); err != nil {
+		return fmt.Errorf("AddCommentUseCase.AddComment: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package post
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	dom "github.com/ee-crocush/go-news/go-news/internal/domain/post"
+	"github.com/segmentio/kafka-go"
+)
+
+type mockCommentsCounter struct {
+	comments map[int32]map[int64]struct{}
+	err      error
+}
+
+func (m *mockCommentsCounter) AddComment(ctx context.Context, postID dom.PostID, commentID int64) (bool, error) {
+	if m.err != nil {
+		return false, m.err
+	}
+
+	ids, ok := m.comments[postID.Value()]
+	if !ok {
+		return false, dom.ErrPostNotFound
+	}
+	if _, ok = ids[commentID]; ok {
+		return false, nil
+	}
+	ids[commentID] = struct{}{}
+
+	return true, nil
+}
+
+func TestAddCommentUseCase_Execute(t *testing.T) {
+	repo := &mockCommentsCounter{comments: map[int32]map[int64]struct{}{7: {}}}
+	useCase := NewAddCommentUseCase(repo)
+
+	msg := kafka.Message{Value: []byte(`{"comment_id": 15, "news_id": 7, "published_at": "2025-01-01T10:00:00Z"}`)}
+
+	// Повторная доставка события не должна приводить к ошибке.
+	for range 2 {
+		if err := useCase.Execute(context.Background(), msg); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if len(repo.comments[7]) != 1 {
+		t.Errorf("expected 1 counted comment, got %d", len(repo.comments[7]))
+	}
+
+	msg.Value = []byte(`{"comment_id": 16, "news_id": 8}`)
+	if err := useCase.Execute(context.Background(), msg); !errors.Is(err, dom.ErrPostNotFound) {
+		t.Errorf("expected ErrPostNotFound, got %v", err)
+	}
+
+	msg.Value = []byte(`{"comment_id": 16, "news_id": 0}`)
+	if err := useCase.Execute(context.Background(), msg); !errors.Is(err, dom.ErrInvalidPostID) {
+		t.Errorf("expected ErrInvalidPostID, got %v", err)
+	}
+
+	msg.Value = []byte(`not json`)
+	if err := useCase.Execute(context.Background(), msg); err == nil {
+		t.Error("expected error for malformed event")
+	}
+}
@@ -0,0 +1,67 @@
+package post
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	dom "github.com/ee-crocush/go-news/go-news/internal/domain/post"
+)
+
+const (
+	cursorNextPrefix = "n"
+	cursorPrevPrefix = "p"
+)
+
+// EncodeCursor кодирует курсор в непрозрачный токен для клиента.
+func EncodeCursor(cursor dom.PostCursor) string {
+	prefix := cursorNextPrefix
+	if cursor.Direction() == dom.CursorPrev {
+		prefix = cursorPrevPrefix
+	}
+
+	raw := fmt.Sprintf("%s:%d:%d", prefix, cursor.PubTime(), cursor.ID().Value())
+
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+// DecodeCursor декодирует токен курсора. Возвращает dom.ErrInvalidCursor, если токен поврежден.
+func DecodeCursor(token string) (dom.PostCursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return dom.PostCursor{}, dom.ErrInvalidCursor
+	}
+
+	parts := strings.Split(string(raw), ":")
+	if len(parts) != 3 {
+		return dom.PostCursor{}, dom.ErrInvalidCursor
+	}
+
+	var direction dom.CursorDirection
+	switch parts[0] {
+	case cursorNextPrefix:
+		direction = dom.CursorNext
+	case cursorPrevPrefix:
+		direction = dom.CursorPrev
+	default:
+		return dom.PostCursor{}, dom.ErrInvalidCursor
+	}
+
+	pubTime, err := strconv.ParseInt(parts[1], 10, 64)
+	if err != nil {
+		return dom.PostCursor{}, dom.ErrInvalidCursor
+	}
+
+	id, err := strconv.ParseInt(parts[2], 10, 32)
+	if err != nil {
+		return dom.PostCursor{}, dom.ErrInvalidCursor
+	}
+
+	cursor, err := dom.NewPostCursor(pubTime, int32(id), direction)
+	if err != nil {
+		return dom.PostCursor{}, dom.ErrInvalidCursor
+	}
+
+	return cursor, nil
+}
@@ -0,0 +1,35 @@
+package post
+
+import (
+	"errors"
+	"testing"
+
+	dom "github.com/ee-crocush/go-news/go-news/internal/domain/post"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	for _, direction := range []dom.CursorDirection{dom.CursorNext, dom.CursorPrev} {
+		cursor, err := dom.NewPostCursor(1739296800, 42, direction)
+		if err != nil {
+			t.Fatalf("NewPostCursor: %v", err)
+		}
+
+		got, err := DecodeCursor(EncodeCursor(cursor))
+		if err != nil {
+			t.Fatalf("DecodeCursor: %v", err)
+		}
+		if got != cursor {
+			t.Errorf("DecodeCursor() = %+v, want %+v", got, cursor)
+		}
+	}
+}
+
+func TestDecodeCursor_Invalid(t *testing.T) {
+	tokens := []string{"", "!!!", "eDoxOjI", "bjoxOmFiYw", "bjoxOjA"}
+
+	for _, token := range tokens {
+		if _, err := DecodeCursor(token); !errors.Is(err, dom.ErrInvalidCursor) {
+			t.Errorf("DecodeCursor(%q) error = %v, want ErrInvalidCursor", token, err)
+		}
+	}
+}
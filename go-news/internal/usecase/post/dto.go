@@ -10,10 +10,94 @@ import (
 
 // ParsedRSSDTO представляет данные, извлечённые из RSS.
 type ParsedRSSDTO struct {
+	GUID    string `json:"guid"`
 	Title   string `json:"title"`
 	Content string `json:"content"`
-	Link    string `json:"link"`
-	PubTime int64  `json:"pub_time"`
+	// ContentHTML - описание записи в виде очищенного HTML, Content - то же описание простым текстом.
+	ContentHTML string     `json:"content_html"`
+	Link        string     `json:"link"`
+	PubTime     int64      `json:"pub_time"`
+	Media       []MediaDTO `json:"media"`
+	// Tags - категории записи ленты без нормализации.
+	Tags []string `json:"tags"`
+}
+
+// MediaDTO представляет медиа-вложение записи ленты или новости.
+// Size задается в байтах, Width и Height - в пикселях, нулевые значения означают, что они неизвестны.
+type MediaDTO struct {
+	URL       string `json:"url"`
+	Type      string `json:"type,omitempty"`
+	Size      int64  `json:"size,omitempty"`
+	Width     int    `json:"width,omitempty"`
+	Height    int    `json:"height,omitempty"`
+	Thumbnail bool   `json:"thumbnail,omitempty"`
+}
+
+// ClassifyInputDTO представляет текст новости для рубрикатора.
+type ClassifyInputDTO struct {
+	Title string
+	// Content - полный текст статьи, если он загружался, иначе описание из ленты.
+	Content string
+	Tags    []string
+}
+
+// TrainingExampleDTO представляет новость с рубриками, проставленными вручную, для обучения рубрикатора.
+type TrainingExampleDTO struct {
+	ClassifyInputDTO
+	Categories []string
+}
+
+// TrainStatsDTO представляет результат обучения рубрикатора.
+type TrainStatsDTO struct {
+	// Examples - количество примеров, на которых обучена модель.
+	Examples int
+	// Categories - рубрики модели.
+	Categories []CategoryModelDTO
+}
+
+// CategoryModelDTO представляет рубрику обученной модели.
+type CategoryModelDTO struct {
+	Category string `json:"category"`
+	// Examples - количество примеров рубрики.
+	Examples int `json:"examples"`
+	// Active - примеров достаточно, чтобы модель назначала рубрику.
+	Active bool `json:"active"`
+}
+
+// ParseRequestDTO представляет запрос на получение ленты.
+// ETag и LastModified - валидаторы кэша предыдущего ответа для условного GET-запроса.
+type ParseRequestDTO struct {
+	URL          string
+	ETag         string
+	LastModified string
+}
+
+// ChannelDTO представляет метаданные канала ленты.
+type ChannelDTO struct {
+	Title string
+	Link  string
+}
+
+// ParseResultDTO представляет результат получения ленты.
+type ParseResultDTO struct {
+	Items        []ParsedRSSDTO
+	Channel      ChannelDTO
+	ETag         string
+	LastModified string
+	// NotModified выставляется, если источник ответил 304 Not Modified.
+	NotModified bool
+	// Warnings - предупреждения о пропущенных записях (например, с неразборчивой датой публикации).
+	Warnings []string
+	// StatusCode - HTTP статус ответа источника, 0 - если ответ не получен.
+	StatusCode int
+}
+
+// ArticleDTO представляет статью, извлеченную со страницы новости.
+type ArticleDTO struct {
+	// Body - основной текст статьи, абзацы разделены пустой строкой.
+	Body string
+	// Image - абсолютная ссылка на главное изображение статьи.
+	Image string
 }
 
 // ParseAndStoreInputDTO представляет входной DTO для парасинга новостей.
@@ -34,25 +118,213 @@ func (p *ParseAndStoreInputDTO) Validate() error {
 	return nil
 }
 
+// ParseAndStoreOutputDTO представляет результат парсинга и сохранения ленты.
+type ParseAndStoreOutputDTO struct {
+	// Seen - количество записей, полученных из ленты.
+	Seen        int  `json:"seen"`
+	Inserted    int  `json:"inserted"`
+	Skipped     int  `json:"skipped"`
+	NotModified bool `json:"not_modified"`
+	// ArticlesFailed - количество новостей, сохраненных без полного текста из-за ошибки загрузки статьи.
+	ArticlesFailed int `json:"articles_failed"`
+	// Warnings - предупреждения парсера о пропущенных записях (например, с неразборчивой датой).
+	Warnings []string `json:"warnings,omitempty"`
+	// Clustered - количество новых новостей, объединенных в кластер с ранее сохраненными почти одинаковыми.
+	Clustered int `json:"clustered"`
+}
+
 // FindByIDInputDTO представляет входной DTO для поиска поста по ID.
 type FindByIDInputDTO struct {
 	ID int32 `json:"id"`
+	// Format - формат содержания: text (по умолчанию) или html.
+	Format string `json:"format"`
 }
 
 // FindAllInputDTO представляет входной DTO для поиска поста по параметрам.
 type FindAllInputDTO struct {
 	Search string
+	Source string
+	// From, To - границы даты публикации включительно: RFC3339 или дата YYYY-MM-DD (для To - до конца дня, UTC).
+	From string
+	To   string
+	// HasComments - отбор по наличию комментариев: true, false или пусто (без отбора).
+	HasComments string
+	// Tag - отбор по тегу, нормализуется так же, как теги новостей.
+	Tag string
+	// Category - отбор по рубрике.
+	Category string
+	// Sort - порядок сортировки: relevance или date (по умолчанию relevance при поиске).
+	Sort string
+	// Highlight - добавить в ответ фрагменты с подсветкой совпадений с поисковым запросом.
+	Highlight bool
+	// Collapse - схлопнуть кластеры почти одинаковых новостей до одной новости.
+	Collapse bool
+	// Cursor - непрозрачный токен keyset-пагинации (next_cursor/prev_cursor предыдущего ответа).
+	// Если указан, Page не используется и общее количество не подсчитывается.
+	Cursor string
 	Limit  int
 	Page   int
 }
 
+// FindAllOutputDTO представляет выходной DTO поиска новостей.
+type FindAllOutputDTO struct {
+	Posts []PostDTO
+	// Total - общее количество новостей, только при постраничной пагинации (без курсора).
+	Total *int32
+	// NextCursor - токен следующей (более старой) страницы, пустой, если страница последняя.
+	NextCursor string
+	// PrevCursor - токен предыдущей (более свежей) страницы, пустой, если страница первая.
+	PrevCursor string
+}
+
 // PostDTO представляет выходной DTO поста.
 type PostDTO struct {
-	ID      int32  `json:"id"`
-	Title   string `json:"title"`
-	Content string `json:"content"`
-	Link    string `json:"link"`
-	PubTime string `json:"pub_time"`
+	ID      int32      `json:"id"`
+	Title   string     `json:"title"`
+	Content string     `json:"content"`
+	Link    string     `json:"link"`
+	PubTime string     `json:"pub_time"`
+	Source  *SourceDTO `json:"source,omitempty"`
+	// Image - главное изображение статьи (для источников с загрузкой полного текста).
+	Image string `json:"image,omitempty"`
+	// Body - полный текст статьи, только в ответах с одной новостью.
+	Body string `json:"body,omitempty"`
+	// Media - медиа-вложения новости.
+	Media []MediaDTO `json:"media,omitempty"`
+	// Tags - теги новости.
+	Tags []string `json:"tags,omitempty"`
+	// Categories - рубрики новости.
+	Categories []string `json:"categories,omitempty"`
+	// CommentsCount - количество опубликованных комментариев.
+	CommentsCount int64 `json:"comments_count"`
+	// ClusterID - ID первой новости кластера почти одинаковых новостей.
+	ClusterID int32 `json:"cluster_id,omitempty"`
+	// ClusterSize - количество новостей кластера в выдаче, только при схлопывании кластеров.
+	ClusterSize int64 `json:"cluster_size,omitempty"`
+	// Highlights заполняется только при поиске с подсветкой.
+	Highlights *HighlightDTO `json:"highlights,omitempty"`
+}
+
+// HighlightDTO представляет фрагменты новости с подсветкой совпадений (HTML с тегами <mark>).
+type HighlightDTO struct {
+	Title   string `json:"title,omitempty"`
+	Content string `json:"content,omitempty"`
+}
+
+// SourceDTO представляет источник новости.
+type SourceDTO struct {
+	FeedURL  string `json:"feed_url"`
+	Title    string `json:"title"`
+	SiteLink string `json:"site_link"`
+}
+
+// mapSource мапит источник новости в DTO. Для новостей без источника возвращает nil.
+func mapSource(source dom.PostSource) *SourceDTO {
+	if source.IsZero() {
+		return nil
+	}
+
+	return &SourceDTO{
+		FeedURL:  source.FeedURL(),
+		Title:    source.Title(),
+		SiteLink: source.SiteLink(),
+	}
+}
+
+func mapMedia(media []dom.PostMedia) []MediaDTO {
+	if len(media) == 0 {
+		return nil
+	}
+
+	out := make([]MediaDTO, 0, len(media))
+	for _, m := range media {
+		out = append(
+			out, MediaDTO{
+				URL:       m.URL(),
+				Type:      m.MIMEType(),
+				Size:      m.Size(),
+				Width:     m.Width(),
+				Height:    m.Height(),
+				Thumbnail: m.Thumbnail(),
+			},
+		)
+	}
+
+	return out
+}
+
+func mapCategories(categories []dom.PostCategory) []string {
+	if len(categories) == 0 {
+		return nil
+	}
+
+	out := make([]string, 0, len(categories))
+	for _, category := range categories {
+		out = append(out, category.Value())
+	}
+
+	return out
+}
+
+func mapTags(tags []dom.PostTag) []string {
+	if len(tags) == 0 {
+		return nil
+	}
+
+	out := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		out = append(out, tag.Value())
+	}
+
+	return out
+}
+
+// FindTagsInputDTO входные данные для получения самых частых тегов.
+type FindTagsInputDTO struct {
+	Limit int
+}
+
+// Validate проверяет входные данные для получения тегов.
+func (f *FindTagsInputDTO) Validate() {
+	if f.Limit <= 0 {
+		f.Limit = 50
+	}
+	if f.Limit > 500 {
+		f.Limit = 500
+	}
+}
+
+// CategoryDTO представляет рубрику и количество новостей в ней.
+type CategoryDTO struct {
+	Category string `json:"category"`
+	Count    int64  `json:"count"`
+}
+
+// CategoryLabelDTO представляет рубрики новости, проставленные вручную.
+type CategoryLabelDTO struct {
+	PostID     int32    `json:"post_id"`
+	Categories []string `json:"categories"`
+}
+
+// TrainCategoriesInputDTO представляет входной DTO обучения рубрикатора.
+type TrainCategoriesInputDTO struct {
+	// Labels - новые ручные разметки; без них модель переобучается на уже размеченных новостях.
+	Labels []CategoryLabelDTO `json:"labels"`
+}
+
+// TrainCategoriesOutputDTO представляет результат обучения рубрикатора.
+type TrainCategoriesOutputDTO struct {
+	// Labeled - количество размеченных в запросе новостей.
+	Labeled int `json:"labeled"`
+	// Examples - количество примеров, на которых обучена модель.
+	Examples   int                `json:"examples"`
+	Categories []CategoryModelDTO `json:"categories"`
+}
+
+// TagDTO представляет тег и количество новостей с ним.
+type TagDTO struct {
+	Tag   string `json:"tag"`
+	Count int64  `json:"count"`
 }
 
 // FindLatestInputDTO входные данные для поиска последних n новостей.
@@ -75,6 +347,7 @@ func (f *FindLatestInputDTO) Validate() {
 	}
 }
 
+// ContentLimit - максимальная длина содержания новости в списках в символах.
 const ContentLimit = 300
 
 // MapPostsToDTO мапит слайс доменных постов в слайс DTO.
@@ -83,17 +356,35 @@ func MapPostsToDTO(posts []*dom.Post) []PostDTO {
 	for _, post := range posts {
 		postsDTO = append(
 			postsDTO, PostDTO{
-				ID:      post.ID().Value(),
-				Title:   post.Title().Value(),
-				Content: truncateContent(post.Content().Value(), ContentLimit),
-				Link:    post.Link().Value(),
-				PubTime: post.PubTime().String(),
+				ID:            post.ID().Value(),
+				Title:         post.Title().Value(),
+				Content:       listContent(post),
+				Link:          post.Link().Value(),
+				PubTime:       post.PubTime().String(),
+				Source:        mapSource(post.Source()),
+				Image:         post.Article().Image(),
+				Media:         mapMedia(post.Media()),
+				Tags:          mapTags(post.Tags()),
+				Categories:    mapCategories(post.Categories()),
+				CommentsCount: post.CommentsCount(),
+				ClusterID:     post.ClusterID(),
+				ClusterSize:   post.ClusterSize(),
 			},
 		)
 	}
 	return postsDTO
 }
 
+// listContent возвращает содержание новости для списков: краткое содержание, а для новостей без него -
+// содержание, обрезанное до ContentLimit символов.
+func listContent(post *dom.Post) string {
+	if summary := post.Summary(); !summary.IsZero() {
+		return summary.Value()
+	}
+
+	return truncateContent(post.Content().Value(), ContentLimit)
+}
+
 func truncateContent(content string, limit int) string {
 	if utf8.RuneCountInString(content) <= limit {
 		return content
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	dom "github.com/ee-crocush/go-news/go-news/internal/domain/post"
+	"time"
 )
 
 var _ FindAllContract = (*FindAllUseCase)(nil)
@@ -19,13 +20,218 @@ func NewFindAllUseCase(repo dom.Repository) *FindAllUseCase {
 }
 
 // Execute выполняет бизнес-логику поиска всех новостей.
-func (uc *FindAllUseCase) Execute(ctx context.Context, in FindAllInputDTO) ([]PostDTO, int32, error) {
+// С курсором используется keyset-пагинация, иначе - постраничная (page/limit) с подсчетом общего количества.
+// Курсоры соседних страниц возвращаются в обоих режимах, если лента отсортирована по дате.
+func (uc *FindAllUseCase) Execute(ctx context.Context, in FindAllInputDTO) (FindAllOutputDTO, error) {
+	sortValue := in.Sort
+	if in.Cursor != "" && sortValue == "" {
+		// Курсор однозначно задает позицию только в ленте по дате.
+		sortValue = string(dom.SortByDate)
+	}
+
+	sort, err := dom.NewPostSort(sortValue, in.Search != "")
+	if err != nil {
+		return FindAllOutputDTO{}, fmt.Errorf("FindAllUseCase.NewPostSort: %w", err)
+	}
+
+	filter, err := buildFilter(in, sort)
+	if err != nil {
+		return FindAllOutputDTO{}, err
+	}
+
+	var out FindAllOutputDTO
+	var posts []*dom.Post
+
+	if in.Cursor != "" {
+		posts, out, err = uc.findByCursor(ctx, filter, in)
+	} else {
+		posts, out, err = uc.findPage(ctx, filter, in)
+	}
+	if err != nil {
+		return FindAllOutputDTO{}, err
+	}
+
+	out.Posts = MapPostsToDTO(posts)
+
+	if in.Highlight && in.Search != "" {
+		stems := newSearchStems(in.Search)
+		for i, post := range posts {
+			out.Posts[i].Highlights = highlightPost(post, stems)
+		}
+	}
+
+	return out, nil
+}
+
+// buildFilter проверяет параметры отбора и формирует доменный фильтр.
+func buildFilter(in FindAllInputDTO, sort dom.PostSort) (dom.PostFilter, error) {
+	from, err := parseTimeBound(in.From, false)
+	if err != nil {
+		return dom.PostFilter{}, fmt.Errorf("FindAllUseCase.parseTimeBound: %w", err)
+	}
+
+	to, err := parseTimeBound(in.To, true)
+	if err != nil {
+		return dom.PostFilter{}, fmt.Errorf("FindAllUseCase.parseTimeBound: %w", err)
+	}
+
+	pubTime, err := dom.NewPubTimeRange(from, to)
+	if err != nil {
+		return dom.PostFilter{}, fmt.Errorf("FindAllUseCase.NewPubTimeRange: %w", err)
+	}
+
+	comments, err := dom.NewCommentsFilter(in.HasComments)
+	if err != nil {
+		return dom.PostFilter{}, fmt.Errorf("FindAllUseCase.NewCommentsFilter: %w", err)
+	}
+
+	var tag dom.PostTag
+	if in.Tag != "" {
+		if tag, err = dom.NewPostTag(in.Tag); err != nil {
+			return dom.PostFilter{}, fmt.Errorf("FindAllUseCase.NewPostTag: %w", err)
+		}
+	}
+
+	var category dom.PostCategory
+	if in.Category != "" {
+		if category, err = dom.NewPostCategory(in.Category); err != nil {
+			return dom.PostFilter{}, fmt.Errorf("FindAllUseCase.NewPostCategory: %w", err)
+		}
+	}
+
+	return dom.PostFilter{
+		Search:   in.Search,
+		Source:   in.Source,
+		PubTime:  pubTime,
+		Comments: comments,
+		Tag:      tag,
+		Category: category,
+		Sort:     sort,
+		Collapse: in.Collapse,
+	}, nil
+}
+
+// parseTimeBound разбирает границу интервала дат: RFC3339 или дата YYYY-MM-DD (UTC).
+// Дата в качестве верхней границы означает конец дня.
+func parseTimeBound(value string, end bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	t, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, dom.ErrInvalidPubTimeRange
+	}
+
+	if end {
+		t = t.Add(24*time.Hour - time.Second)
+	}
+
+	return t, nil
+}
+
+// findPage получает страницу новостей по номеру.
+func (uc *FindAllUseCase) findPage(
+	ctx context.Context, filter dom.PostFilter, in FindAllInputDTO,
+) ([]*dom.Post, FindAllOutputDTO, error) {
 	offset := (in.Page - 1) * in.Limit
 
-	posts, total, err := uc.repo.FindAll(ctx, in.Search, in.Limit, offset)
+	posts, total, err := uc.repo.FindAll(ctx, filter, in.Limit, offset)
+	if err != nil {
+		return nil, FindAllOutputDTO{}, fmt.Errorf("FindAllUseCase.Execute: %w", err)
+	}
+
+	out := FindAllOutputDTO{Total: &total}
+
+	if len(posts) == 0 || !keysetSupported(filter) {
+		return posts, out, nil
+	}
+
+	if offset+len(posts) < int(total) {
+		out.NextCursor = EncodeCursor(dom.CursorFromPost(posts[len(posts)-1], dom.CursorNext))
+	}
+	if offset > 0 {
+		out.PrevCursor = EncodeCursor(dom.CursorFromPost(posts[0], dom.CursorPrev))
+	}
+
+	return posts, out, nil
+}
+
+// findByCursor получает страницу новостей после курсора. Запрашивается на одну новость больше,
+// чтобы без подсчета общего количества узнать, есть ли следующая страница в направлении листания.
+func (uc *FindAllUseCase) findByCursor(
+	ctx context.Context, filter dom.PostFilter, in FindAllInputDTO,
+) ([]*dom.Post, FindAllOutputDTO, error) {
+	if !keysetSupported(filter) {
+		return nil, FindAllOutputDTO{}, fmt.Errorf("FindAllUseCase.Execute: %w", dom.ErrCursorSort)
+	}
+
+	cursor, err := DecodeCursor(in.Cursor)
 	if err != nil {
-		return []PostDTO{}, 0, fmt.Errorf("FindAllUseCase.Execute: %w", err)
+		return nil, FindAllOutputDTO{}, fmt.Errorf("FindAllUseCase.DecodeCursor: %w", err)
+	}
+
+	posts, err := uc.repo.FindByCursor(ctx, filter, cursor, in.Limit+1)
+	if err != nil {
+		return nil, FindAllOutputDTO{}, fmt.Errorf("FindAllUseCase.Execute: %w", err)
+	}
+
+	hasMore := len(posts) > in.Limit
+	if hasMore {
+		if cursor.Direction() == dom.CursorPrev {
+			// Лишняя новость - самая свежая, она в начале ленты.
+			posts = posts[len(posts)-in.Limit:]
+		} else {
+			posts = posts[:in.Limit]
+		}
+	}
+
+	var out FindAllOutputDTO
+	if len(posts) == 0 {
+		return posts, out, nil
+	}
+
+	first := EncodeCursor(dom.CursorFromPost(posts[0], dom.CursorPrev))
+	last := EncodeCursor(dom.CursorFromPost(posts[len(posts)-1], dom.CursorNext))
+
+	// В сторону, откуда пришел клиент, новости есть всегда.
+	if cursor.Direction() == dom.CursorPrev {
+		out.NextCursor = last
+		if hasMore {
+			out.PrevCursor = first
+		}
+	} else {
+		out.PrevCursor = first
+		if hasMore {
+			out.NextCursor = last
+		}
+	}
+
+	return posts, out, nil
+}
+
+// keysetSupported сообщает, поддерживает ли порядок ленты keyset-пагинацию.
+// При поиске с сортировкой по релевантности позиция новости не определяется датой и ID.
+func keysetSupported(filter dom.PostFilter) bool {
+	return filter.Sort != dom.SortByRelevance || filter.Search == ""
+}
+
+// highlightPost формирует фрагменты новости с подсветкой. Возвращает nil, если совпадений нет.
+func highlightPost(post *dom.Post, stems []searchStem) *HighlightDTO {
+	var h HighlightDTO
+
+	if title, ok := markMatches(post.Title().Value(), stems); ok {
+		h.Title = title
+	}
+	h.Content = snippet(replaceUnnecessary(post.Content().Value()), stems, SnippetLength)
+
+	if h.Title == "" && h.Content == "" {
+		return nil
 	}
 
-	return MapPostsToDTO(posts), total, nil
+	return &h
 }
@@ -4,19 +4,42 @@ import (
 	"context"
 	"errors"
 	dom "github.com/ee-crocush/go-news/go-news/internal/domain/post"
+	"strconv"
 	"testing"
+	"time"
 )
 
 // mockRepository implements Repository for testing
 type mockRepository struct {
-	posts []*dom.Post
-	err   error
+	// dom.Repository встроен, чтобы не реализовывать методы, не используемые в тесте
+	dom.Repository
+	posts  []*dom.Post
+	err    error
+	filter dom.PostFilter
+	cursor dom.PostCursor
+	limit  int
 }
 
-func (m *mockRepository) FindAll(ctx context.Context, search string, limit int, offset int) ([]*dom.Post, error) {
+func (m *mockRepository) FindAll(ctx context.Context, filter dom.PostFilter, limit int, offset int) (
+	[]*dom.Post, int32, error,
+) {
+	m.filter = filter
+	if m.err != nil {
+		return nil, 0, m.err
+	}
+	return m.posts, int32(len(m.posts)), nil
+}
+
+func (m *mockRepository) FindByCursor(
+	ctx context.Context, filter dom.PostFilter, cursor dom.PostCursor, limit int,
+) ([]*dom.Post, error) {
+	m.filter, m.cursor, m.limit = filter, cursor, limit
 	if m.err != nil {
 		return nil, m.err
 	}
+	if len(m.posts) > limit {
+		return m.posts[:limit], nil
+	}
 	return m.posts, nil
 }
 
@@ -78,7 +101,8 @@ func TestFindAllUseCase_Execute(t *testing.T) {
 				Limit:  10,
 				Page:   0,
 			}
-			result, err := useCase.Execute(ctx, in)
+			out, err := useCase.Execute(ctx, in)
+			result := out.Posts
 
 			if err != nil {
 				t.Errorf("expected no error, got %v", err)
@@ -120,7 +144,8 @@ func TestFindAllUseCase_Execute(t *testing.T) {
 				Limit:  10,
 				Page:   0,
 			}
-			result, err := useCase.Execute(ctx, in)
+			out, err := useCase.Execute(ctx, in)
+			result := out.Posts
 
 			if err == nil {
 				t.Error("expected error, got nil")
@@ -151,7 +176,8 @@ func TestFindAllUseCase_Execute(t *testing.T) {
 				Limit:  10,
 				Page:   0,
 			}
-			result, err := useCase.Execute(ctx, in)
+			out, err := useCase.Execute(ctx, in)
+			result := out.Posts
 
 			if err != nil {
 				t.Errorf("expected no error, got %v", err)
@@ -182,3 +208,222 @@ func TestFindAllUseCase_Execute(t *testing.T) {
 		},
 	)
 }
+
+func TestFindAllUseCase_Execute_Filter(t *testing.T) {
+	repo := &mockRepository{}
+	useCase := NewFindAllUseCase(repo)
+
+	in := FindAllInputDTO{Search: "go", Source: "https://example.com/rss", Limit: 10, Page: 1}
+	if _, err := useCase.Execute(context.Background(), in); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	want := dom.PostFilter{Search: "go", Source: "https://example.com/rss", Sort: dom.SortByRelevance}
+	if repo.filter != want {
+		t.Errorf("filter = %+v, want %+v", repo.filter, want)
+	}
+
+	in.Sort = "date"
+	if _, err := useCase.Execute(context.Background(), in); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.filter.Sort != dom.SortByDate {
+		t.Errorf("filter.Sort = %q, want %q", repo.filter.Sort, dom.SortByDate)
+	}
+
+	in.Sort = "popularity"
+	if _, err := useCase.Execute(context.Background(), in); !errors.Is(err, dom.ErrInvalidPostSort) {
+		t.Errorf("expected ErrInvalidPostSort, got %v", err)
+	}
+}
+
+func TestFindAllUseCase_Execute_Criteria(t *testing.T) {
+	repo := &mockRepository{}
+	useCase := NewFindAllUseCase(repo)
+
+	in := FindAllInputDTO{
+		From: "2025-01-01", To: "2025-01-07", HasComments: "true", Tag: " #Go ", Limit: 10, Page: 1,
+	}
+	if _, err := useCase.Execute(context.Background(), in); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	from := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 7, 23, 59, 59, 0, time.UTC)
+	if !repo.filter.PubTime.From().Equal(from) || !repo.filter.PubTime.To().Equal(to) {
+		t.Errorf("PubTime = %v..%v, want %v..%v", repo.filter.PubTime.From(), repo.filter.PubTime.To(), from, to)
+	}
+	if repo.filter.Comments != dom.CommentsWith {
+		t.Errorf("Comments = %q, want %q", repo.filter.Comments, dom.CommentsWith)
+	}
+	if repo.filter.Tag.Value() != "go" {
+		t.Errorf("Tag = %q, want %q", repo.filter.Tag.Value(), "go")
+	}
+
+	in = FindAllInputDTO{From: "2025-01-01T10:00:00+03:00", Limit: 10, Page: 1}
+	if _, err := useCase.Execute(context.Background(), in); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := time.Date(2025, 1, 1, 7, 0, 0, 0, time.UTC); !repo.filter.PubTime.From().Equal(want) {
+		t.Errorf("From = %v, want %v", repo.filter.PubTime.From(), want)
+	}
+	if !repo.filter.PubTime.To().IsZero() {
+		t.Errorf("To must be unbounded, got %v", repo.filter.PubTime.To())
+	}
+
+	testCases := []struct {
+		name    string
+		in      FindAllInputDTO
+		wantErr error
+	}{
+		{name: "bad from", in: FindAllInputDTO{From: "01.01.2025"}, wantErr: dom.ErrInvalidPubTimeRange},
+		{name: "to before from", in: FindAllInputDTO{From: "2025-02-01", To: "2025-01-01"}, wantErr: dom.ErrInvalidPubTimeRange},
+		{name: "bad has_comments", in: FindAllInputDTO{HasComments: "maybe"}, wantErr: dom.ErrInvalidCommentsFilter},
+		{name: "bad tag", in: FindAllInputDTO{Tag: "#"}, wantErr: dom.ErrInvalidPostTag},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				tc.in.Limit, tc.in.Page = 10, 1
+				if _, err := useCase.Execute(context.Background(), tc.in); !errors.Is(err, tc.wantErr) {
+					t.Errorf("expected %v, got %v", tc.wantErr, err)
+				}
+			},
+		)
+	}
+}
+
+func TestFindAllUseCase_Execute_Highlight(t *testing.T) {
+	post1, _ := dom.NewPost("Вышел Go 1.24", "Релиз Go 1.24 уже доступен.", "https://example.com/1", 1)
+	post2, _ := dom.NewPost("Обзор недели", "Без совпадений.", "https://example.com/2", 1)
+
+	repo := &mockRepository{posts: []*dom.Post{post1, post2}}
+	useCase := NewFindAllUseCase(repo)
+
+	in := FindAllInputDTO{Search: "релиз", Limit: 10, Page: 1, Highlight: true}
+	out, err := useCase.Execute(context.Background(), in)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	result := out.Posts
+
+	if result[0].Highlights == nil || result[0].Highlights.Content != "<mark>Релиз</mark> Go 1.24 уже доступен." {
+		t.Errorf("unexpected highlights: %+v", result[0].Highlights)
+	}
+	if result[0].Highlights.Title != "" {
+		t.Errorf("title without matches must not be highlighted, got %q", result[0].Highlights.Title)
+	}
+	if result[1].Highlights != nil {
+		t.Errorf("post without matches must have no highlights, got %+v", result[1].Highlights)
+	}
+
+	in.Highlight = false
+	out, _ = useCase.Execute(context.Background(), in)
+	result = out.Posts
+	if result[0].Highlights != nil {
+		t.Error("highlights must be omitted when not requested")
+	}
+}
+
+func newTestPosts(t *testing.T, n int) []*dom.Post {
+	t.Helper()
+
+	posts := make([]*dom.Post, 0, n)
+	for i := n; i >= 1; i-- {
+		id, _ := dom.NewPostID(int32(i))
+		post, err := dom.NewPost("Title", "Content", "https://example.com/"+strconv.Itoa(i), int64(1000+i))
+		if err != nil {
+			t.Fatalf("NewPost: %v", err)
+		}
+		post.SetID(id)
+		posts = append(posts, post)
+	}
+
+	return posts
+}
+
+func TestFindAllUseCase_Execute_PageCursors(t *testing.T) {
+	// Лента из 3 новостей, страница по 2: в моке FindAll возвращает все новости, total = 3.
+	repo := &mockRepository{posts: newTestPosts(t, 3)[:2]}
+	useCase := NewFindAllUseCase(repo)
+
+	out, err := useCase.Execute(context.Background(), FindAllInputDTO{Limit: 2, Page: 1})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if out.Total == nil || *out.Total != 2 {
+		t.Fatalf("expected total in page mode, got %v", out.Total)
+	}
+	if out.NextCursor != "" || out.PrevCursor != "" {
+		t.Errorf("single page must have no cursors, got next=%q prev=%q", out.NextCursor, out.PrevCursor)
+	}
+
+	out, _ = useCase.Execute(context.Background(), FindAllInputDTO{Limit: 1, Page: 2})
+	if out.PrevCursor == "" {
+		t.Error("expected prev cursor on the second page")
+	}
+
+	// Постраничный режим с поиском по релевантности не выдает курсоров.
+	out, _ = useCase.Execute(context.Background(), FindAllInputDTO{Search: "go", Limit: 1, Page: 2})
+	if out.NextCursor != "" || out.PrevCursor != "" {
+		t.Errorf("relevance sort must have no cursors, got next=%q prev=%q", out.NextCursor, out.PrevCursor)
+	}
+}
+
+func TestFindAllUseCase_Execute_Cursor(t *testing.T) {
+	posts := newTestPosts(t, 5)
+	repo := &mockRepository{posts: posts[1:]}
+	useCase := NewFindAllUseCase(repo)
+
+	token := EncodeCursor(dom.CursorFromPost(posts[0], dom.CursorNext))
+	out, err := useCase.Execute(context.Background(), FindAllInputDTO{Cursor: token, Limit: 2})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if repo.limit != 3 {
+		t.Errorf("expected limit+1 to be requested, got %d", repo.limit)
+	}
+	if repo.cursor.ID().Value() != 5 || repo.cursor.Direction() != dom.CursorNext {
+		t.Errorf("unexpected cursor passed to repository: %+v", repo.cursor)
+	}
+	if repo.filter.Sort != dom.SortByDate {
+		t.Errorf("cursor mode must sort by date, got %q", repo.filter.Sort)
+	}
+	if out.Total != nil {
+		t.Error("total must not be counted in cursor mode")
+	}
+	if len(out.Posts) != 2 || out.Posts[0].ID != 4 || out.Posts[1].ID != 3 {
+		t.Fatalf("unexpected page: %+v", out.Posts)
+	}
+
+	next, err := DecodeCursor(out.NextCursor)
+	if err != nil || next.ID().Value() != 3 || next.Direction() != dom.CursorNext {
+		t.Errorf("unexpected next cursor: %+v, %v", next, err)
+	}
+	prev, err := DecodeCursor(out.PrevCursor)
+	if err != nil || prev.ID().Value() != 4 || prev.Direction() != dom.CursorPrev {
+		t.Errorf("unexpected prev cursor: %+v, %v", prev, err)
+	}
+
+	// Последняя страница.
+	repo.posts = posts[3:]
+	out, _ = useCase.Execute(context.Background(), FindAllInputDTO{Cursor: out.NextCursor, Limit: 2})
+	if out.NextCursor != "" || out.PrevCursor == "" {
+		t.Errorf("last page: next=%q prev=%q", out.NextCursor, out.PrevCursor)
+	}
+
+	if _, err = useCase.Execute(
+		context.Background(), FindAllInputDTO{Cursor: "bm90LWEtY3Vyc29y", Limit: 2},
+	); !errors.Is(err, dom.ErrInvalidCursor) {
+		t.Errorf("expected ErrInvalidCursor, got %v", err)
+	}
+
+	if _, err = useCase.Execute(
+		context.Background(), FindAllInputDTO{Cursor: token, Search: "go", Sort: "relevance", Limit: 2},
+	); !errors.Is(err, dom.ErrCursorSort) {
+		t.Errorf("expected ErrCursorSort, got %v", err)
+	}
+}
@@ -3,6 +3,8 @@ package post
 import (
 	"context"
 	"fmt"
+	"html"
+	"regexp"
 	"strings"
 
 	dom "github.com/ee-crocush/go-news/go-news/internal/domain/post"
@@ -27,22 +29,68 @@ func (uc *FindByIDUseCase) Execute(ctx context.Context, in FindByIDInputDTO) (Po
 		return PostDTO{}, fmt.Errorf("FindByIDUseCase.NewPostID: %w", err)
 	}
 
+	format, err := dom.NewContentFormat(in.Format)
+	if err != nil {
+		return PostDTO{}, fmt.Errorf("FindByIDUseCase.NewContentFormat: %w", err)
+	}
+
 	post, err := uc.repo.FindByID(ctx, postID)
 	if err != nil {
 		return PostDTO{}, fmt.Errorf("findByIDUseCase.FindByID: %w", err)
 	}
 
 	return PostDTO{
-		ID:      post.ID().Value(),
-		Title:   post.Title().Value(),
-		Content: replaceUnnecessary(post.Content().Value()),
-		Link:    post.Link().Value(),
-		PubTime: post.PubTime().String(),
+		ID:            post.ID().Value(),
+		Title:         post.Title().Value(),
+		Content:       formatContent(post, format),
+		Link:          post.Link().Value(),
+		PubTime:       post.PubTime().String(),
+		Source:        mapSource(post.Source()),
+		Image:         post.Article().Image(),
+		Body:          post.Article().Body(),
+		Media:         mapMedia(post.Media()),
+		Tags:          mapTags(post.Tags()),
+		Categories:    mapCategories(post.Categories()),
+		CommentsCount: post.CommentsCount(),
+		ClusterID:     post.ClusterID(),
 	}, nil
 }
 
 const UnnecessaryWords = "Читать далее"
 
+// unnecessaryLinkRe находит ссылку "Читать далее" в HTML-содержании.
+var unnecessaryLinkRe = regexp.MustCompile(`<a\b[^>]*>\s*` + UnnecessaryWords + `\s*</a>`)
+
+// formatContent возвращает содержание новости в запрошенном формате.
+// Для новостей без HTML-версии она строится из текста: абзацы оборачиваются в <p>.
+func formatContent(post *dom.Post, format dom.ContentFormat) string {
+	if format != dom.FormatHTML {
+		return replaceUnnecessary(post.Content().Value())
+	}
+
+	if contentHTML := post.ContentHTML(); !contentHTML.IsZero() {
+		return strings.TrimSpace(unnecessaryLinkRe.ReplaceAllString(contentHTML.Value(), ""))
+	}
+
+	return textToHTML(replaceUnnecessary(post.Content().Value()))
+}
+
+// textToHTML экранирует текст и оформляет абзацы, разделенные пустой строкой, тегами <p>.
+func textToHTML(text string) string {
+	var b strings.Builder
+	for _, paragraph := range strings.Split(text, "\n\n") {
+		paragraph = strings.TrimSpace(paragraph)
+		if paragraph == "" {
+			continue
+		}
+		b.WriteString("<p>")
+		b.WriteString(strings.ReplaceAll(html.EscapeString(paragraph), "\n", "<br/>"))
+		b.WriteString("</p>")
+	}
+
+	return b.String()
+}
+
 func replaceUnnecessary(original string) string {
 	clean := strings.Replace(original, UnnecessaryWords, "", 1)
 
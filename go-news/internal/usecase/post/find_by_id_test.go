@@ -9,6 +9,8 @@ import (
 
 // mockRepositoryForFindByID implements dom.Repository for testing
 type mockRepositoryForFindByID struct {
+	// dom.Repository встроен, чтобы не реализовывать методы, не используемые в тесте
+	dom.Repository
 	post *dom.Post
 	err  error
 }
@@ -20,10 +22,10 @@ func (m *mockRepositoryForFindByID) FindByID(ctx context.Context, postID dom.Pos
 	return m.post, nil
 }
 
-func (m *mockRepositoryForFindByID) FindAll(ctx context.Context, search string, limit int, offset int) (
-	[]*dom.Post, error,
+func (m *mockRepositoryForFindByID) FindAll(ctx context.Context, filter dom.PostFilter, limit int, offset int) (
+	[]*dom.Post, int32, error,
 ) {
-	return nil, nil
+	return nil, 0, nil
 }
 
 func (m *mockRepositoryForFindByID) FindLast(ctx context.Context) (*dom.Post, error) {
@@ -207,3 +209,49 @@ func TestFindByIDUseCase_Execute(t *testing.T) {
 		},
 	)
 }
+
+func TestFindByIDUseCase_Execute_Format(t *testing.T) {
+	postID, _ := dom.NewPostID(1)
+	post, _ := dom.NewPost("Title", "Первый абзац\n\nВторой <абзац> Читать далее", "https://example.com", 1700000000)
+	post.SetID(postID)
+
+	withHTML, _ := dom.NewPost("Title", "Текст", "https://example.com/2", 1700000000)
+	withHTML.SetID(postID)
+	withHTML.SetContentHTML(
+		dom.NewPostContentHTML(`<p>Текст <b>жирный</b></p><a href="https://example.com/2">Читать далее</a>`),
+	)
+
+	tests := []struct {
+		name    string
+		post    *dom.Post
+		format  string
+		want    string
+		wantErr error
+	}{
+		{name: "text by default", post: post, want: "Первый абзац\n\nВторой <абзац>"},
+		{name: "stored html", post: withHTML, format: "html", want: "<p>Текст <b>жирный</b></p>"},
+		{
+			name:   "html built from text",
+			post:   post,
+			format: "html",
+			want:   "<p>Первый абзац</p><p>Второй &lt;абзац&gt;</p>",
+		},
+		{name: "invalid format", post: post, format: "xml", wantErr: dom.ErrInvalidContentFormat},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				useCase := NewFindByIDUseCase(&mockRepositoryForFindByID{post: tt.post})
+
+				result, err := useCase.Execute(context.Background(), FindByIDInputDTO{ID: 1, Format: tt.format})
+				if !errors.Is(err, tt.wantErr) {
+					t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+				}
+				if result.Content != tt.want {
+					t.Errorf("expected content %q, got %q", tt.want, result.Content)
+				}
+			},
+		)
+	}
+}
@@ -0,0 +1,34 @@
+package post
+
+import (
+	"context"
+	"fmt"
+	dom "github.com/ee-crocush/go-news/go-news/internal/domain/post"
+)
+
+var _ FindCategoriesContract = (*FindCategoriesUseCase)(nil)
+
+// FindCategoriesUseCase представляет структуру, реализующую бизнес-логику получения рубрик.
+type FindCategoriesUseCase struct {
+	repo dom.Repository
+}
+
+// NewFindCategoriesUseCase создает новый экземпляр use case для получения рубрик.
+func NewFindCategoriesUseCase(repo dom.Repository) *FindCategoriesUseCase {
+	return &FindCategoriesUseCase{repo: repo}
+}
+
+// Execute выполняет бизнес-логику получения рубрик с количеством новостей.
+func (uc *FindCategoriesUseCase) Execute(ctx context.Context) ([]CategoryDTO, error) {
+	counts, err := uc.repo.CountCategories(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("FindCategoriesUseCase.CountCategories: %w", err)
+	}
+
+	categories := make([]CategoryDTO, 0, len(counts))
+	for _, c := range counts {
+		categories = append(categories, CategoryDTO{Category: c.Category.Value(), Count: c.Count})
+	}
+
+	return categories, nil
+}
@@ -25,10 +25,18 @@ func (uc *FindLastUseCase) Execute(ctx context.Context) (PostDTO, error) {
 	}
 
 	return PostDTO{
-		ID:      post.ID().Value(),
-		Title:   post.Title().Value(),
-		Content: post.Content().Value(),
-		Link:    post.Link().Value(),
-		PubTime: post.PubTime().String(),
+		ID:            post.ID().Value(),
+		Title:         post.Title().Value(),
+		Content:       post.Content().Value(),
+		Link:          post.Link().Value(),
+		PubTime:       post.PubTime().String(),
+		Source:        mapSource(post.Source()),
+		Image:         post.Article().Image(),
+		Body:          post.Article().Body(),
+		Media:         mapMedia(post.Media()),
+		Tags:          mapTags(post.Tags()),
+		Categories:    mapCategories(post.Categories()),
+		CommentsCount: post.CommentsCount(),
+		ClusterID:     post.ClusterID(),
 	}, nil
 }
@@ -9,6 +9,8 @@ import (
 
 // mockRepositoryForFindLast implements dom.Repository for testing
 type mockRepositoryForFindLast struct {
+	// dom.Repository встроен, чтобы не реализовывать методы, не используемые в тесте
+	dom.Repository
 	post *dom.Post
 	err  error
 }
@@ -24,10 +26,10 @@ func (m *mockRepositoryForFindLast) FindByID(ctx context.Context, postID dom.Pos
 	return nil, nil
 }
 
-func (m *mockRepositoryForFindLast) FindAll(ctx context.Context, search string, limit int, offset int) (
-	[]*dom.Post, error,
+func (m *mockRepositoryForFindLast) FindAll(ctx context.Context, filter dom.PostFilter, limit int, offset int) (
+	[]*dom.Post, int32, error,
 ) {
-	return nil, nil
+	return nil, 0, nil
 }
 
 func (m *mockRepositoryForFindLast) FindLatest(ctx context.Context, limit int) ([]*dom.Post, error) {
@@ -9,6 +9,8 @@ import (
 
 // mockRepositoryForFindLatest implements dom.Repository for testing
 type mockRepositoryForFindLatest struct {
+	// dom.Repository встроен, чтобы не реализовывать методы, не используемые в тесте
+	dom.Repository
 	posts []*dom.Post
 	err   error
 }
@@ -24,10 +26,10 @@ func (m *mockRepositoryForFindLatest) FindByID(ctx context.Context, postID dom.P
 	return nil, nil
 }
 
-func (m *mockRepositoryForFindLatest) FindAll(ctx context.Context, search string, limit int, offset int) (
-	[]*dom.Post, error,
+func (m *mockRepositoryForFindLatest) FindAll(ctx context.Context, filter dom.PostFilter, limit int, offset int) (
+	[]*dom.Post, int32, error,
 ) {
-	return nil, nil
+	return nil, 0, nil
 }
 
 func (m *mockRepositoryForFindLatest) FindLast(ctx context.Context) (*dom.Post, error) {
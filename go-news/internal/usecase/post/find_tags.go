@@ -0,0 +1,36 @@
+package post
+
+import (
+	"context"
+	"fmt"
+	dom "github.com/ee-crocush/go-news/go-news/internal/domain/post"
+)
+
+var _ FindTagsContract = (*FindTagsUseCase)(nil)
+
+// FindTagsUseCase представляет структуру, реализующую бизнес-логику получения самых частых тегов.
+type FindTagsUseCase struct {
+	repo dom.Repository
+}
+
+// NewFindTagsUseCase создает новый экземпляр use case для получения самых частых тегов.
+func NewFindTagsUseCase(repo dom.Repository) *FindTagsUseCase {
+	return &FindTagsUseCase{repo: repo}
+}
+
+// Execute выполняет бизнес-логику получения тегов с количеством новостей.
+func (uc *FindTagsUseCase) Execute(ctx context.Context, in FindTagsInputDTO) ([]TagDTO, error) {
+	in.Validate()
+
+	counts, err := uc.repo.CountTags(ctx, in.Limit)
+	if err != nil {
+		return nil, fmt.Errorf("FindTagsUseCase.CountTags: %w", err)
+	}
+
+	tags := make([]TagDTO, 0, len(counts))
+	for _, c := range counts {
+		tags = append(tags, TagDTO{Tag: c.Tag.Value(), Count: c.Count})
+	}
+
+	return tags, nil
+}
@@ -0,0 +1,74 @@
+package post
+
+import (
+	"context"
+	"errors"
+	dom "github.com/ee-crocush/go-news/go-news/internal/domain/post"
+	"testing"
+)
+
+// mockRepositoryForFindTags реализует интерфейс dom.Repository для тестирования
+type mockRepositoryForFindTags struct {
+	// dom.Repository встроен, чтобы не реализовывать методы, не используемые в тесте
+	dom.Repository
+	counts []dom.TagCount
+	err    error
+	limit  int
+}
+
+func (m *mockRepositoryForFindTags) CountTags(ctx context.Context, limit int) ([]dom.TagCount, error) {
+	m.limit = limit
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.counts, nil
+}
+
+func TestFindTagsUseCase_Execute(t *testing.T) {
+	golang, _ := dom.NewPostTag("Go")
+	backend, _ := dom.NewPostTag("Backend")
+
+	t.Run(
+		"successful execution", func(t *testing.T) {
+			repo := &mockRepositoryForFindTags{
+				counts: []dom.TagCount{{Tag: golang, Count: 5}, {Tag: backend, Count: 2}},
+			}
+
+			tags, err := NewFindTagsUseCase(repo).Execute(context.Background(), FindTagsInputDTO{})
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if repo.limit != 50 {
+				t.Errorf("expected default limit 50, got %d", repo.limit)
+			}
+			if len(tags) != 2 || tags[0] != (TagDTO{Tag: "go", Count: 5}) || tags[1] != (TagDTO{Tag: "backend", Count: 2}) {
+				t.Errorf("unexpected tags: %+v", tags)
+			}
+		},
+	)
+
+	t.Run(
+		"limit is capped", func(t *testing.T) {
+			repo := &mockRepositoryForFindTags{}
+
+			if _, err := NewFindTagsUseCase(repo).Execute(context.Background(), FindTagsInputDTO{Limit: 10000}); err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+			if repo.limit != 500 {
+				t.Errorf("expected limit 500, got %d", repo.limit)
+			}
+		},
+	)
+
+	t.Run(
+		"repository error", func(t *testing.T) {
+			repoErr := errors.New("database error")
+			repo := &mockRepositoryForFindTags{err: repoErr}
+
+			if _, err := NewFindTagsUseCase(repo).Execute(context.Background(), FindTagsInputDTO{}); !errors.Is(err, repoErr) {
+				t.Errorf("expected repository error, got %v", err)
+			}
+		},
+	)
+}
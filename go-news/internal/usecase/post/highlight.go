@@ -0,0 +1,191 @@
+package post
+
+import (
+	"html"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const (
+	// SnippetLength - максимальная длина фрагмента содержания с подсветкой в символах.
+	SnippetLength = 200
+
+	highlightOpen  = "<mark>"
+	highlightClose = "</mark>"
+	ellipsis       = "…"
+)
+
+// searchStem - основа поискового слова. Короткие слова сравниваются целиком, у длинных
+// отбрасывается окончание: это грубое приближение стемминга текстового индекса,
+// достаточное для подсветки («новости» находит «новостей», «released» - «releases»).
+type searchStem struct {
+	value string
+	exact bool
+}
+
+// span - границы слова в тексте (в байтах).
+type span struct {
+	start, end int
+}
+
+// newSearchStems разбирает поисковый запрос на основы слов.
+// Исключенные слова (с префиксом «-») не подсвечиваются.
+func newSearchStems(query string) []searchStem {
+	var stems []searchStem
+
+	for _, field := range strings.Fields(query) {
+		if strings.HasPrefix(field, "-") {
+			continue
+		}
+
+		for _, sp := range wordSpans(field) {
+			word := []rune(strings.ToLower(field[sp.start:sp.end]))
+
+			switch n := len(word); {
+			case n < 4:
+				stems = append(stems, searchStem{value: string(word), exact: true})
+			case n < 6:
+				stems = append(stems, searchStem{value: string(word[:n-1])})
+			default:
+				stems = append(stems, searchStem{value: string(word[:n-2])})
+			}
+		}
+	}
+
+	return stems
+}
+
+// wordSpans возвращает границы слов (последовательностей букв и цифр) в тексте.
+func wordSpans(text string) []span {
+	var spans []span
+
+	start := -1
+	for i, r := range text {
+		if unicode.IsLetter(r) || unicode.IsDigit(r) {
+			if start < 0 {
+				start = i
+			}
+			continue
+		}
+
+		if start >= 0 {
+			spans = append(spans, span{start: start, end: i})
+			start = -1
+		}
+	}
+
+	if start >= 0 {
+		spans = append(spans, span{start: start, end: len(text)})
+	}
+
+	return spans
+}
+
+// matchStems сообщает, совпадает ли слово с одной из основ.
+func matchStems(word string, stems []searchStem) bool {
+	word = strings.ToLower(word)
+
+	for _, stem := range stems {
+		if stem.exact && word == stem.value {
+			return true
+		}
+		if !stem.exact && strings.HasPrefix(word, stem.value) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// markMatches экранирует текст как HTML и оборачивает совпавшие слова в <mark>.
+// Второе значение сообщает, было ли хотя бы одно совпадение.
+func markMatches(text string, stems []searchStem) (string, bool) {
+	var (
+		b       strings.Builder
+		last    int
+		matched bool
+	)
+
+	for _, sp := range wordSpans(text) {
+		if !matchStems(text[sp.start:sp.end], stems) {
+			continue
+		}
+
+		matched = true
+		b.WriteString(html.EscapeString(text[last:sp.start]))
+		b.WriteString(highlightOpen)
+		b.WriteString(html.EscapeString(text[sp.start:sp.end]))
+		b.WriteString(highlightClose)
+		last = sp.end
+	}
+
+	b.WriteString(html.EscapeString(text[last:]))
+
+	return b.String(), matched
+}
+
+// snippet возвращает фрагмент текста длиной не более maxRunes символов вокруг первого
+// совпадения с подсвеченными словами. Если совпадений нет, возвращает пустую строку.
+func snippet(text string, stems []searchStem, maxRunes int) string {
+	first := -1
+	for _, sp := range wordSpans(text) {
+		if matchStems(text[sp.start:sp.end], stems) {
+			first = sp.start
+			break
+		}
+	}
+
+	if first < 0 {
+		return ""
+	}
+
+	runes := []rune(text)
+	pos := utf8.RuneCountInString(text[:first])
+
+	// Совпадение размещается в первой трети фрагмента, чтобы был виден контекст до него.
+	from := max(0, pos-maxRunes/3)
+	to := min(len(runes), from+maxRunes)
+	from = max(0, min(from, to-maxRunes))
+
+	// Фрагмент не должен начинаться и заканчиваться на середине слова.
+	if from > 0 {
+		if i := indexRune(runes[from:pos], ' '); i >= 0 {
+			from += i + 1
+		}
+	}
+	if to < len(runes) {
+		if i := lastIndexRune(runes[pos:to], ' '); i > 0 {
+			to = pos + i
+		}
+	}
+
+	marked, _ := markMatches(strings.TrimSpace(string(runes[from:to])), stems)
+
+	if from > 0 {
+		marked = ellipsis + marked
+	}
+	if to < len(runes) {
+		marked += ellipsis
+	}
+
+	return marked
+}
+
+func indexRune(runes []rune, r rune) int {
+	for i, c := range runes {
+		if c == r {
+			return i
+		}
+	}
+	return -1
+}
+
+func lastIndexRune(runes []rune, r rune) int {
+	for i := len(runes) - 1; i >= 0; i-- {
+		if runes[i] == r {
+			return i
+		}
+	}
+	return -1
+}
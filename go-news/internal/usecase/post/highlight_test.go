@@ -0,0 +1,89 @@
+package post
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestMarkMatches(t *testing.T) {
+	testCases := []struct {
+		name      string
+		query     string
+		text      string
+		want      string
+		wantMatch bool
+	}{
+		{
+			name:      "russian word forms",
+			query:     "новости",
+			text:      "Обзор новостей за неделю",
+			want:      "Обзор <mark>новостей</mark> за неделю",
+			wantMatch: true,
+		},
+		{
+			name:      "english word forms and case",
+			query:     "releases",
+			text:      "Go 1.24 is Released",
+			want:      "Go 1.24 is <mark>Released</mark>",
+			wantMatch: true,
+		},
+		{
+			name:      "short words are matched exactly",
+			query:     "go",
+			text:      "Go and Google",
+			want:      "<mark>Go</mark> and Google",
+			wantMatch: true,
+		},
+		{
+			name:      "excluded words are not highlighted",
+			query:     "go -rust",
+			text:      "Rust vs Go",
+			want:      "Rust vs <mark>Go</mark>",
+			wantMatch: true,
+		},
+		{
+			name:  "html is escaped",
+			query: "generics",
+			text:  "Vec<T> & co",
+			want:  "Vec&lt;T&gt; &amp; co",
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(
+			tc.name, func(t *testing.T) {
+				got, matched := markMatches(tc.text, newSearchStems(tc.query))
+				if got != tc.want || matched != tc.wantMatch {
+					t.Errorf("markMatches() = (%q, %v), want (%q, %v)", got, matched, tc.want, tc.wantMatch)
+				}
+			},
+		)
+	}
+}
+
+func TestSnippet(t *testing.T) {
+	stems := newSearchStems("итераторы")
+	text := strings.Repeat("вступление ", 40) + "про итераторы в Go " + strings.Repeat("заключение ", 40)
+
+	got := snippet(text, stems, 100)
+
+	if !strings.Contains(got, "<mark>итераторы</mark>") {
+		t.Fatalf("snippet() must contain highlighted match, got %q", got)
+	}
+	if !strings.HasPrefix(got, ellipsis) || !strings.HasSuffix(got, ellipsis) {
+		t.Errorf("snippet() must be surrounded by ellipsis, got %q", got)
+	}
+
+	plain := strings.NewReplacer(highlightOpen, "", highlightClose, "", ellipsis, "").Replace(got)
+	if n := utf8.RuneCountInString(plain); n > 100 {
+		t.Errorf("snippet() length = %d, want <= 100", n)
+	}
+	if strings.HasPrefix(plain, "ступление") || strings.HasSuffix(plain, "заключени") {
+		t.Errorf("snippet() must not cut words, got %q", plain)
+	}
+
+	if got = snippet(text, newSearchStems("kubernetes"), 100); got != "" {
+		t.Errorf("snippet() without matches = %q, want empty", got)
+	}
+}
@@ -1,6 +1,9 @@
 package post
 
-import "context"
+import (
+	"context"
+	"github.com/segmentio/kafka-go"
+)
 
 // FindByIDUContract интерфейс для поиска новости по ID.
 type FindByIDUContract interface {
@@ -9,7 +12,7 @@ type FindByIDUContract interface {
 
 // FindAllContract интерфейс для поиска всех новостей.
 type FindAllContract interface {
-	Execute(ctx context.Context, in FindAllInputDTO) ([]PostDTO, int32, error)
+	Execute(ctx context.Context, in FindAllInputDTO) (FindAllOutputDTO, error)
 }
 
 // FindLastContract интерфейс для поиска последней новости.
@@ -21,3 +24,23 @@ type FindLastContract interface {
 type FindLatestContract interface {
 	Execute(ctx context.Context, in FindLatestInputDTO) ([]PostDTO, error)
 }
+
+// FindTagsContract интерфейс для получения самых частых тегов.
+type FindTagsContract interface {
+	Execute(ctx context.Context, in FindTagsInputDTO) ([]TagDTO, error)
+}
+
+// FindCategoriesContract интерфейс для получения рубрик с количеством новостей.
+type FindCategoriesContract interface {
+	Execute(ctx context.Context) ([]CategoryDTO, error)
+}
+
+// TrainCategoriesContract интерфейс для ручной разметки рубрик и обучения рубрикатора.
+type TrainCategoriesContract interface {
+	Execute(ctx context.Context, in TrainCategoriesInputDTO) (TrainCategoriesOutputDTO, error)
+}
+
+// AddCommentContract интерфейс для учета опубликованных комментариев.
+type AddCommentContract interface {
+	Execute(ctx context.Context, msg kafka.Message) error
+}
@@ -2,56 +2,386 @@ package post
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"time"
+
+	"github.com/ee-crocush/go-news/go-news/internal/domain/feed"
 	dom "github.com/ee-crocush/go-news/go-news/internal/domain/post"
 )
 
 // Parser — интерфейс RSS-парсера.
+// При ошибке после получения ответа источника результат содержит его HTTP статус.
 type Parser interface {
-	Parse(url string) ([]ParsedRSSDTO, error)
+	Parse(ctx context.Context, in ParseRequestDTO) (ParseResultDTO, error)
+}
+
+// ArticleFetcher — интерфейс загрузки полного текста статьи по ссылке новости.
+type ArticleFetcher interface {
+	Fetch(ctx context.Context, url string) (ArticleDTO, error)
+}
+
+// Summarizer — интерфейс составления краткого содержания новости для списков новостей.
+type Summarizer interface {
+	Summarize(text string) string
+}
+
+// Classifier — интерфейс рубрикатора новостей. Возвращает рубрики новости.
+type Classifier interface {
+	Classify(in ClassifyInputDTO) []string
 }
 
 // ParseAndStoreUseCase интерфейс для парснига и сохранения RSS.
 type ParseAndStoreUseCase interface {
-	Execute(ctx context.Context, in ParseAndStoreInputDTO) error
+	Execute(ctx context.Context, in ParseAndStoreInputDTO) (ParseAndStoreOutputDTO, error)
 }
 
 type parseAndStoreUseCase struct {
-	repo   dom.Repository
-	parser Parser
+	repo       dom.Repository
+	feeds      feed.Repository
+	runs       feed.RunStore
+	parser     Parser
+	articles   ArticleFetcher
+	summarizer Summarizer
+	classifier Classifier
 }
 
 // NewParseAndStoreUseCase создает новый экземпляр adapter для парсинга и сохранения RSS.
-func NewParseAndStoreUseCase(repo dom.Repository, parser Parser) ParseAndStoreUseCase {
-	return &parseAndStoreUseCase{repo: repo, parser: parser}
+// runs сохраняет журнал опросов источников; nil отключает журнал.
+// articles загружает полный текст статей для источников, где это включено; nil отключает загрузку.
+// summarizer составляет краткое содержание новых новостей; nil отключает его, в списках новостей
+// содержание тогда обрезается. classifier назначает новым новостям рубрики; nil отключает рубрикатор.
+func NewParseAndStoreUseCase(
+	repo dom.Repository, feeds feed.Repository, runs feed.RunStore, parser Parser, articles ArticleFetcher,
+	summarizer Summarizer, classifier Classifier,
+) ParseAndStoreUseCase {
+	return &parseAndStoreUseCase{
+		repo: repo, feeds: feeds, runs: runs, parser: parser, articles: articles, summarizer: summarizer,
+		classifier: classifier,
+	}
 }
 
 // Execute выполняет парсинг RSS ленты по указанному URL и сохраняет полученные посты в репозиторий.
-func (uc *parseAndStoreUseCase) Execute(ctx context.Context, in ParseAndStoreInputDTO) error {
+// Если лента не изменилась с прошлого запроса (304 Not Modified), сохранение пропускается.
+// Уже сохраненные ранее новости (по ссылке или GUID) пропускаются и учитываются в Skipped.
+// Для источников с загрузкой полного текста статья загружается только для новых новостей;
+// при ошибке загрузки новость сохраняется с кратким описанием и учитывается в ArticlesFailed.
+// Предупреждения парсера о пропущенных записях возвращаются в Warnings и сохраняются в источнике.
+// Каждый опрос, успешный или нет, записывается в журнал опросов.
+func (uc *parseAndStoreUseCase) Execute(ctx context.Context, in ParseAndStoreInputDTO) (
+	ParseAndStoreOutputDTO, error,
+) {
 	if err := in.Validate(); err != nil {
-		return fmt.Errorf("ParseAndStoreUseCase.Validate: %w", err)
+		return ParseAndStoreOutputDTO{}, fmt.Errorf("ParseAndStoreUseCase.Validate: %w", err)
 	}
 
-	items, err := uc.parser.Parse(in.URL)
+	source, err := uc.findOrCreateFeed(ctx, in.URL)
 	if err != nil {
-		return fmt.Errorf("ParseAndStoreUseCase.Parse: %w", err)
+		return ParseAndStoreOutputDTO{}, fmt.Errorf("ParseAndStoreUseCase.FindFeed: %w", err)
 	}
 
-	for _, item := range items {
-		post, err := dom.NewPost(item.Title, item.Content, item.Link, item.PubTime)
+	startedAt := time.Now()
+	out, httpStatus, err := uc.ingest(ctx, in.URL, source)
+
+	if runErr := uc.recordRun(ctx, source, startedAt, httpStatus, out, err); runErr != nil {
+		return out, errors.Join(err, runErr)
+	}
+
+	return out, err
+}
+
+// ingest получает ленту и сохраняет новые записи. Возвращает HTTP статус ответа источника.
+func (uc *parseAndStoreUseCase) ingest(ctx context.Context, url string, source *feed.Feed) (
+	ParseAndStoreOutputDTO, int, error,
+) {
+	var out ParseAndStoreOutputDTO
+
+	result, err := uc.parser.Parse(
+		ctx, ParseRequestDTO{
+			URL:          url,
+			ETag:         source.ETag(),
+			LastModified: source.LastModified(),
+		},
+	)
+	if err != nil {
+		err = fmt.Errorf("ParseAndStoreUseCase.Parse: %w", err)
+		return out, result.StatusCode, uc.recordFailure(ctx, source, err)
+	}
+
+	if result.NotModified {
+		out.NotModified = true
+		source.RecordSuccess(time.Now(), 0, true)
+		if err = uc.saveFetch(ctx, source, 0, false); err != nil {
+			return out, result.StatusCode, err
+		}
+		return out, result.StatusCode, nil
+	}
+
+	out.Seen = len(result.Items)
+
+	postSource, err := newPostSource(url, source, result.Channel)
+	if err != nil {
+		return out, result.StatusCode, fmt.Errorf("ParseAndStoreUseCase.NewPostSource: %w", err)
+	}
+
+	fetchFull := uc.articles != nil && source.FetchFullArticle()
+
+	for _, item := range result.Items {
+		res, err := uc.storeItem(ctx, item, postSource, fetchFull)
 		if err != nil {
-			return fmt.Errorf("ParseAndStoreUseCase.NewPost: %w", err)
+			return out, result.StatusCode, uc.recordFailure(ctx, source, err)
+		}
+
+		if res.articleFailed {
+			out.ArticlesFailed++
 		}
 
-		existing, err := uc.repo.FindByID(ctx, post.ID())
-		if err == nil && existing != nil {
+		if !res.inserted {
+			out.Skipped++
 			continue
 		}
 
-		if err = uc.repo.Store(ctx, post); err != nil {
-			return fmt.Errorf("ParseAndStoreUseCase.Store: %w", err)
+		out.Inserted++
+		if res.clustered {
+			out.Clustered++
+		}
+	}
+
+	// Валидаторы сохраняем только после успешной обработки всех записей,
+	// иначе при следующем запросе источник ответит 304 и записи будут потеряны.
+	source.SetValidators(result.ETag, result.LastModified)
+	source.RecordSuccess(time.Now(), out.Inserted, false)
+	source.RecordWarnings(result.Warnings)
+	out.Warnings = result.Warnings
+	if err = uc.saveFetch(ctx, source, out.Inserted, true); err != nil {
+		return out, result.StatusCode, err
+	}
+
+	return out, result.StatusCode, nil
+}
+
+// recordRun записывает опрос в журнал. Опрос источника, так и не сохраненного в хранилище, не записывается.
+// Запись сохраняется и после отмены ctx (например, при остановке сервиса), чтобы прерванный опрос
+// остался в журнале.
+func (uc *parseAndStoreUseCase) recordRun(
+	ctx context.Context, source *feed.Feed, startedAt time.Time, httpStatus int, out ParseAndStoreOutputDTO,
+	err error,
+) error {
+	if uc.runs == nil || source.ID().Value() == 0 {
+		return nil
+	}
+
+	run := feed.NewRun(source.ID(), source.URL(), startedAt)
+	run.SetHTTPStatus(httpStatus)
+
+	counts := feed.RunCounts{Seen: out.Seen, Inserted: out.Inserted, Skipped: out.Skipped}
+	if err != nil {
+		run.Fail(time.Now(), counts, err)
+	} else {
+		run.Succeed(time.Now(), counts, out.NotModified)
+	}
+
+	if saveErr := uc.runs.SaveRun(context.WithoutCancel(ctx), run); saveErr != nil {
+		return fmt.Errorf("ParseAndStoreUseCase.SaveRun: %w", saveErr)
+	}
+
+	return nil
+}
+
+// recordFailure фиксирует ошибку опроса в источнике и возвращает исходную ошибку.
+// Источник, еще не сохраненный в хранилище, не создается из-за неудачного опроса.
+func (uc *parseAndStoreUseCase) recordFailure(ctx context.Context, source *feed.Feed, err error) error {
+	if source.ID().Value() == 0 {
+		return err
+	}
+
+	source.RecordFailure(time.Now(), err)
+	if saveErr := uc.saveFetch(ctx, source, 0, false); saveErr != nil {
+		return errors.Join(err, saveErr)
+	}
+
+	return err
+}
+
+// saveFetch сохраняет результат опроса. Новый источник создается целиком, у существующего обновляются
+// только результат опроса, счетчик новостей и, если validators, валидаторы кэша: настройки источника
+// могли измениться во время опроса.
+func (uc *parseAndStoreUseCase) saveFetch(
+	ctx context.Context, source *feed.Feed, inserted int, validators bool,
+) error {
+	if source.ID().Value() == 0 {
+		if err := uc.feeds.Save(ctx, source); err != nil {
+			return fmt.Errorf("ParseAndStoreUseCase.SaveFeed: %w", err)
+		}
+		return nil
+	}
+
+	if err := uc.feeds.SaveFetch(ctx, source, inserted); err != nil {
+		return fmt.Errorf("ParseAndStoreUseCase.SaveFetch: %w", err)
+	}
+
+	if validators {
+		if err := uc.feeds.SaveValidators(ctx, source); err != nil {
+			return fmt.Errorf("ParseAndStoreUseCase.SaveValidators: %w", err)
 		}
 	}
 
 	return nil
 }
+
+// storeItemResult - результат сохранения записи ленты.
+type storeItemResult struct {
+	inserted bool
+	// articleFailed - полный текст статьи загрузить не удалось.
+	articleFailed bool
+	// clustered - новость объединена в кластер с ранее сохраненной почти одинаковой новостью.
+	clustered bool
+}
+
+// storeItem сохраняет запись ленты, если такой новости еще нет.
+// Проверка по ключу и ссылке выполняется до сохранения, чтобы не расходовать идентификаторы и не загружать
+// статьи уже сохраненных новостей. Новая новость объединяется в кластер с наиболее похожей
+// из опубликованных в пределах dom.ClusterWindow.
+func (uc *parseAndStoreUseCase) storeItem(
+	ctx context.Context, item ParsedRSSDTO, source dom.PostSource, fetchFull bool,
+) (res storeItemResult, err error) {
+	post, err := dom.NewPost(item.Title, item.Content, item.Link, item.PubTime)
+	if err != nil {
+		return res, fmt.Errorf("ParseAndStoreUseCase.NewPost: %w", err)
+	}
+
+	key, err := dom.NewPostKey(item.GUID, item.Link)
+	if err != nil {
+		return res, fmt.Errorf("ParseAndStoreUseCase.NewPostKey: %w", err)
+	}
+	post.SetKey(key)
+	post.SetSource(source)
+	post.SetContentHTML(dom.NewPostContentHTML(item.ContentHTML))
+	post.SetMedia(newPostMedia(item.Media))
+	post.SetTags(dom.NewPostTags(item.Tags))
+
+	exists, err := uc.repo.ExistsByKey(ctx, post.Key(), post.Link())
+	if err != nil {
+		return res, fmt.Errorf("ParseAndStoreUseCase.ExistsByKey: %w", err)
+	}
+	if exists {
+		return res, nil
+	}
+
+	if fetchFull {
+		article, fetchErr := uc.articles.Fetch(ctx, post.Link().Value())
+		if fetchErr != nil {
+			res.articleFailed = true
+		} else {
+			post.SetArticle(dom.NewPostArticle(article.Body, article.Image))
+		}
+	}
+
+	if uc.summarizer != nil {
+		// Короткое описание из ленты помещается в список целиком, краткое содержание для него не хранится.
+		if summary := uc.summarizer.Summarize(summarySource(post)); summary != post.Content().Value() {
+			post.SetSummary(dom.NewPostSummary(summary))
+		}
+	}
+
+	if uc.classifier != nil {
+		post.SetCategories(dom.NewPostCategories(uc.classifier.Classify(classifyInput(post))))
+	}
+
+	if res.clustered, err = uc.joinCluster(ctx, post); err != nil {
+		return res, err
+	}
+
+	res.inserted, err = uc.repo.StoreIfNotExists(ctx, post)
+	if err != nil {
+		return res, fmt.Errorf("ParseAndStoreUseCase.Store: %w", err)
+	}
+
+	return res, nil
+}
+
+// joinCluster добавляет новость в кластер наиболее похожей ранее сохраненной новости.
+// Возвращает false, если похожих новостей нет: тогда новость начнет собственный кластер.
+func (uc *parseAndStoreUseCase) joinCluster(ctx context.Context, post *dom.Post) (bool, error) {
+	if post.Fingerprint().IsZero() {
+		return false, nil
+	}
+
+	candidates, err := uc.repo.FindSimilar(ctx, post.Fingerprint(), dom.ClusterPubTimeRange(post.PubTime()))
+	if err != nil {
+		return false, fmt.Errorf("ParseAndStoreUseCase.FindSimilar: %w", err)
+	}
+
+	closest, ok := dom.ClosestPost(post.Fingerprint(), candidates)
+	if !ok {
+		return false, nil
+	}
+
+	post.JoinCluster(closest.ClusterID())
+
+	return true, nil
+}
+
+// summarySource возвращает текст для краткого содержания: полный текст статьи, если он загружен,
+// иначе описание из ленты.
+func summarySource(post *dom.Post) string {
+	if body := post.Article().Body(); body != "" {
+		return body
+	}
+
+	return post.Content().Value()
+}
+
+// classifyInput формирует текст новости для рубрикатора. Для обучения используется тот же текст,
+// что и при назначении рубрик.
+func classifyInput(post *dom.Post) ClassifyInputDTO {
+	return ClassifyInputDTO{
+		Title:   post.Title().Value(),
+		Content: summarySource(post),
+		Tags:    mapTags(post.Tags()),
+	}
+}
+
+// newPostSource формирует источник новостей ленты. Название канала берется из ленты,
+// а если оно не указано - из настроек источника.
+func newPostSource(url string, f *feed.Feed, channel ChannelDTO) (dom.PostSource, error) {
+	title := channel.Title
+	if title == "" {
+		title = f.Title()
+	}
+
+	return dom.NewPostSource(url, title, channel.Link)
+}
+
+// findOrCreateFeed получает источник по адресу ленты либо создает новый.
+func (uc *parseAndStoreUseCase) findOrCreateFeed(ctx context.Context, url string) (*feed.Feed, error) {
+	feedURL, err := feed.NewFeedURL(url)
+	if err != nil {
+		return nil, err
+	}
+
+	source, err := uc.feeds.FindByURL(ctx, feedURL)
+	if err == nil {
+		return source, nil
+	}
+
+	if !errors.Is(err, feed.ErrFeedNotFound) {
+		return nil, err
+	}
+
+	return feed.NewFeed(url)
+}
+
+// newPostMedia создает медиа-вложения новости из записи ленты, вложения с невалидным адресом пропускаются.
+func newPostMedia(items []MediaDTO) []dom.PostMedia {
+	media := make([]dom.PostMedia, 0, len(items))
+	for _, item := range items {
+		m, err := dom.NewPostMedia(item.URL, item.Type, item.Size, item.Width, item.Height, item.Thumbnail)
+		if err != nil {
+			continue
+		}
+		media = append(media, m)
+	}
+
+	return media
+}
@@ -3,7 +3,9 @@ package post
 import (
 	"context"
 	"errors"
+	"github.com/ee-crocush/go-news/go-news/internal/domain/feed"
 	dom "github.com/ee-crocush/go-news/go-news/internal/domain/post"
+	"strings"
 	"testing"
 	"time"
 )
@@ -23,6 +25,53 @@ func (m *mockStoreRepository) Store(ctx context.Context, post *dom.Post) error {
 	return nil
 }
 
+func (m *mockStoreRepository) StoreIfNotExists(ctx context.Context, post *dom.Post) (bool, error) {
+	for _, existing := range m.posts {
+		if existing.Key() == post.Key() {
+			return false, nil
+		}
+	}
+	if err := m.Store(ctx, post); err != nil {
+		return false, err
+	}
+	id, _ := dom.NewPostID(int32(len(m.posts)))
+	post.SetID(id)
+	if post.ClusterID() == 0 {
+		post.JoinCluster(id.Value())
+	}
+	return true, nil
+}
+
+func (m *mockStoreRepository) FindSimilar(
+	ctx context.Context, fingerprint dom.PostFingerprint, pubTime dom.PubTimeRange,
+) ([]*dom.Post, error) {
+	var similar []*dom.Post
+	for _, post := range m.posts {
+		if fingerprint.Similar(post.Fingerprint()) {
+			similar = append(similar, post)
+		}
+	}
+	return similar, nil
+}
+
+func (m *mockStoreRepository) FindByLink(ctx context.Context, link dom.PostLink) (*dom.Post, error) {
+	for _, post := range m.posts {
+		if post.Link() == link {
+			return post, nil
+		}
+	}
+	return nil, dom.ErrPostNotFound
+}
+
+func (m *mockStoreRepository) ExistsByKey(ctx context.Context, key dom.PostKey, link dom.PostLink) (bool, error) {
+	for _, post := range m.posts {
+		if post.Key() == key || post.Link() == link {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func (m *mockStoreRepository) FindByID(ctx context.Context, id dom.PostID) (*dom.Post, error) {
 	if m.findByIDErr != nil {
 		return nil, m.findByIDErr
@@ -36,10 +85,40 @@ func (m *mockStoreRepository) FindByID(ctx context.Context, id dom.PostID) (*dom
 	return nil, errors.New("post not found")
 }
 
-func (m *mockStoreRepository) FindAll(ctx context.Context, search string, limit int, offset int) ([]*dom.Post, error) {
+func (m *mockStoreRepository) FindAll(ctx context.Context, filter dom.PostFilter, limit int, offset int) (
+	[]*dom.Post, int32, error,
+) {
+	return m.posts, int32(len(m.posts)), nil
+}
+
+func (m *mockStoreRepository) FindByCursor(
+	ctx context.Context, filter dom.PostFilter, cursor dom.PostCursor, limit int,
+) ([]*dom.Post, error) {
 	return m.posts, nil
 }
 
+func (m *mockStoreRepository) AddComment(ctx context.Context, postID dom.PostID, commentID int64) (bool, error) {
+	return false, nil
+}
+
+func (m *mockStoreRepository) CountTags(ctx context.Context, limit int) ([]dom.TagCount, error) {
+	return nil, nil
+}
+
+func (m *mockStoreRepository) LabelCategories(
+	ctx context.Context, id dom.PostID, categories []dom.PostCategory,
+) error {
+	return nil
+}
+
+func (m *mockStoreRepository) FindLabeled(ctx context.Context, limit int) ([]*dom.Post, error) {
+	return nil, nil
+}
+
+func (m *mockStoreRepository) CountCategories(ctx context.Context) ([]dom.CategoryCount, error) {
+	return nil, nil
+}
+
 func (m *mockStoreRepository) FindLast(ctx context.Context) (*dom.Post, error) {
 	return nil, nil
 }
@@ -48,17 +127,92 @@ func (m *mockStoreRepository) FindLatest(ctx context.Context, limit int) ([]*dom
 	return nil, nil
 }
 
+// mockFeedRepository реализует интерфейс feed.Repository для тестирования
+type mockFeedRepository struct {
+	// feed.Repository встроен, чтобы не реализовывать методы, не используемые в тесте
+	feed.Repository
+	feeds   map[string]*feed.Feed
+	saveErr error
+	nextID  int32
+	// saved - источники, сохраненные целиком; fetched - источники, у которых обновлен результат опроса.
+	saved      []int32
+	fetched    []int32
+	validators []int32
+}
+
+func newMockFeedRepository() *mockFeedRepository {
+	return &mockFeedRepository{feeds: make(map[string]*feed.Feed)}
+}
+
+func (m *mockFeedRepository) Save(ctx context.Context, f *feed.Feed) error {
+	if m.saveErr != nil {
+		return m.saveErr
+	}
+	if f.ID().Value() == 0 {
+		m.nextID++
+		id, _ := feed.NewFeedID(m.nextID)
+		f.SetID(id)
+	}
+	m.feeds[f.URL().Value()] = f
+	m.saved = append(m.saved, f.ID().Value())
+	return nil
+}
+
+func (m *mockFeedRepository) SaveFetch(ctx context.Context, f *feed.Feed, inserted int) error {
+	if m.saveErr != nil {
+		return m.saveErr
+	}
+	m.fetched = append(m.fetched, f.ID().Value())
+	return nil
+}
+
+func (m *mockFeedRepository) SaveValidators(ctx context.Context, f *feed.Feed) error {
+	if m.saveErr != nil {
+		return m.saveErr
+	}
+	m.validators = append(m.validators, f.ID().Value())
+	return nil
+}
+
+func (m *mockFeedRepository) FindByURL(ctx context.Context, url feed.FeedURL) (*feed.Feed, error) {
+	if f, ok := m.feeds[url.Value()]; ok {
+		return f, nil
+	}
+	return nil, feed.ErrFeedNotFound
+}
+
 // mockParser реализует интерфейс Parser для тестирования
 type mockParser struct {
-	items []ParsedRSSDTO
-	err   error
+	items    []ParsedRSSDTO
+	channel  ChannelDTO
+	err      error
+	requests []ParseRequestDTO
+	etag     string
+	warnings []string
+	status   int
 }
 
-func (m *mockParser) Parse(url string) ([]ParsedRSSDTO, error) {
+func (m *mockParser) Parse(ctx context.Context, in ParseRequestDTO) (ParseResultDTO, error) {
+	m.requests = append(m.requests, in)
 	if m.err != nil {
-		return nil, m.err
+		return ParseResultDTO{StatusCode: m.status}, m.err
+	}
+	if m.etag != "" && in.ETag == m.etag {
+		return ParseResultDTO{ETag: in.ETag, NotModified: true, StatusCode: 304}, nil
 	}
-	return m.items, nil
+	return ParseResultDTO{
+		Items: m.items, Channel: m.channel, ETag: m.etag, Warnings: m.warnings, StatusCode: m.status,
+	}, nil
+}
+
+// mockRunStore реализует интерфейс feed.RunStore для тестирования
+type mockRunStore struct {
+	runs []*feed.Run
+}
+
+func (m *mockRunStore) SaveRun(ctx context.Context, run *feed.Run) error {
+	m.runs = append(m.runs, run)
+	return nil
 }
 
 func TestParseAndStoreUseCase_Execute_Success(t *testing.T) {
@@ -91,18 +245,22 @@ func TestParseAndStoreUseCase_Execute_Success(t *testing.T) {
 		findByIDErr: errors.New("post not found"), // Симулируем, что посты не найдены
 	}
 
-	useCase := NewParseAndStoreUseCase(repo, parser)
+	useCase := NewParseAndStoreUseCase(repo, newMockFeedRepository(), nil, parser, nil, nil, nil)
 
 	input := ParseAndStoreInputDTO{
 		URL: "https://example.com/rss",
 	}
 
-	err := useCase.Execute(ctx, input)
+	out, err := useCase.Execute(ctx, input)
 
 	if err != nil {
 		t.Fatalf("Expected no error, got: %v", err)
 	}
 
+	if out.Inserted != 2 || out.Skipped != 0 {
+		t.Fatalf("Expected 2 inserted and 0 skipped, got: %+v", out)
+	}
+
 	// Проверяем, что посты были сохранены
 	if len(repo.posts) != 2 {
 		t.Fatalf("Expected 2 posts to be stored, got: %d", len(repo.posts))
@@ -114,14 +272,14 @@ func TestParseAndStoreUseCase_Execute_InvalidInput(t *testing.T) {
 
 	parser := &mockParser{}
 	repo := &mockRepository{}
-	useCase := NewParseAndStoreUseCase(repo, parser)
+	useCase := NewParseAndStoreUseCase(repo, newMockFeedRepository(), nil, parser, nil, nil, nil)
 
 	// Пустой URL должен вызвать ошибку валидации
 	input := ParseAndStoreInputDTO{
 		URL: "",
 	}
 
-	err := useCase.Execute(ctx, input)
+	_, err := useCase.Execute(ctx, input)
 
 	if err == nil {
 		t.Fatal("Expected validation error, got nil")
@@ -137,13 +295,13 @@ func TestParseAndStoreUseCase_Execute_ParserError(t *testing.T) {
 	}
 
 	repo := &mockRepository{}
-	useCase := NewParseAndStoreUseCase(repo, parser)
+	useCase := NewParseAndStoreUseCase(repo, newMockFeedRepository(), nil, parser, nil, nil, nil)
 
 	input := ParseAndStoreInputDTO{
 		URL: "https://example.com/rss",
 	}
 
-	err := useCase.Execute(ctx, input)
+	_, err := useCase.Execute(ctx, input)
 
 	if err == nil {
 		t.Fatal("Expected parser error, got nil")
@@ -153,3 +311,558 @@ func TestParseAndStoreUseCase_Execute_ParserError(t *testing.T) {
 		t.Fatalf("Expected parser error, got: %v", err)
 	}
 }
+
+func TestParseAndStoreUseCase_Execute_RecordsFeedStatus(t *testing.T) {
+	ctx := context.Background()
+
+	parser := &mockParser{
+		items: []ParsedRSSDTO{
+			{Title: "Title 1", Content: "Content 1", Link: "https://example.com/1", PubTime: time.Now().Unix()},
+			{Title: "Title 2", Content: "Content 2", Link: "https://example.com/2", PubTime: time.Now().Unix()},
+		},
+	}
+	feeds := newMockFeedRepository()
+	useCase := NewParseAndStoreUseCase(&mockStoreRepository{}, feeds, nil, parser, nil, nil, nil)
+	input := ParseAndStoreInputDTO{URL: "https://example.com/rss"}
+
+	if _, err := useCase.Execute(ctx, input); err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+
+	stored := feeds.feeds[input.URL]
+	if stored.ItemCount() != 2 {
+		t.Errorf("ItemCount() = %d, want 2", stored.ItemCount())
+	}
+	if stored.LastFetch().Status() != feed.FetchStatusOK {
+		t.Errorf("Status() = %q, want %q", stored.LastFetch().Status(), feed.FetchStatusOK)
+	}
+
+	parser.err = errors.New("connection refused")
+	if _, err := useCase.Execute(ctx, input); err == nil {
+		t.Fatal("expected parser error, got nil")
+	}
+
+	if stored.LastFetch().Status() != feed.FetchStatusError {
+		t.Errorf("Status() = %q, want %q", stored.LastFetch().Status(), feed.FetchStatusError)
+	}
+	if stored.ItemCount() != 2 {
+		t.Errorf("ItemCount() must be kept after failure, got %d", stored.ItemCount())
+	}
+
+	// Существующий источник не сохраняется целиком, чтобы не перезаписать настройки,
+	// измененные во время опроса; валидаторы сохраняются только после успешного опроса.
+	id := stored.ID().Value()
+	if len(feeds.saved) != 1 || feeds.saved[0] != id {
+		t.Errorf("only the new feed must be saved whole, got %v", feeds.saved)
+	}
+	if len(feeds.fetched) != 1 || feeds.fetched[0] != id {
+		t.Errorf("expected fetch result of feed %d to be saved once, got %v", id, feeds.fetched)
+	}
+	if len(feeds.validators) != 0 {
+		t.Errorf("validators must not be saved after failure, got %v", feeds.validators)
+	}
+}
+
+func TestParseAndStoreUseCase_Execute_Warnings(t *testing.T) {
+	ctx := context.Background()
+
+	warning := `item "https://example.com/2" skipped: unparseable date "yesterday"`
+	parser := &mockParser{
+		items: []ParsedRSSDTO{
+			{Title: "Title 1", Content: "Content 1", Link: "https://example.com/1", PubTime: time.Now().Unix()},
+		},
+		warnings: []string{warning},
+	}
+	feeds := newMockFeedRepository()
+	useCase := NewParseAndStoreUseCase(&mockStoreRepository{}, feeds, nil, parser, nil, nil, nil)
+	input := ParseAndStoreInputDTO{URL: "https://example.com/rss"}
+
+	out, err := useCase.Execute(ctx, input)
+	if err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+
+	if out.Inserted != 1 || len(out.Warnings) != 1 || out.Warnings[0] != warning {
+		t.Errorf("unexpected output: %+v", out)
+	}
+	if got := feeds.feeds[input.URL].LastFetch().Warnings(); len(got) != 1 || got[0] != warning {
+		t.Errorf("feed warnings = %v, want [%s]", got, warning)
+	}
+
+	parser.warnings = nil
+	if _, err = useCase.Execute(ctx, input); err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+	if got := feeds.feeds[input.URL].LastFetch().Warnings(); len(got) != 0 {
+		t.Errorf("feed warnings must be reset, got %v", got)
+	}
+}
+
+func TestParseAndStoreUseCase_Execute_RecordsRuns(t *testing.T) {
+	ctx := context.Background()
+
+	parser := &mockParser{
+		items: []ParsedRSSDTO{
+			{Title: "Title 1", Content: "Content 1", Link: "https://example.com/1", PubTime: time.Now().Unix()},
+			{Title: "Title 2", Content: "Content 2", Link: "https://example.com/2", PubTime: time.Now().Unix()},
+		},
+		status: 200,
+	}
+	feeds := newMockFeedRepository()
+	runs := &mockRunStore{}
+	useCase := NewParseAndStoreUseCase(&mockStoreRepository{}, feeds, runs, parser, nil, nil, nil)
+	input := ParseAndStoreInputDTO{URL: "https://example.com/rss"}
+
+	if _, err := useCase.Execute(ctx, input); err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+	if _, err := useCase.Execute(ctx, input); err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+
+	parser.err = errors.New("unexpected http status: 503")
+	parser.status = 503
+	if _, err := useCase.Execute(ctx, input); err == nil {
+		t.Fatal("expected parser error, got nil")
+	}
+
+	if len(runs.runs) != 3 {
+		t.Fatalf("expected 3 runs, got %d", len(runs.runs))
+	}
+
+	first := runs.runs[0]
+	if first.FeedID() != feeds.feeds[input.URL].ID() || first.Status() != feed.FetchStatusOK ||
+		first.HTTPStatus() != 200 || first.Counts() != (feed.RunCounts{Seen: 2, Inserted: 2}) {
+		t.Errorf("unexpected first run: %+v", first)
+	}
+	if first.FinishedAt().Before(first.StartedAt()) {
+		t.Errorf("run finished before start: %+v", first)
+	}
+
+	if second := runs.runs[1]; second.Counts() != (feed.RunCounts{Seen: 2, Skipped: 2}) {
+		t.Errorf("unexpected second run counts: %+v", second.Counts())
+	}
+
+	failed := runs.runs[2]
+	if failed.Status() != feed.FetchStatusError || failed.HTTPStatus() != 503 ||
+		!strings.Contains(failed.Error(), "503") {
+		t.Errorf("unexpected failed run: %+v", failed)
+	}
+}
+
+func TestParseAndStoreUseCase_Execute_NoRunForUnsavedFeed(t *testing.T) {
+	runs := &mockRunStore{}
+	parser := &mockParser{err: errors.New("connection refused")}
+	useCase := NewParseAndStoreUseCase(&mockStoreRepository{}, newMockFeedRepository(), runs, parser, nil, nil, nil)
+
+	if _, err := useCase.Execute(context.Background(), ParseAndStoreInputDTO{URL: "https://example.com/rss"}); err == nil {
+		t.Fatal("expected parser error, got nil")
+	}
+	if len(runs.runs) != 0 {
+		t.Errorf("runs of unsaved feed must not be recorded, got %d", len(runs.runs))
+	}
+}
+
+func TestParseAndStoreUseCase_Execute_Source(t *testing.T) {
+	ctx := context.Background()
+
+	parser := &mockParser{
+		items: []ParsedRSSDTO{
+			{Title: "Title 1", Content: "Content 1", Link: "https://example.com/1", PubTime: time.Now().Unix()},
+		},
+		channel: ChannelDTO{Title: "Example", Link: "https://example.com/"},
+	}
+	repo := &mockStoreRepository{}
+	useCase := NewParseAndStoreUseCase(repo, newMockFeedRepository(), nil, parser, nil, nil, nil)
+
+	if _, err := useCase.Execute(ctx, ParseAndStoreInputDTO{URL: "https://example.com/rss"}); err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+
+	if len(repo.posts) != 1 {
+		t.Fatalf("expected 1 stored post, got %d", len(repo.posts))
+	}
+
+	source := repo.posts[0].Source()
+	if source.FeedURL() != "https://example.com/rss" || source.Title() != "Example" ||
+		source.SiteLink() != "https://example.com/" {
+		t.Errorf("unexpected source: %+v", source)
+	}
+}
+
+func TestParseAndStoreUseCase_Execute_NotModified(t *testing.T) {
+	ctx := context.Background()
+
+	parser := &mockParser{
+		items: []ParsedRSSDTO{
+			{
+				Title:   "Test Title",
+				Content: "Test Content",
+				Link:    "https://example.com/1",
+				PubTime: time.Now().Unix(),
+			},
+		},
+		etag: `"v1"`,
+	}
+	repo := &mockStoreRepository{findByIDErr: errors.New("post not found")}
+	feeds := newMockFeedRepository()
+	useCase := NewParseAndStoreUseCase(repo, feeds, nil, parser, nil, nil, nil)
+
+	input := ParseAndStoreInputDTO{URL: "https://example.com/rss"}
+
+	if _, err := useCase.Execute(ctx, input); err != nil {
+		t.Fatalf("first Execute() unexpected error: %v", err)
+	}
+
+	stored, ok := feeds.feeds[input.URL]
+	if !ok {
+		t.Fatal("expected feed to be saved after first run")
+	}
+	if stored.ETag() != `"v1"` {
+		t.Errorf("expected ETag to be saved, got %q", stored.ETag())
+	}
+
+	out, err := useCase.Execute(ctx, input)
+	if err != nil {
+		t.Fatalf("second Execute() unexpected error: %v", err)
+	}
+
+	if !out.NotModified {
+		t.Error("expected second run to be reported as not modified")
+	}
+
+	if len(parser.requests) != 2 || parser.requests[1].ETag != `"v1"` {
+		t.Fatalf("expected conditional request with saved ETag, got %+v", parser.requests)
+	}
+
+	if len(repo.posts) != 1 {
+		t.Errorf("expected posts to be stored only once, got %d", len(repo.posts))
+	}
+}
+
+func TestParseAndStoreUseCase_Execute_Deduplication(t *testing.T) {
+	ctx := context.Background()
+	pubTime := time.Now().Unix()
+
+	parser := &mockParser{
+		items: []ParsedRSSDTO{
+			{
+				GUID:    "https://example.com/?p=1",
+				Title:   "Title 1",
+				Content: "Content 1",
+				Link:    "https://example.com/posts/1",
+				PubTime: pubTime,
+			},
+			{
+				// Тот же GUID, но ссылка с трекинговыми параметрами
+				GUID:    "https://example.com/?p=1",
+				Title:   "Title 1",
+				Content: "Content 1",
+				Link:    "https://example.com/posts/1?utm_source=rss",
+				PubTime: pubTime,
+			},
+			{
+				Title:   "Title 2",
+				Content: "Content 2",
+				Link:    "https://example.com/posts/2",
+				PubTime: pubTime,
+			},
+		},
+	}
+	repo := &mockStoreRepository{}
+	useCase := NewParseAndStoreUseCase(repo, newMockFeedRepository(), nil, parser, nil, nil, nil)
+	input := ParseAndStoreInputDTO{URL: "https://example.com/rss"}
+
+	out, err := useCase.Execute(ctx, input)
+	if err != nil {
+		t.Fatalf("first Execute() unexpected error: %v", err)
+	}
+	if out.Inserted != 2 || out.Skipped != 1 {
+		t.Fatalf("first run: expected 2 inserted and 1 skipped, got %+v", out)
+	}
+
+	out, err = useCase.Execute(ctx, input)
+	if err != nil {
+		t.Fatalf("second Execute() unexpected error: %v", err)
+	}
+	if out.Inserted != 0 || out.Skipped != 3 {
+		t.Fatalf("second run: expected 0 inserted and 3 skipped, got %+v", out)
+	}
+
+	if len(repo.posts) != 2 {
+		t.Errorf("expected 2 stored posts, got %d", len(repo.posts))
+	}
+}
+
+// mockArticleFetcher реализует интерфейс ArticleFetcher для тестирования
+type mockArticleFetcher struct {
+	articles map[string]ArticleDTO
+	err      error
+	urls     []string
+}
+
+func (m *mockArticleFetcher) Fetch(ctx context.Context, url string) (ArticleDTO, error) {
+	m.urls = append(m.urls, url)
+	if m.err != nil {
+		return ArticleDTO{}, m.err
+	}
+	return m.articles[url], nil
+}
+
+func TestParseAndStoreUseCase_Execute_FetchFullArticle(t *testing.T) {
+	ctx := context.Background()
+	pubTime := time.Now().Unix()
+	feedURL := "https://example.com/rss"
+
+	parser := &mockParser{
+		items: []ParsedRSSDTO{
+			{Title: "Title 1", Content: "Summary 1", Link: "https://example.com/posts/1", PubTime: pubTime},
+			{Title: "Title 2", Content: "Summary 2", Link: "https://example.com/posts/2", PubTime: pubTime},
+		},
+	}
+	fetcher := &mockArticleFetcher{
+		articles: map[string]ArticleDTO{
+			"https://example.com/posts/1": {Body: "Full body 1", Image: "https://example.com/1.png"},
+			"https://example.com/posts/2": {Body: "Full body 2"},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		fetchFull bool
+		fetchErr  error
+		wantCalls int
+		wantBody  string
+		wantFails int
+	}{
+		{name: "enabled", fetchFull: true, wantCalls: 2, wantBody: "Full body 1"},
+		{name: "disabled", fetchFull: false, wantCalls: 0},
+		{name: "fetch error", fetchFull: true, fetchErr: errors.New("timeout"), wantCalls: 2, wantFails: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(
+			tt.name, func(t *testing.T) {
+				fetcher.urls = nil
+				fetcher.err = tt.fetchErr
+
+				feeds := newMockFeedRepository()
+				source, _ := feed.NewFeed(feedURL)
+				source.SetFetchFullArticle(tt.fetchFull)
+				_ = feeds.Save(ctx, source)
+
+				repo := &mockStoreRepository{}
+				useCase := NewParseAndStoreUseCase(repo, feeds, nil, parser, fetcher, nil, nil)
+
+				out, err := useCase.Execute(ctx, ParseAndStoreInputDTO{URL: feedURL})
+				if err != nil {
+					t.Fatalf("Execute() unexpected error: %v", err)
+				}
+
+				if out.Inserted != 2 || out.ArticlesFailed != tt.wantFails {
+					t.Errorf("expected 2 inserted and %d failed articles, got %+v", tt.wantFails, out)
+				}
+				if len(fetcher.urls) != tt.wantCalls {
+					t.Errorf("expected %d fetches, got %v", tt.wantCalls, fetcher.urls)
+				}
+
+				article := repo.posts[0].Article()
+				if article.Body() != tt.wantBody {
+					t.Errorf("expected body %q, got %q", tt.wantBody, article.Body())
+				}
+				if repo.posts[0].Content().Value() != "Summary 1" {
+					t.Errorf("expected summary to be kept, got %q", repo.posts[0].Content().Value())
+				}
+
+				// Повторный опрос не загружает статьи уже сохраненных новостей
+				fetcher.urls = nil
+				if _, err = useCase.Execute(ctx, ParseAndStoreInputDTO{URL: feedURL}); err != nil {
+					t.Fatalf("second Execute() unexpected error: %v", err)
+				}
+				if len(fetcher.urls) != 0 {
+					t.Errorf("expected no fetches for existing posts, got %v", fetcher.urls)
+				}
+			},
+		)
+	}
+}
+
+func TestParseAndStoreUseCase_Execute_MediaAndTags(t *testing.T) {
+	parser := &mockParser{
+		items: []ParsedRSSDTO{
+			{
+				Title:   "Title",
+				Content: "Content",
+				Link:    "https://example.com/posts/1",
+				PubTime: time.Now().Unix(),
+				Media: []MediaDTO{
+					{URL: "https://cdn.example.com/1.mp3", Type: "audio/mpeg", Size: 1024},
+					{URL: "/relative.png"},
+					{URL: "https://cdn.example.com/1.jpg", Width: 320, Height: 180, Thumbnail: true},
+				},
+				Tags: []string{"Go", " go", "Open Source", ""},
+			},
+		},
+	}
+	repo := &mockStoreRepository{}
+	useCase := NewParseAndStoreUseCase(repo, newMockFeedRepository(), nil, parser, nil, nil, nil)
+
+	if _, err := useCase.Execute(context.Background(), ParseAndStoreInputDTO{URL: "https://example.com/rss"}); err != nil {
+		t.Fatalf("Execute() unexpected error: %v", err)
+	}
+
+	media := repo.posts[0].Media()
+	if len(media) != 2 {
+		t.Fatalf("expected invalid media to be skipped, got %d items", len(media))
+	}
+	if media[0].MIMEType() != "audio/mpeg" || media[0].Size() != 1024 {
+		t.Errorf("unexpected enclosure: %+v", media[0])
+	}
+	if !media[1].Thumbnail() || media[1].Width() != 320 {
+		t.Errorf("unexpected thumbnail: %+v", media[1])
+	}
+
+	tags := repo.posts[0].Tags()
+	if len(tags) != 2 || tags[0].Value() != "go" || tags[1].Value() != "open source" {
+		t.Errorf("expected normalized unique tags, got %+v", tags)
+	}
+}
+
+func TestParseAndStoreUseCase_Execute_Clusters(t *testing.T) {
+	ctx := context.Background()
+
+	content := "Совет директоров Банка России сохранил ключевую ставку на прежнем уровне. " +
+		"Регулятор сообщил, что инфляция замедляется, но остается выше цели."
+	parser := &mockParser{
+		items: []ParsedRSSDTO{
+			{Title: "ЦБ сохранил ключевую ставку", Content: content, Link: "https://a.example.com/1", PubTime: 1700000000},
+			{
+				Title: "Центробанк сохранил ключевую ставку", Content: content, Link: "https://b.example.com/2",
+				PubTime: 1700000600,
+			},
+			{
+				Title: "Apple представила новый iPhone", Content: "Смартфон получил увеличенный аккумулятор и новую камеру.",
+				Link: "https://a.example.com/3", PubTime: 1700001200,
+			},
+		},
+	}
+	repo := &mockStoreRepository{}
+
+	out, err := NewParseAndStoreUseCase(repo, newMockFeedRepository(), nil, parser, nil, nil, nil).
+		Execute(ctx, ParseAndStoreInputDTO{URL: "https://example.com/rss"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if out.Inserted != 3 || out.Clustered != 1 {
+		t.Fatalf("Expected 3 inserted and 1 clustered, got: %+v", out)
+	}
+
+	first, second, other := repo.posts[0], repo.posts[1], repo.posts[2]
+	if second.ClusterID() != first.ID().Value() {
+		t.Errorf("Expected near-duplicate in cluster %d, got %d", first.ID().Value(), second.ClusterID())
+	}
+	if other.ClusterID() != other.ID().Value() {
+		t.Errorf("Expected distinct post to start own cluster, got %d", other.ClusterID())
+	}
+}
+
+// mockSummarizer реализует интерфейс Summarizer для тестирования
+type mockSummarizer struct {
+	limit int
+}
+
+func (m *mockSummarizer) Summarize(text string) string {
+	if len([]rune(text)) <= m.limit {
+		return text
+	}
+	return string([]rune(text)[:m.limit])
+}
+
+func TestParseAndStoreUseCase_Execute_Summary(t *testing.T) {
+	ctx := context.Background()
+
+	long := strings.Repeat("Длинное описание новости. ", 5)
+	parser := &mockParser{
+		items: []ParsedRSSDTO{
+			{Title: "Long", Content: long, Link: "https://example.com/1", PubTime: time.Now().Unix()},
+			{Title: "Short", Content: "Короткое описание.", Link: "https://example.com/2", PubTime: time.Now().Unix()},
+		},
+	}
+	repo := &mockStoreRepository{}
+
+	_, err := NewParseAndStoreUseCase(repo, newMockFeedRepository(), nil, parser, nil, &mockSummarizer{limit: 20}, nil).
+		Execute(ctx, ParseAndStoreInputDTO{URL: "https://example.com/rss"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if got, want := repo.posts[0].Summary().Value(), string([]rune(long)[:20]); got != strings.TrimSpace(want) {
+		t.Errorf("Expected summary %q, got %q", want, got)
+	}
+	if !repo.posts[1].Summary().IsZero() {
+		t.Errorf("Expected no summary for short content, got %q", repo.posts[1].Summary().Value())
+	}
+
+	dto := MapPostsToDTO(repo.posts)
+	if dto[0].Content != repo.posts[0].Summary().Value() {
+		t.Errorf("Expected list content to be the summary, got %q", dto[0].Content)
+	}
+	if dto[1].Content != "Короткое описание." {
+		t.Errorf("Expected short content unchanged, got %q", dto[1].Content)
+	}
+}
+
+// mockClassifier реализует интерфейс Classifier для тестирования
+type mockClassifier struct {
+	inputs []ClassifyInputDTO
+}
+
+func (m *mockClassifier) Classify(in ClassifyInputDTO) []string {
+	m.inputs = append(m.inputs, in)
+	if strings.Contains(in.Title, "Go") {
+		return []string{"Go", "bad category!"}
+	}
+	return nil
+}
+
+func TestParseAndStoreUseCase_Execute_Categories(t *testing.T) {
+	ctx := context.Background()
+
+	parser := &mockParser{
+		items: []ParsedRSSDTO{
+			{
+				Title: "Вышел Go 1.24", Content: "Релиз.", Link: "https://example.com/1",
+				PubTime: time.Now().Unix(), Tags: []string{"релизы"},
+			},
+			{Title: "Погода", Content: "Солнечно.", Link: "https://example.com/2", PubTime: time.Now().Unix()},
+		},
+	}
+	repo := &mockStoreRepository{}
+	classifier := &mockClassifier{}
+
+	_, err := NewParseAndStoreUseCase(repo, newMockFeedRepository(), nil, parser, nil, nil, classifier).
+		Execute(ctx, ParseAndStoreInputDTO{URL: "https://example.com/rss"})
+	if err != nil {
+		t.Fatalf("Expected no error, got: %v", err)
+	}
+
+	if len(classifier.inputs) != 2 {
+		t.Fatalf("Expected 2 classified posts, got %d", len(classifier.inputs))
+	}
+	in := classifier.inputs[0]
+	if in.Title != "Вышел Go 1.24" || in.Content != "Релиз." || len(in.Tags) != 1 || in.Tags[0] != "релизы" {
+		t.Errorf("Unexpected classify input: %+v", in)
+	}
+
+	got := mapCategories(repo.posts[0].Categories())
+	if len(got) != 1 || got[0] != "go" {
+		t.Errorf("Expected categories [go], got %v", got)
+	}
+	if repo.posts[0].CategoriesLabeled() {
+		t.Error("Expected automatic categories not to be marked as labeled")
+	}
+	if len(repo.posts[1].Categories()) != 0 {
+		t.Errorf("Expected no categories, got %v", mapCategories(repo.posts[1].Categories()))
+	}
+}
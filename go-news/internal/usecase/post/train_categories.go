@@ -0,0 +1,84 @@
+package post
+
+import (
+	"context"
+	"fmt"
+	dom "github.com/ee-crocush/go-news/go-news/internal/domain/post"
+)
+
+var _ TrainCategoriesContract = (*TrainCategoriesUseCase)(nil)
+
+// MaxTrainingExamples - максимальное количество размеченных новостей для обучения рубрикатора.
+const MaxTrainingExamples = 5000
+
+// CategoryTrainer — интерфейс обучения рубрикатора на новостях с рубриками, проставленными вручную.
+type CategoryTrainer interface {
+	Train(examples []TrainingExampleDTO) TrainStatsDTO
+}
+
+// TrainCategoriesUseCase представляет структуру, реализующую ручную разметку рубрик и обучение рубрикатора.
+type TrainCategoriesUseCase struct {
+	repo    dom.Repository
+	trainer CategoryTrainer
+}
+
+// NewTrainCategoriesUseCase создает новый экземпляр use case для обучения рубрикатора.
+func NewTrainCategoriesUseCase(repo dom.Repository, trainer CategoryTrainer) *TrainCategoriesUseCase {
+	return &TrainCategoriesUseCase{repo: repo, trainer: trainer}
+}
+
+// Execute сохраняет рубрики, проставленные вручную, и переобучает рубрикатор на всех размеченных новостях
+// (не больше MaxTrainingExamples последних). Разметки проверяются до сохранения: при невалидной рубрике
+// или ID не сохраняется ни одна. Рубрики уже сохраненных новостей не пересчитываются.
+func (uc *TrainCategoriesUseCase) Execute(ctx context.Context, in TrainCategoriesInputDTO) (
+	TrainCategoriesOutputDTO, error,
+) {
+	type label struct {
+		id         dom.PostID
+		categories []dom.PostCategory
+	}
+
+	labels := make([]label, 0, len(in.Labels))
+	for _, l := range in.Labels {
+		id, err := dom.NewPostID(l.PostID)
+		if err != nil {
+			return TrainCategoriesOutputDTO{}, fmt.Errorf("TrainCategoriesUseCase.NewPostID: %w", err)
+		}
+
+		categories, err := dom.ParsePostCategories(l.Categories)
+		if err != nil {
+			return TrainCategoriesOutputDTO{}, fmt.Errorf("TrainCategoriesUseCase.ParsePostCategories: %w", err)
+		}
+
+		labels = append(labels, label{id: id, categories: categories})
+	}
+
+	for _, l := range labels {
+		if err := uc.repo.LabelCategories(ctx, l.id, l.categories); err != nil {
+			return TrainCategoriesOutputDTO{}, fmt.Errorf("TrainCategoriesUseCase.LabelCategories: %w", err)
+		}
+	}
+
+	posts, err := uc.repo.FindLabeled(ctx, MaxTrainingExamples)
+	if err != nil {
+		return TrainCategoriesOutputDTO{}, fmt.Errorf("TrainCategoriesUseCase.FindLabeled: %w", err)
+	}
+
+	examples := make([]TrainingExampleDTO, 0, len(posts))
+	for _, post := range posts {
+		examples = append(
+			examples, TrainingExampleDTO{
+				ClassifyInputDTO: classifyInput(post),
+				Categories:       mapCategories(post.Categories()),
+			},
+		)
+	}
+
+	stats := uc.trainer.Train(examples)
+
+	return TrainCategoriesOutputDTO{
+		Labeled:    len(labels),
+		Examples:   stats.Examples,
+		Categories: stats.Categories,
+	}, nil
+}
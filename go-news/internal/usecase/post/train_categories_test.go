@@ -0,0 +1,188 @@
+package post
+
+import (
+	"context"
+	"errors"
+	dom "github.com/ee-crocush/go-news/go-news/internal/domain/post"
+	"testing"
+)
+
+// mockRepositoryForCategories реализует интерфейс dom.Repository для тестирования
+type mockRepositoryForCategories struct {
+	// dom.Repository встроен, чтобы не реализовывать методы, не используемые в тесте
+	dom.Repository
+	posts   map[int32]*dom.Post
+	labeled []int32
+	counts  []dom.CategoryCount
+	err     error
+}
+
+func (m *mockRepositoryForCategories) LabelCategories(
+	ctx context.Context, id dom.PostID, categories []dom.PostCategory,
+) error {
+	post, ok := m.posts[id.Value()]
+	if !ok {
+		return dom.ErrPostNotFound
+	}
+
+	post.LabelCategories(categories)
+	m.labeled = append(m.labeled, id.Value())
+
+	return nil
+}
+
+func (m *mockRepositoryForCategories) FindLabeled(ctx context.Context, limit int) ([]*dom.Post, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+
+	var posts []*dom.Post
+	for _, post := range m.posts {
+		if post.CategoriesLabeled() {
+			posts = append(posts, post)
+		}
+	}
+
+	return posts, nil
+}
+
+func (m *mockRepositoryForCategories) CountCategories(ctx context.Context) ([]dom.CategoryCount, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.counts, nil
+}
+
+// mockTrainer реализует интерфейс CategoryTrainer для тестирования
+type mockTrainer struct {
+	examples []TrainingExampleDTO
+}
+
+func (m *mockTrainer) Train(examples []TrainingExampleDTO) TrainStatsDTO {
+	m.examples = examples
+	return TrainStatsDTO{
+		Examples:   len(examples),
+		Categories: []CategoryModelDTO{{Category: "go", Examples: len(examples), Active: true}},
+	}
+}
+
+func newCategoriesRepository(t *testing.T) *mockRepositoryForCategories {
+	t.Helper()
+
+	post, err := dom.NewPost("Вышел Go 1.24", "Релиз Go 1.24 уже доступен.", "https://example.com/1", 1)
+	if err != nil {
+		t.Fatalf("failed to create post: %v", err)
+	}
+	id, _ := dom.NewPostID(1)
+	post.SetID(id)
+
+	return &mockRepositoryForCategories{posts: map[int32]*dom.Post{1: post}}
+}
+
+func TestTrainCategoriesUseCase_Execute(t *testing.T) {
+	t.Run(
+		"labels posts and trains on labeled", func(t *testing.T) {
+			repo := newCategoriesRepository(t)
+			trainer := &mockTrainer{}
+
+			out, err := NewTrainCategoriesUseCase(repo, trainer).Execute(
+				context.Background(), TrainCategoriesInputDTO{
+					Labels: []CategoryLabelDTO{{PostID: 1, Categories: []string{"Go", "go", "Releases"}}},
+				},
+			)
+			if err != nil {
+				t.Fatalf("expected no error, got %v", err)
+			}
+
+			if out.Labeled != 1 || out.Examples != 1 || len(out.Categories) != 1 {
+				t.Errorf("unexpected output: %+v", out)
+			}
+			if len(trainer.examples) != 1 {
+				t.Fatalf("expected 1 training example, got %d", len(trainer.examples))
+			}
+			example := trainer.examples[0]
+			if example.Title != "Вышел Go 1.24" || len(example.Categories) != 2 ||
+				example.Categories[0] != "go" || example.Categories[1] != "releases" {
+				t.Errorf("unexpected training example: %+v", example)
+			}
+		},
+	)
+
+	t.Run(
+		"invalid category labels nothing", func(t *testing.T) {
+			repo := newCategoriesRepository(t)
+			repo.posts[2] = repo.posts[1]
+
+			_, err := NewTrainCategoriesUseCase(repo, &mockTrainer{}).Execute(
+				context.Background(), TrainCategoriesInputDTO{
+					Labels: []CategoryLabelDTO{
+						{PostID: 1, Categories: []string{"go"}},
+						{PostID: 2, Categories: []string{"c++"}},
+					},
+				},
+			)
+			if !errors.Is(err, dom.ErrInvalidPostCategory) {
+				t.Fatalf("expected ErrInvalidPostCategory, got %v", err)
+			}
+			if len(repo.labeled) != 0 {
+				t.Errorf("expected no labeled posts, got %v", repo.labeled)
+			}
+		},
+	)
+
+	t.Run(
+		"invalid post ID", func(t *testing.T) {
+			_, err := NewTrainCategoriesUseCase(newCategoriesRepository(t), &mockTrainer{}).Execute(
+				context.Background(), TrainCategoriesInputDTO{Labels: []CategoryLabelDTO{{PostID: 0}}},
+			)
+			if !errors.Is(err, dom.ErrInvalidPostID) {
+				t.Errorf("expected ErrInvalidPostID, got %v", err)
+			}
+		},
+	)
+
+	t.Run(
+		"post not found", func(t *testing.T) {
+			_, err := NewTrainCategoriesUseCase(newCategoriesRepository(t), &mockTrainer{}).Execute(
+				context.Background(), TrainCategoriesInputDTO{
+					Labels: []CategoryLabelDTO{{PostID: 42, Categories: []string{"go"}}},
+				},
+			)
+			if !errors.Is(err, dom.ErrPostNotFound) {
+				t.Errorf("expected ErrPostNotFound, got %v", err)
+			}
+		},
+	)
+
+	t.Run(
+		"repository error", func(t *testing.T) {
+			repoErr := errors.New("database error")
+			repo := newCategoriesRepository(t)
+			repo.err = repoErr
+
+			_, err := NewTrainCategoriesUseCase(repo, &mockTrainer{}).Execute(
+				context.Background(), TrainCategoriesInputDTO{},
+			)
+			if !errors.Is(err, repoErr) {
+				t.Errorf("expected repository error, got %v", err)
+			}
+		},
+	)
+}
+
+func TestFindCategoriesUseCase_Execute(t *testing.T) {
+	golang, _ := dom.NewPostCategory("go")
+	devops, _ := dom.NewPostCategory("devops")
+	repo := &mockRepositoryForCategories{
+		counts: []dom.CategoryCount{{Category: golang, Count: 5}, {Category: devops, Count: 2}},
+	}
+
+	categories, err := NewFindCategoriesUseCase(repo).Execute(context.Background())
+	if err != nil {
+		t.Fatalf("expected no error, got %v", err)
+	}
+	if len(categories) != 2 || categories[0] != (CategoryDTO{Category: "go", Count: 5}) ||
+		categories[1] != (CategoryDTO{Category: "devops", Count: 2}) {
+		t.Errorf("unexpected categories: %+v", categories)
+	}
+}
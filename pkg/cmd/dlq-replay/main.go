@@ -0,0 +1,48 @@
+// Command dlq-replay отправляет сообщения dead-letter топика обратно в исходные топики.
+// Повторно отправленное сообщение обрабатывает только группа consumer'а, которая не смогла его обработать.
+//
+//	go run ./cmd/dlq-replay -brokers localhost:9092 -topic comments.moderated.dlq
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/ee-crocush/go-news/pkg/kafka"
+)
+
+func main() {
+	brokers := flag.String("brokers", "localhost:9092", "брокеры Kafka через запятую")
+	topic := flag.String("topic", "", "dead-letter топик")
+	group := flag.String("group", "dlq-replay", "группа consumer'а для подтверждения отправленных сообщений")
+	limit := flag.Int("limit", 0, "максимальное количество сообщений (0 - все)")
+	idle := flag.Duration("idle", 10*time.Second, "завершение, если новых сообщений нет дольше")
+	flag.Parse()
+
+	if *topic == "" {
+		fmt.Fprintln(os.Stderr, "flag -topic is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	replayer := kafka.NewReplayer(strings.Split(*brokers, ","), *topic, *group)
+	result, err := replayer.Replay(ctx, *limit, *idle)
+	if closeErr := replayer.Close(); closeErr != nil {
+		fmt.Fprintf(os.Stderr, "failed to close replayer: %v\n", closeErr)
+	}
+
+	fmt.Printf("replayed: %d, skipped: %d\n", result.Replayed, result.Skipped)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "replay failed: %v\n", err)
+		os.Exit(1)
+	}
+}
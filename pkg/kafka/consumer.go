@@ -16,14 +16,91 @@ type ConsumerProcessor interface {
 	Execute(ctx context.Context, msg kafka.Message) error
 }
 
+// DeadLetterPublisher — интерфейс отправки сообщения в dead-letter топик.
+type DeadLetterPublisher interface {
+	PublishMessage(ctx context.Context, msg kafka.Message) error
+}
+
+// RetryPolicy - политика повторной обработки сообщения. Нулевые значения заменяются значениями по умолчанию.
+type RetryPolicy struct {
+	// Attempts - количество попыток обработки, включая первую.
+	Attempts int
+	// MinBackoff и MaxBackoff - границы экспоненциальной задержки между попытками.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+}
+
+const (
+	defaultRetryAttempts   = 3
+	defaultRetryMinBackoff = time.Second
+	defaultRetryMaxBackoff = 30 * time.Second
+)
+
+// withDefaults возвращает политику с заполненными значениями по умолчанию.
+func (p RetryPolicy) withDefaults() RetryPolicy {
+	if p.Attempts <= 0 {
+		p.Attempts = defaultRetryAttempts
+	}
+	if p.MinBackoff <= 0 {
+		p.MinBackoff = defaultRetryMinBackoff
+	}
+	if p.MaxBackoff < p.MinBackoff {
+		p.MaxBackoff = max(defaultRetryMaxBackoff, p.MinBackoff)
+	}
+
+	return p
+}
+
+// backoff возвращает задержку после неудачной попытки attempt (с 1): MinBackoff, затем удваивается до MaxBackoff.
+func (p RetryPolicy) backoff(attempt int) time.Duration {
+	delay := p.MinBackoff
+	for i := 1; i < attempt && delay < p.MaxBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, p.MaxBackoff)
+}
+
+// ConsumerOption - параметр Consumer.
+type ConsumerOption func(c *Consumer)
+
+// WithRetryPolicy задает политику повторной обработки сообщений.
+func WithRetryPolicy(policy RetryPolicy) ConsumerOption {
+	return func(c *Consumer) {
+		c.retry = policy.withDefaults()
+	}
+}
+
+// WithWorkers включает параллельную обработку сообщений n обработчиками. Сообщения с одинаковым ключом
+// обрабатываются по порядку одним обработчиком, смещения подтверждаются только до первого
+// необработанного сообщения партиции. При n <= 1 сообщения обрабатываются последовательно.
+func WithWorkers(n int) ConsumerOption {
+	return func(c *Consumer) {
+		c.workers = n
+	}
+}
+
+// WithDeadLetter задает публикацию сообщений, которые не удалось обработать, в dead-letter топик.
+func WithDeadLetter(dlq DeadLetterPublisher) ConsumerOption {
+	return func(c *Consumer) {
+		c.dlq = dlq
+	}
+}
+
 // Consumer представляет собой Kafka consumer.
 type Consumer struct {
-	reader  *kafka.Reader
+	reader  messageReader
 	handler ConsumerProcessor
+	groupID string
+	retry   RetryPolicy
+	dlq     DeadLetterPublisher
+	workers int
+	now     func() time.Time
 }
 
-// NewConsumer создает новый экземпляр Consumer.
-func NewConsumer(brokers []string, topic, groupID string, handler ConsumerProcessor) *Consumer {
+// NewConsumer создает новый экземпляр Consumer. Сообщение, которое не удалось обработать за все попытки
+// политики повторов, отправляется в dead-letter топик, если он задан, иначе пропускается с записью в лог.
+func NewConsumer(brokers []string, topic, groupID string, handler ConsumerProcessor, opts ...ConsumerOption) *Consumer {
 	reader := kafka.NewReader(
 		kafka.ReaderConfig{
 			Brokers:        brokers,
@@ -35,11 +112,27 @@ func NewConsumer(brokers []string, topic, groupID string, handler ConsumerProces
 			MaxBytes:       10e6,
 		},
 	)
-	return &Consumer{reader: reader, handler: handler}
+
+	c := &Consumer{
+		reader:  reader,
+		handler: handler,
+		groupID: groupID,
+		retry:   RetryPolicy{}.withDefaults(),
+		now:     time.Now,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
 }
 
 // Start запускает consumer для обработки сообщений.
 func (c *Consumer) Start(ctx context.Context) error {
+	if c.workers > 1 {
+		return c.startWorkers(ctx)
+	}
+
 	log := logger.GetLogger()
 	log.Info().Msg("Starting Kafka consumer...")
 
@@ -49,33 +142,16 @@ func (c *Consumer) Start(ctx context.Context) error {
 			log.Info().Msg("Stopping Kafka consumer...")
 			return c.reader.Close()
 		default:
-			msg, err := c.reader.FetchMessage(ctx)
+			msg, ok, err := c.fetch(ctx)
 			if err != nil {
-				if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
-					// просто ждём дальше
-					continue
-				}
-
-				// Добавляем retry для GroupCoordinatorNotAvailable
-				if kafkaErr, ok := err.(kafka.Error); ok && kafkaErr.Temporary() {
-					log.Warn().Err(err).Msg("Temporary Kafka error, retrying...")
-					time.Sleep(time.Second * 5)
-					continue
-				}
-
-				return fmt.Errorf("failed to fetch message: %w", err)
+				return err
 			}
-
-			if msg.Offset == 0 && len(msg.Topic) == 0 {
-				time.Sleep(time.Second * 2)
+			if !ok {
 				continue
 			}
 
-			if err = c.handler.Execute(ctx, msg); err != nil {
-				log.
-					Err(err).
-					RawJSON(string(msg.Key), msg.Value).
-					Msg("Failed to to update comment status")
+			if err = c.process(ctx, msg); err != nil {
+				// Остановка во время повторов: сообщение не подтверждено и будет получено снова
 				continue
 			}
 
@@ -88,6 +164,137 @@ func (c *Consumer) Start(ctx context.Context) error {
 	}
 }
 
+// fetch получает следующее сообщение. ok = false означает, что сообщения нет и запрос нужно повторить.
+func (c *Consumer) fetch(ctx context.Context) (msg kafka.Message, ok bool, err error) {
+	msg, err = c.reader.FetchMessage(ctx)
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			// просто ждём дальше
+			return msg, false, nil
+		}
+
+		// Добавляем retry для GroupCoordinatorNotAvailable
+		if kafkaErr, ok := err.(kafka.Error); ok && kafkaErr.Temporary() {
+			logger.GetLogger().Warn().Err(err).Msg("Temporary Kafka error, retrying...")
+			time.Sleep(time.Second * 5)
+			return msg, false, nil
+		}
+
+		return msg, false, fmt.Errorf("failed to fetch message: %w", err)
+	}
+
+	if msg.Offset == 0 && len(msg.Topic) == 0 {
+		time.Sleep(time.Second * 2)
+		return msg, false, nil
+	}
+
+	return msg, true, nil
+}
+
+// process обрабатывает сообщение по политике повторов. Сообщение, которое не удалось обработать,
+// отправляется в dead-letter топик; отправка повторяется, пока не удастся, чтобы сообщение не потерялось.
+// Сообщение, повторно отправленное из dead-letter топика для другой группы, пропускается.
+// Ошибка возвращается только при отмене ctx: тогда сообщение нельзя подтверждать.
+func (c *Consumer) process(ctx context.Context, msg kafka.Message) error {
+	log := logger.GetLogger()
+	ctx = withRequestID(ctx, msg)
+
+	if group := replayedFor(msg); group != "" && group != c.groupID {
+		log.Debug().
+			Str("topic", msg.Topic).
+			Int64("offset", msg.Offset).
+			Str("replay_group", group).
+			Msg("Kafka message replayed for another consumer group skipped")
+		return nil
+	}
+
+	var err error
+	attempt := 1
+	for ; ; attempt++ {
+		if err = c.handler.Execute(ctx, msg); err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		log.Warn().
+			Err(err).
+			Str("topic", msg.Topic).
+			Int("partition", msg.Partition).
+			Int64("offset", msg.Offset).
+			Int("attempt", attempt).
+			Msg("Failed to process Kafka message")
+
+		if attempt >= c.retry.Attempts {
+			break
+		}
+		if err = wait(ctx, c.retry.backoff(attempt)); err != nil {
+			return err
+		}
+	}
+
+	if c.dlq == nil {
+		log.Error().
+			Err(err).
+			Str("topic", msg.Topic).
+			Int("partition", msg.Partition).
+			Int64("offset", msg.Offset).
+			Msg("Kafka message skipped: dead-letter topic is not configured")
+		return nil
+	}
+
+	dead := NewDeadLetterMessage(msg, c.groupID, err, attempt, c.now())
+	for retry := 1; ; retry++ {
+		dlqErr := c.dlq.PublishMessage(ctx, dead)
+		if dlqErr == nil {
+			break
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		log.Err(dlqErr).Int64("offset", msg.Offset).Msg("Failed to publish message to dead-letter topic")
+		if dlqErr = wait(ctx, c.retry.backoff(retry)); dlqErr != nil {
+			return dlqErr
+		}
+	}
+
+	log.Error().
+		Err(err).
+		Str("topic", msg.Topic).
+		Int("partition", msg.Partition).
+		Int64("offset", msg.Offset).
+		Msg("Kafka message moved to dead-letter topic")
+
+	return nil
+}
+
+// withRequestID добавляет в контекст request_id из заголовка сообщения, чтобы события,
+// отправленные при обработке, продолжили цепочку запроса.
+func withRequestID(ctx context.Context, msg kafka.Message) context.Context {
+	for _, h := range msg.Headers {
+		if h.Key == HeaderRequestID && len(h.Value) > 0 {
+			return logger.WithRequestID(ctx, string(h.Value))
+		}
+	}
+
+	return ctx
+}
+
+// wait ожидает d или отмены ctx.
+func wait(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
 func (c *Consumer) Close() error {
 	return c.reader.Close()
 }
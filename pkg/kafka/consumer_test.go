@@ -0,0 +1,182 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// mockProcessor реализует интерфейс ConsumerProcessor для тестирования
+type mockProcessor struct {
+	calls    int
+	failures int
+}
+
+func (m *mockProcessor) Execute(ctx context.Context, msg kafka.Message) error {
+	m.calls++
+	if m.calls <= m.failures {
+		return errors.New("database error")
+	}
+	return nil
+}
+
+// mockDeadLetter реализует интерфейс DeadLetterPublisher для тестирования
+type mockDeadLetter struct {
+	mu       sync.Mutex
+	messages []kafka.Message
+	failures int
+}
+
+func (m *mockDeadLetter) PublishMessage(ctx context.Context, msg kafka.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.failures > 0 {
+		m.failures--
+		return errors.New("broker unavailable")
+	}
+	m.messages = append(m.messages, msg)
+	return nil
+}
+
+func newTestConsumer(handler ConsumerProcessor, opts ...ConsumerOption) *Consumer {
+	c := &Consumer{handler: handler, groupID: "group", retry: RetryPolicy{}.withDefaults(), now: time.Now}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c
+}
+
+func headerValue(msg kafka.Message, key string) string {
+	for _, h := range msg.Headers {
+		if h.Key == key {
+			return string(h.Value)
+		}
+	}
+	return ""
+}
+
+func TestConsumer_Process(t *testing.T) {
+	policy := RetryPolicy{Attempts: 3, MinBackoff: time.Millisecond, MaxBackoff: time.Millisecond}
+	msg := kafka.Message{Topic: "comments.moderated", Partition: 1, Offset: 42, Key: []byte("7"), Value: []byte(`{}`)}
+
+	t.Run(
+		"succeeds after retry", func(t *testing.T) {
+			handler := &mockProcessor{failures: 2}
+			dlq := &mockDeadLetter{}
+			c := newTestConsumer(handler, WithRetryPolicy(policy), WithDeadLetter(dlq))
+
+			if err := c.process(context.Background(), msg); err != nil {
+				t.Fatalf("process() error = %v", err)
+			}
+			if handler.calls != 3 {
+				t.Errorf("expected 3 calls, got %d", handler.calls)
+			}
+			if len(dlq.messages) != 0 {
+				t.Errorf("expected no dead-letter messages, got %d", len(dlq.messages))
+			}
+		},
+	)
+
+	t.Run(
+		"moves to dead-letter topic after all attempts", func(t *testing.T) {
+			handler := &mockProcessor{failures: 10}
+			dlq := &mockDeadLetter{failures: 1}
+			c := newTestConsumer(handler, WithRetryPolicy(policy), WithDeadLetter(dlq))
+
+			if err := c.process(context.Background(), msg); err != nil {
+				t.Fatalf("process() error = %v", err)
+			}
+			if handler.calls != 3 {
+				t.Errorf("expected 3 calls, got %d", handler.calls)
+			}
+			if len(dlq.messages) != 1 {
+				t.Fatalf("expected 1 dead-letter message, got %d", len(dlq.messages))
+			}
+
+			dead := dlq.messages[0]
+			if string(dead.Key) != "7" || string(dead.Value) != `{}` {
+				t.Errorf("unexpected dead-letter message: %s %s", dead.Key, dead.Value)
+			}
+			if got := headerValue(dead, HeaderDLQAttempts); got != "3" {
+				t.Errorf("expected 3 attempts, got %q", got)
+			}
+			if got := headerValue(dead, HeaderDLQError); got != "database error" {
+				t.Errorf("expected error header, got %q", got)
+			}
+		},
+	)
+
+	t.Run(
+		"skips without dead-letter topic", func(t *testing.T) {
+			handler := &mockProcessor{failures: 10}
+			c := newTestConsumer(handler, WithRetryPolicy(policy))
+
+			if err := c.process(context.Background(), msg); err != nil {
+				t.Fatalf("process() error = %v", err)
+			}
+			if handler.calls != 3 {
+				t.Errorf("expected 3 calls, got %d", handler.calls)
+			}
+		},
+	)
+
+	t.Run(
+		"replayed message is processed only by its group", func(t *testing.T) {
+			replayed := msg
+			replayed.Headers = []kafka.Header{{Key: HeaderReplayConsumerGroup, Value: []byte("other")}}
+
+			handler := &mockProcessor{}
+			if err := newTestConsumer(handler).process(context.Background(), replayed); err != nil {
+				t.Fatalf("process() error = %v", err)
+			}
+			if handler.calls != 0 {
+				t.Errorf("expected message for another group to be skipped, got %d calls", handler.calls)
+			}
+
+			replayed.Headers[0].Value = []byte("group")
+			if err := newTestConsumer(handler).process(context.Background(), replayed); err != nil {
+				t.Fatalf("process() error = %v", err)
+			}
+			if handler.calls != 1 {
+				t.Errorf("expected message for own group to be processed, got %d calls", handler.calls)
+			}
+		},
+	)
+
+	t.Run(
+		"canceled during retries", func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			cancel()
+
+			handler := &mockProcessor{failures: 10}
+			dlq := &mockDeadLetter{}
+			c := newTestConsumer(handler, WithRetryPolicy(policy), WithDeadLetter(dlq))
+
+			if err := c.process(ctx, msg); !errors.Is(err, context.Canceled) {
+				t.Fatalf("expected context.Canceled, got %v", err)
+			}
+			if len(dlq.messages) != 0 {
+				t.Errorf("expected no dead-letter messages, got %d", len(dlq.messages))
+			}
+		},
+	)
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	policy := RetryPolicy{MinBackoff: time.Second, MaxBackoff: 5 * time.Second}.withDefaults()
+
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second}
+	for i, w := range want {
+		if got := policy.backoff(i + 1); got != w {
+			t.Errorf("backoff(%d) = %s, want %s", i+1, got, w)
+		}
+	}
+	if policy.Attempts != defaultRetryAttempts {
+		t.Errorf("expected default attempts %d, got %d", defaultRetryAttempts, policy.Attempts)
+	}
+}
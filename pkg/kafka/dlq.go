@@ -0,0 +1,204 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ee-crocush/go-news/pkg/logger"
+
+	"github.com/segmentio/kafka-go"
+)
+
+// Заголовки сообщения dead-letter топика с данными об ошибке обработки.
+const (
+	HeaderDLQPrefix            = "x-dlq-"
+	HeaderDLQError             = HeaderDLQPrefix + "error"
+	HeaderDLQAttempts          = HeaderDLQPrefix + "attempts"
+	HeaderDLQOriginalTopic     = HeaderDLQPrefix + "original-topic"
+	HeaderDLQOriginalPartition = HeaderDLQPrefix + "original-partition"
+	HeaderDLQOriginalOffset    = HeaderDLQPrefix + "original-offset"
+	HeaderDLQConsumerGroup     = HeaderDLQPrefix + "consumer-group"
+	HeaderDLQFailedAt          = HeaderDLQPrefix + "failed-at"
+)
+
+// HeaderReplayConsumerGroup - группа consumer'а, для которой повторно отправлено сообщение dead-letter
+// топика. Consumer других групп подтверждает такое сообщение без обработки: они его уже обработали.
+const HeaderReplayConsumerGroup = "x-replay-consumer-group"
+
+// ErrNoOriginalTopic представляет ошибку сообщения dead-letter топика без исходного топика.
+var ErrNoOriginalTopic = errors.New("dead-letter message has no original topic")
+
+// NewDeadLetterMessage создает сообщение dead-letter топика: ключ, значение и заголовки исходного сообщения
+// сохраняются, к заголовкам добавляются данные об ошибке обработки.
+func NewDeadLetterMessage(msg kafka.Message, groupID string, err error, attempts int, failedAt time.Time) kafka.Message {
+	headers := withoutDLQHeaders(msg.Headers)
+	headers = append(
+		headers,
+		kafka.Header{Key: HeaderDLQError, Value: []byte(err.Error())},
+		kafka.Header{Key: HeaderDLQAttempts, Value: []byte(strconv.Itoa(attempts))},
+		kafka.Header{Key: HeaderDLQOriginalTopic, Value: []byte(msg.Topic)},
+		kafka.Header{Key: HeaderDLQOriginalPartition, Value: []byte(strconv.Itoa(msg.Partition))},
+		kafka.Header{Key: HeaderDLQOriginalOffset, Value: []byte(strconv.FormatInt(msg.Offset, 10))},
+		kafka.Header{Key: HeaderDLQConsumerGroup, Value: []byte(groupID)},
+		kafka.Header{Key: HeaderDLQFailedAt, Value: []byte(failedAt.UTC().Format(time.RFC3339))},
+	)
+
+	return kafka.Message{Key: msg.Key, Value: msg.Value, Headers: headers, Time: failedAt}
+}
+
+// OriginalMessage восстанавливает исходное сообщение из сообщения dead-letter топика: топик берется
+// из заголовка, заголовки с данными об ошибке удаляются. Группа, не обработавшая сообщение, переносится
+// в заголовок HeaderReplayConsumerGroup, чтобы его обработала только она.
+func OriginalMessage(msg kafka.Message) (kafka.Message, error) {
+	var topic, group string
+	for _, h := range msg.Headers {
+		switch h.Key {
+		case HeaderDLQOriginalTopic:
+			topic = string(h.Value)
+		case HeaderDLQConsumerGroup:
+			group = string(h.Value)
+		}
+	}
+	if topic == "" {
+		return kafka.Message{}, ErrNoOriginalTopic
+	}
+
+	headers := make([]kafka.Header, 0, len(msg.Headers))
+	for _, h := range withoutDLQHeaders(msg.Headers) {
+		// Сообщение могло попасть в dead-letter топик повторно после прошлой повторной отправки.
+		if h.Key != HeaderReplayConsumerGroup {
+			headers = append(headers, h)
+		}
+	}
+	if group != "" {
+		headers = append(headers, kafka.Header{Key: HeaderReplayConsumerGroup, Value: []byte(group)})
+	}
+
+	return kafka.Message{
+		Topic:   topic,
+		Key:     msg.Key,
+		Value:   msg.Value,
+		Headers: headers,
+		Time:    time.Now(),
+	}, nil
+}
+
+// replayedFor возвращает группу, для которой сообщение отправлено повторно из dead-letter топика,
+// или пустую строку для обычного сообщения.
+func replayedFor(msg kafka.Message) string {
+	for _, h := range msg.Headers {
+		if h.Key == HeaderReplayConsumerGroup {
+			return string(h.Value)
+		}
+	}
+
+	return ""
+}
+
+// withoutDLQHeaders возвращает копию заголовков без данных об ошибке обработки.
+func withoutDLQHeaders(headers []kafka.Header) []kafka.Header {
+	result := make([]kafka.Header, 0, len(headers))
+	for _, h := range headers {
+		if !strings.HasPrefix(h.Key, HeaderDLQPrefix) {
+			result = append(result, h)
+		}
+	}
+
+	return result
+}
+
+// messageReader — интерфейс чтения сообщений с подтверждением.
+type messageReader interface {
+	FetchMessage(ctx context.Context) (kafka.Message, error)
+	CommitMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// messageWriter — интерфейс записи сообщений.
+type messageWriter interface {
+	WriteMessages(ctx context.Context, msgs ...kafka.Message) error
+	Close() error
+}
+
+// ReplayResult - итог повторной отправки сообщений dead-letter топика.
+type ReplayResult struct {
+	// Replayed - количество сообщений, отправленных в исходные топики.
+	Replayed int
+	// Skipped - количество сообщений без исходного топика.
+	Skipped int
+}
+
+// Replayer отправляет сообщения dead-letter топика обратно в исходные топики. Прочитанные сообщения
+// подтверждаются в группе replayer'а, поэтому повторный запуск не отправляет их снова. Отправленное
+// сообщение обрабатывает только группа, которая не смогла его обработать (см. OriginalMessage).
+type Replayer struct {
+	reader messageReader
+	writer messageWriter
+}
+
+// NewReplayer создает новый Replayer для dead-letter топика.
+func NewReplayer(brokers []string, dlqTopic, groupID string) *Replayer {
+	return &Replayer{
+		reader: kafka.NewReader(
+			kafka.ReaderConfig{
+				Brokers:     brokers,
+				Topic:       dlqTopic,
+				GroupID:     groupID,
+				StartOffset: kafka.FirstOffset,
+				MinBytes:    1,
+				MaxBytes:    10e6,
+			},
+		),
+		// Как и Publisher: повторно отправленные сообщения попадают в партицию своего ключа.
+		writer: &kafka.Writer{
+			Addr:         kafka.TCP(brokers...),
+			Balancer:     &kafka.Hash{},
+			RequiredAcks: kafka.RequireAll,
+		},
+	}
+}
+
+// Replay отправляет сообщения в исходные топики, пока не отправит limit сообщений (0 - без ограничения)
+// или новых сообщений не будет дольше idle. Сообщение, которое не удалось отправить, не подтверждается.
+func (r *Replayer) Replay(ctx context.Context, limit int, idle time.Duration) (ReplayResult, error) {
+	log := logger.GetLogger()
+
+	var result ReplayResult
+	for limit <= 0 || result.Replayed+result.Skipped < limit {
+		fetchCtx, cancel := context.WithTimeout(ctx, idle)
+		msg, err := r.reader.FetchMessage(fetchCtx)
+		cancel()
+		if err != nil {
+			if errors.Is(err, context.DeadlineExceeded) && ctx.Err() == nil {
+				break
+			}
+			return result, fmt.Errorf("Replayer.FetchMessage: %w", err)
+		}
+
+		original, err := OriginalMessage(msg)
+		if err != nil {
+			log.Warn().Err(err).Int64("offset", msg.Offset).Msg("Dead-letter message skipped")
+			result.Skipped++
+		} else {
+			if err = r.writer.WriteMessages(ctx, original); err != nil {
+				return result, fmt.Errorf("Replayer.WriteMessages: %w", err)
+			}
+			result.Replayed++
+		}
+
+		if err = r.reader.CommitMessages(ctx, msg); err != nil {
+			return result, fmt.Errorf("Replayer.CommitMessages: %w", err)
+		}
+	}
+
+	return result, nil
+}
+
+// Close закрывает соединения с Kafka.
+func (r *Replayer) Close() error {
+	return errors.Join(r.reader.Close(), r.writer.Close())
+}
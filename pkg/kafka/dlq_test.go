@@ -0,0 +1,170 @@
+package kafka
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+func TestDeadLetterMessage_RoundTrip(t *testing.T) {
+	failedAt := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	msg := kafka.Message{
+		Topic:     "comments.moderated",
+		Partition: 2,
+		Offset:    15,
+		Key:       []byte("7"),
+		Value:     []byte(`{"id":7}`),
+		Headers:   []kafka.Header{{Key: "x-request-id", Value: []byte("abc")}},
+	}
+
+	dead := NewDeadLetterMessage(msg, "comments_service_group", errors.New("boom"), 3, failedAt)
+
+	wantHeaders := map[string]string{
+		HeaderDLQError:             "boom",
+		HeaderDLQAttempts:          "3",
+		HeaderDLQOriginalTopic:     "comments.moderated",
+		HeaderDLQOriginalPartition: "2",
+		HeaderDLQOriginalOffset:    "15",
+		HeaderDLQConsumerGroup:     "comments_service_group",
+		HeaderDLQFailedAt:          "2025-01-01T10:00:00Z",
+		"x-request-id":             "abc",
+	}
+	for key, want := range wantHeaders {
+		if got := headerValue(dead, key); got != want {
+			t.Errorf("header %s = %q, want %q", key, got, want)
+		}
+	}
+
+	original, err := OriginalMessage(dead)
+	if err != nil {
+		t.Fatalf("OriginalMessage() error = %v", err)
+	}
+	if original.Topic != msg.Topic || string(original.Key) != "7" || string(original.Value) != `{"id":7}` {
+		t.Errorf("unexpected original message: %+v", original)
+	}
+	if len(original.Headers) != 2 || original.Headers[0].Key != "x-request-id" {
+		t.Errorf("expected original headers and replay group, got %v", original.Headers)
+	}
+	if got := headerValue(original, HeaderReplayConsumerGroup); got != "comments_service_group" {
+		t.Errorf("expected replay group header, got %q", got)
+	}
+
+	// Повторная ошибка после повторной отправки: группа заголовка заменяется, а не дублируется.
+	again, err := OriginalMessage(NewDeadLetterMessage(original, "other_group", errors.New("boom"), 3, failedAt))
+	if err != nil {
+		t.Fatalf("OriginalMessage() error = %v", err)
+	}
+	if len(again.Headers) != 2 || headerValue(again, HeaderReplayConsumerGroup) != "other_group" {
+		t.Errorf("expected single replay group header, got %v", again.Headers)
+	}
+
+	if _, err = OriginalMessage(kafka.Message{Value: []byte(`{}`)}); !errors.Is(err, ErrNoOriginalTopic) {
+		t.Errorf("expected ErrNoOriginalTopic, got %v", err)
+	}
+}
+
+// mockReader реализует интерфейс messageReader для тестирования
+type mockReader struct {
+	messages  []kafka.Message
+	committed []int64
+}
+
+func (m *mockReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	if len(m.messages) == 0 {
+		<-ctx.Done()
+		return kafka.Message{}, ctx.Err()
+	}
+	msg := m.messages[0]
+	m.messages = m.messages[1:]
+	return msg, nil
+}
+
+func (m *mockReader) CommitMessages(ctx context.Context, msgs ...kafka.Message) error {
+	for _, msg := range msgs {
+		m.committed = append(m.committed, msg.Offset)
+	}
+	return nil
+}
+
+func (m *mockReader) Close() error { return nil }
+
+// mockWriter реализует интерфейс messageWriter для тестирования
+type mockWriter struct {
+	messages []kafka.Message
+	err      error
+}
+
+func (m *mockWriter) WriteMessages(ctx context.Context, msgs ...kafka.Message) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.messages = append(m.messages, msgs...)
+	return nil
+}
+
+func (m *mockWriter) Close() error { return nil }
+
+func TestReplayer_Replay(t *testing.T) {
+	dead := func(offset int64) kafka.Message {
+		msg := NewDeadLetterMessage(
+			kafka.Message{Topic: "comments.moderated", Value: []byte(`{}`)}, "group", errors.New("boom"), 3,
+			time.Now(),
+		)
+		msg.Offset = offset
+		return msg
+	}
+
+	t.Run(
+		"replays until idle", func(t *testing.T) {
+			reader := &mockReader{messages: []kafka.Message{dead(0), {Offset: 1}, dead(2)}}
+			writer := &mockWriter{}
+			replayer := &Replayer{reader: reader, writer: writer}
+
+			result, err := replayer.Replay(context.Background(), 0, 10*time.Millisecond)
+			if err != nil {
+				t.Fatalf("Replay() error = %v", err)
+			}
+			if result.Replayed != 2 || result.Skipped != 1 {
+				t.Errorf("unexpected result: %+v", result)
+			}
+			if len(writer.messages) != 2 || writer.messages[0].Topic != "comments.moderated" {
+				t.Errorf("unexpected replayed messages: %v", writer.messages)
+			}
+			if len(reader.committed) != 3 {
+				t.Errorf("expected 3 committed messages, got %v", reader.committed)
+			}
+		},
+	)
+
+	t.Run(
+		"stops at limit", func(t *testing.T) {
+			reader := &mockReader{messages: []kafka.Message{dead(0), dead(1)}}
+			replayer := &Replayer{reader: reader, writer: &mockWriter{}}
+
+			result, err := replayer.Replay(context.Background(), 1, time.Second)
+			if err != nil {
+				t.Fatalf("Replay() error = %v", err)
+			}
+			if result.Replayed != 1 || len(reader.messages) != 1 {
+				t.Errorf("expected one replayed message, got %+v", result)
+			}
+		},
+	)
+
+	t.Run(
+		"write error leaves message uncommitted", func(t *testing.T) {
+			reader := &mockReader{messages: []kafka.Message{dead(0)}}
+			replayer := &Replayer{reader: reader, writer: &mockWriter{err: errors.New("broker unavailable")}}
+
+			if _, err := replayer.Replay(context.Background(), 0, time.Second); err == nil {
+				t.Fatal("expected error")
+			}
+			if len(reader.committed) != 0 {
+				t.Errorf("expected no committed messages, got %v", reader.committed)
+			}
+		},
+	)
+}
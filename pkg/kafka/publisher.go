@@ -3,48 +3,217 @@ package kafka
 import (
 	"context"
 	"fmt"
-	"github.com/ee-crocush/go-news/pkg/logger"
+	"strconv"
 	"time"
 
+	"github.com/ee-crocush/go-news/pkg/logger"
+
 	"github.com/segmentio/kafka-go"
 )
 
+// Заголовки сообщений, которые добавляет Publisher.
+const (
+	HeaderRequestID     = "x-request-id"
+	HeaderEventType     = "x-event-type"
+	HeaderSchemaVersion = "x-schema-version"
+)
+
+// DeliveryCallback вызывается после асинхронной отправки пакета сообщений, err != nil - пакет не доставлен.
+// Вызывается из горутины writer'а и не должен блокироваться.
+type DeliveryCallback func(messages []kafka.Message, err error)
+
+// PublisherConfig - параметры отправки. Нулевые значения заменяются значениями по умолчанию kafka-go,
+// кроме Acks: по умолчанию сообщение подтверждают все реплики.
+type PublisherConfig struct {
+	// Acks - подтверждение записи: all - всеми репликами, one - лидером партиции, none - без подтверждения.
+	Acks string
+	// Compression - сжатие сообщений: none, gzip, snappy, lz4 или zstd.
+	Compression string
+	// BatchSize - максимальное количество сообщений в пакете.
+	BatchSize int
+	// BatchTimeout - максимальное время накопления неполного пакета.
+	BatchTimeout time.Duration
+}
+
+// requiredAcks возвращает уровень подтверждения записи по названию.
+func requiredAcks(acks string) kafka.RequiredAcks {
+	switch acks {
+	case "one":
+		return kafka.RequireOne
+	case "none":
+		return kafka.RequireNone
+	default:
+		return kafka.RequireAll
+	}
+}
+
+// compression возвращает алгоритм сжатия по названию, 0 - без сжатия.
+func compression(name string) kafka.Compression {
+	switch name {
+	case "gzip":
+		return kafka.Gzip
+	case "snappy":
+		return kafka.Snappy
+	case "lz4":
+		return kafka.Lz4
+	case "zstd":
+		return kafka.Zstd
+	default:
+		return 0
+	}
+}
+
+// PublisherOption - параметр Publisher.
+type PublisherOption func(p *Publisher)
+
+// WithPublisherConfig задает параметры отправки.
+func WithPublisherConfig(cfg PublisherConfig) PublisherOption {
+	return func(p *Publisher) {
+		p.writer.RequiredAcks = requiredAcks(cfg.Acks)
+		p.writer.Compression = compression(cfg.Compression)
+		p.writer.BatchSize = cfg.BatchSize
+		p.writer.BatchTimeout = cfg.BatchTimeout
+	}
+}
+
+// WithEventType добавляет к каждому сообщению заголовки с типом события и версией его схемы.
+func WithEventType(eventType string, schemaVersion int) PublisherOption {
+	return func(p *Publisher) {
+		p.headers = append(
+			p.headers,
+			kafka.Header{Key: HeaderEventType, Value: []byte(eventType)},
+			kafka.Header{Key: HeaderSchemaVersion, Value: []byte(strconv.Itoa(schemaVersion))},
+		)
+	}
+}
+
+// WithAsync включает асинхронную отправку: методы Publisher не ждут записи сообщений, а результат
+// передается в callback. Без callback ошибки доставки только записываются в лог. Подходит только
+// для событий, потеря которых при недоступности Kafka допустима.
+func WithAsync(callback DeliveryCallback) PublisherOption {
+	return func(p *Publisher) {
+		if callback == nil {
+			callback = logDeliveryError
+		}
+
+		p.writer.Async = true
+		p.writer.Completion = callback
+	}
+}
+
+// logDeliveryError записывает в лог ошибку асинхронной отправки.
+func logDeliveryError(messages []kafka.Message, err error) {
+	if err != nil {
+		logger.GetLogger().Err(err).Int("messages", len(messages)).Msg("Failed to deliver messages to Kafka")
+	}
+}
+
 // Publisher представляет Kafka publisher.
 type Publisher struct {
 	writer *kafka.Writer
+	// headers - заголовки, добавляемые к каждому сообщению.
+	headers []kafka.Header
 }
 
-// NewPublisher создает новый Kafka Publisher.
-func NewPublisher(brokers []string, topic string) *Publisher {
-	return &Publisher{
+// NewPublisher создает новый Kafka Publisher. Партиция выбирается по хешу ключа, поэтому сообщения
+// с одним ключом попадают в одну партицию и получатели обрабатывают их в порядке отправки.
+func NewPublisher(brokers []string, topic string, opts ...PublisherOption) *Publisher {
+	p := &Publisher{
 		writer: &kafka.Writer{
 			Addr:         kafka.TCP(brokers...),
 			Topic:        topic,
-			Balancer:     &kafka.LeastBytes{},
+			Balancer:     &kafka.Hash{},
 			RequiredAcks: kafka.RequireAll,
 		},
 	}
+	for _, opt := range opts {
+		opt(p)
+	}
+
+	return p
+}
+
+// Record - сообщение для отправки пакетом.
+type Record struct {
+	Key     string
+	Value   []byte
+	Headers []kafka.Header
 }
 
 // Publish отправляет событие.
 func (p *Publisher) Publish(ctx context.Context, key string, value []byte) error {
-	log := logger.GetLogger()
-	log.Info().Msgf("Starting publishing message key: %s", key)
+	return p.PublishBatch(ctx, []Record{{Key: key, Value: value}})
+}
 
-	msg := kafka.Message{
-		Key:   []byte(key),
-		Value: value,
-		Time:  time.Now(),
+// PublishBatch отправляет события одним запросом. В синхронном режиме при ошибке часть событий
+// может быть уже записана.
+func (p *Publisher) PublishBatch(ctx context.Context, records []Record) error {
+	if len(records) == 0 {
+		return nil
 	}
-	if err := p.writer.WriteMessages(ctx, msg); err != nil {
+
+	now := time.Now()
+	msgs := make([]kafka.Message, len(records))
+	for i, r := range records {
+		msgs[i] = kafka.Message{
+			Key:     []byte(r.Key),
+			Value:   r.Value,
+			Headers: p.withHeaders(ctx, r.Headers),
+			Time:    now,
+		}
+	}
+
+	if err := p.writer.WriteMessages(ctx, msgs...); err != nil {
 		return fmt.Errorf("failed to write message to Kafka: %w", err)
 	}
 
-	log.Info().Msgf("Message published successfully key: %s", key)
+	logger.GetLogger().Debug().
+		Str("topic", p.writer.Topic).
+		Int("messages", len(msgs)).
+		Msg("Messages published")
+
+	return nil
+}
+
+// PublishMessage отправляет готовое сообщение с его ключом и заголовками в топик publisher.
+func (p *Publisher) PublishMessage(ctx context.Context, msg kafka.Message) error {
+	msg.Topic = ""
+	msg.Headers = p.withHeaders(ctx, msg.Headers)
+	if msg.Time.IsZero() {
+		msg.Time = time.Now()
+	}
+	if err := p.writer.WriteMessages(ctx, msg); err != nil {
+		return fmt.Errorf("failed to write message to Kafka: %w", err)
+	}
 
 	return nil
 }
 
+// withHeaders дополняет заголовки сообщения заголовками publisher и request_id из контекста.
+// Заголовки, уже заданные в сообщении, не заменяются.
+func (p *Publisher) withHeaders(ctx context.Context, headers []kafka.Header) []kafka.Header {
+	result := make([]kafka.Header, 0, len(headers)+len(p.headers)+1)
+	result = append(result, headers...)
+
+	add := func(h kafka.Header) {
+		for _, existing := range result {
+			if existing.Key == h.Key {
+				return
+			}
+		}
+		result = append(result, h)
+	}
+
+	for _, h := range p.headers {
+		add(h)
+	}
+	if requestID := logger.RequestID(ctx); requestID != "" {
+		add(kafka.Header{Key: HeaderRequestID, Value: []byte(requestID)})
+	}
+
+	return result
+}
+
 func (p *Publisher) Close() error {
 	return p.writer.Close()
 }
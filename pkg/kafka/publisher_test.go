@@ -0,0 +1,85 @@
+package kafka
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/ee-crocush/go-news/pkg/logger"
+
+	"github.com/segmentio/kafka-go"
+)
+
+func TestNewPublisher_Options(t *testing.T) {
+	p := NewPublisher(
+		[]string{"localhost:9092"}, "comments.created",
+		WithPublisherConfig(
+			PublisherConfig{Acks: "one", Compression: "zstd", BatchSize: 50, BatchTimeout: 10 * time.Millisecond},
+		),
+		WithAsync(nil),
+	)
+
+	if p.writer.RequiredAcks != kafka.RequireOne {
+		t.Errorf("expected RequireOne, got %v", p.writer.RequiredAcks)
+	}
+	if p.writer.Compression != kafka.Zstd {
+		t.Errorf("expected zstd compression, got %v", p.writer.Compression)
+	}
+	if p.writer.BatchSize != 50 || p.writer.BatchTimeout != 10*time.Millisecond {
+		t.Errorf("unexpected batch settings: %d %s", p.writer.BatchSize, p.writer.BatchTimeout)
+	}
+	if !p.writer.Async || p.writer.Completion == nil {
+		t.Error("expected async mode with completion callback")
+	}
+
+	defaults := NewPublisher([]string{"localhost:9092"}, "comments.created", WithPublisherConfig(PublisherConfig{}))
+	if defaults.writer.RequiredAcks != kafka.RequireAll || defaults.writer.Compression != 0 || defaults.writer.Async {
+		t.Errorf("unexpected defaults: %+v", defaults.writer)
+	}
+	if _, ok := defaults.writer.Balancer.(*kafka.Hash); !ok {
+		t.Errorf("expected key hash balancer, got %T", defaults.writer.Balancer)
+	}
+}
+
+func TestPublisher_WithHeaders(t *testing.T) {
+	p := NewPublisher([]string{"localhost:9092"}, "comments.created", WithEventType("comment.created", 2))
+	ctx := logger.WithRequestID(context.Background(), "req-1")
+
+	headers := p.withHeaders(ctx, []kafka.Header{{Key: HeaderSchemaVersion, Value: []byte("3")}})
+	msg := kafka.Message{Headers: headers}
+
+	want := map[string]string{
+		HeaderEventType:     "comment.created",
+		HeaderSchemaVersion: "3",
+		HeaderRequestID:     "req-1",
+	}
+	if len(headers) != len(want) {
+		t.Fatalf("expected %d headers, got %v", len(want), headers)
+	}
+	for key, value := range want {
+		if got := headerValue(msg, key); got != value {
+			t.Errorf("header %s = %q, want %q", key, got, value)
+		}
+	}
+
+	if headers = p.withHeaders(context.Background(), nil); len(headers) != 2 {
+		t.Errorf("expected only event headers without request id, got %v", headers)
+	}
+
+	// request_id, сохраненный в сообщении (например, в outbox), не заменяется request_id из контекста
+	headers = p.withHeaders(ctx, []kafka.Header{{Key: HeaderRequestID, Value: []byte("req-0")}})
+	if got := headerValue(kafka.Message{Headers: headers}, HeaderRequestID); got != "req-0" {
+		t.Errorf("expected request id from message headers, got %q", got)
+	}
+}
+
+func TestWithRequestID(t *testing.T) {
+	msg := kafka.Message{Headers: []kafka.Header{{Key: HeaderRequestID, Value: []byte("req-1")}}}
+
+	if got := logger.RequestID(withRequestID(context.Background(), msg)); got != "req-1" {
+		t.Errorf("expected request id from header, got %q", got)
+	}
+	if got := logger.RequestID(withRequestID(context.Background(), kafka.Message{})); got != "" {
+		t.Errorf("expected no request id, got %q", got)
+	}
+}
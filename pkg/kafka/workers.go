@@ -0,0 +1,241 @@
+package kafka
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"sync"
+	"time"
+
+	"github.com/ee-crocush/go-news/pkg/logger"
+
+	"github.com/segmentio/kafka-go"
+)
+
+const (
+	// workerQueueSize - размер очереди сообщений одного обработчика.
+	workerQueueSize = 64
+	// commitTimeout - время ожидания подтверждения смещений.
+	commitTimeout = 10 * time.Second
+)
+
+// trackedMessage - сообщение, переданное обработчику, с поколением его партиции.
+type trackedMessage struct {
+	msg        kafka.Message
+	generation int
+}
+
+// topicPartition - партиция топика.
+type topicPartition struct {
+	topic     string
+	partition int
+}
+
+// partitionOffsets - состояние обработки сообщений партиции.
+type partitionOffsets struct {
+	generation int
+	// pending - смещения полученных и еще не подтвержденных сообщений в порядке получения.
+	pending []int64
+	done    map[int64]bool
+}
+
+// offsetTracker отслеживает обработку полученных сообщений и определяет, до какого смещения
+// сообщения партиции обработаны без пропусков.
+type offsetTracker struct {
+	mu         sync.Mutex
+	partitions map[topicPartition]*partitionOffsets
+}
+
+// newOffsetTracker создает новый offsetTracker.
+func newOffsetTracker() *offsetTracker {
+	return &offsetTracker{partitions: make(map[topicPartition]*partitionOffsets)}
+}
+
+// track регистрирует полученное сообщение и возвращает поколение его партиции. Смещение не больше
+// последнего полученного означает повторное получение после перебалансировки группы: состояние партиции
+// сбрасывается, а завершение обработки сообщений прежнего поколения не учитывается.
+func (t *offsetTracker) track(msg kafka.Message) int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	tp := topicPartition{topic: msg.Topic, partition: msg.Partition}
+	p, ok := t.partitions[tp]
+	if !ok {
+		p = &partitionOffsets{done: make(map[int64]bool)}
+		t.partitions[tp] = p
+	}
+
+	if n := len(p.pending); n > 0 && msg.Offset <= p.pending[n-1] {
+		p.generation++
+		p.pending = nil
+		p.done = make(map[int64]bool)
+	}
+	p.pending = append(p.pending, msg.Offset)
+
+	return p.generation
+}
+
+// complete отмечает сообщение обработанным и возвращает смещение последнего сообщения партиции,
+// до которого все полученные сообщения обработаны. ok = false, если это смещение не изменилось.
+func (t *offsetTracker) complete(m trackedMessage) (offset int64, ok bool) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	p := t.partitions[topicPartition{topic: m.msg.Topic, partition: m.msg.Partition}]
+	if p == nil || p.generation != m.generation {
+		return 0, false
+	}
+
+	p.done[m.msg.Offset] = true
+	for len(p.pending) > 0 && p.done[p.pending[0]] {
+		offset, ok = p.pending[0], true
+		delete(p.done, offset)
+		p.pending = p.pending[1:]
+	}
+
+	return offset, ok
+}
+
+// startWorkers получает сообщения и распределяет их между обработчиками по ключу, пока не отменен ctx.
+// Сообщения без ключа распределяются по очереди.
+func (c *Consumer) startWorkers(ctx context.Context) error {
+	log := logger.GetLogger()
+	log.Info().Int("workers", c.workers).Msg("Starting Kafka consumer...")
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	tracker := newOffsetTracker()
+	completed := make(chan trackedMessage, c.workers*workerQueueSize)
+
+	queues := make([]chan trackedMessage, c.workers)
+	var workers sync.WaitGroup
+	for i := range queues {
+		queues[i] = make(chan trackedMessage, workerQueueSize)
+		workers.Add(1)
+		go func(queue <-chan trackedMessage) {
+			defer workers.Done()
+			c.work(ctx, queue, completed)
+		}(queues[i])
+	}
+
+	commitErr := make(chan error, 1)
+	go func() {
+		commitErr <- c.commitCompleted(tracker, completed, cancel)
+	}()
+
+	var err error
+	next := 0
+	for ctx.Err() == nil {
+		msg, ok, fetchErr := c.fetch(ctx)
+		if fetchErr != nil {
+			err = fetchErr
+			break
+		}
+		if !ok {
+			continue
+		}
+
+		worker := next
+		if len(msg.Key) > 0 {
+			h := fnv.New32a()
+			_, _ = h.Write(msg.Key)
+			worker = int(h.Sum32() % uint32(c.workers))
+		} else {
+			next = (next + 1) % c.workers
+		}
+
+		m := trackedMessage{msg: msg, generation: tracker.track(msg)}
+		select {
+		case queues[worker] <- m:
+		case <-ctx.Done():
+		}
+	}
+
+	log.Info().Msg("Stopping Kafka consumer...")
+	for _, queue := range queues {
+		close(queue)
+	}
+	workers.Wait()
+	close(completed)
+
+	if cErr := <-commitErr; err == nil {
+		err = cErr
+	}
+	if closeErr := c.reader.Close(); err == nil {
+		err = closeErr
+	}
+
+	return err
+}
+
+// work обрабатывает сообщения очереди по порядку и передает обработанные в completed.
+// После отмены ctx оставшиеся сообщения не обрабатываются и не подтверждаются.
+func (c *Consumer) work(ctx context.Context, queue <-chan trackedMessage, completed chan<- trackedMessage) {
+	log := logger.GetLogger()
+
+	for m := range queue {
+		if ctx.Err() != nil {
+			continue
+		}
+		if err := c.process(ctx, m.msg); err != nil {
+			continue
+		}
+
+		log.Info().RawJSON(string(m.msg.Key), m.msg.Value).Msg("Kafka message processed successfully")
+		completed <- m
+	}
+}
+
+// commitCompleted подтверждает смещения обработанных сообщений, объединяя накопившиеся завершения
+// в одно подтверждение. После ошибки подтверждения отменяет получение сообщений через cancel
+// и продолжает читать completed до закрытия, чтобы не блокировать обработчики.
+func (c *Consumer) commitCompleted(
+	tracker *offsetTracker, completed <-chan trackedMessage, cancel context.CancelFunc,
+) error {
+	var err error
+	for m := range completed {
+		if err != nil {
+			continue
+		}
+
+		latest := make(map[topicPartition]int64)
+		add := func(m trackedMessage) {
+			if offset, ok := tracker.complete(m); ok {
+				latest[topicPartition{topic: m.msg.Topic, partition: m.msg.Partition}] = offset
+			}
+		}
+
+		add(m)
+	drain:
+		for {
+			select {
+			case m, ok := <-completed:
+				if !ok {
+					break drain
+				}
+				add(m)
+			default:
+				break drain
+			}
+		}
+
+		if len(latest) == 0 {
+			continue
+		}
+
+		msgs := make([]kafka.Message, 0, len(latest))
+		for tp, offset := range latest {
+			msgs = append(msgs, kafka.Message{Topic: tp.topic, Partition: tp.partition, Offset: offset})
+		}
+
+		ctx, cancelCommit := context.WithTimeout(context.Background(), commitTimeout)
+		if commitErr := c.reader.CommitMessages(ctx, msgs...); commitErr != nil {
+			err = fmt.Errorf("failed to commit message: %w", commitErr)
+			cancel()
+		}
+		cancelCommit()
+	}
+
+	return err
+}
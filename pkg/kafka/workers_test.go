@@ -0,0 +1,190 @@
+package kafka
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/segmentio/kafka-go"
+)
+
+func TestOffsetTracker(t *testing.T) {
+	tracker := newOffsetTracker()
+	msg := func(partition int, offset int64) kafka.Message {
+		return kafka.Message{Topic: "comments.created", Partition: partition, Offset: offset}
+	}
+
+	gen := make(map[int64]int)
+	for _, offset := range []int64{10, 11, 13} {
+		gen[offset] = tracker.track(msg(0, offset))
+	}
+	tracker.track(msg(1, 5))
+
+	if _, ok := tracker.complete(trackedMessage{msg: msg(0, 11), generation: gen[11]}); ok {
+		t.Fatal("expected no commit while offset 10 is in progress")
+	}
+	if _, ok := tracker.complete(trackedMessage{msg: msg(0, 13), generation: gen[13]}); ok {
+		t.Fatal("expected no commit while offset 10 is in progress")
+	}
+	if offset, ok := tracker.complete(trackedMessage{msg: msg(0, 10), generation: gen[10]}); !ok || offset != 13 {
+		t.Fatalf("expected commit up to 13, got %d %v", offset, ok)
+	}
+	if offset, ok := tracker.complete(trackedMessage{msg: msg(1, 5)}); !ok || offset != 5 {
+		t.Fatalf("expected commit up to 5 in partition 1, got %d %v", offset, ok)
+	}
+
+	// Повторное получение после перебалансировки
+	old := tracker.track(msg(0, 14))
+	regen := tracker.track(msg(0, 12))
+	if regen == old {
+		t.Fatal("expected new generation after redelivery")
+	}
+	if _, ok := tracker.complete(trackedMessage{msg: msg(0, 14), generation: old}); ok {
+		t.Fatal("expected completion of previous generation to be ignored")
+	}
+	if offset, ok := tracker.complete(trackedMessage{msg: msg(0, 12), generation: regen}); !ok || offset != 12 {
+		t.Fatalf("expected commit up to 12, got %d %v", offset, ok)
+	}
+}
+
+// syncReader реализует интерфейс messageReader для тестирования параллельной обработки
+type syncReader struct {
+	mu        sync.Mutex
+	messages  []kafka.Message
+	committed map[int]int64
+}
+
+func (r *syncReader) FetchMessage(ctx context.Context) (kafka.Message, error) {
+	r.mu.Lock()
+	if len(r.messages) > 0 {
+		msg := r.messages[0]
+		r.messages = r.messages[1:]
+		r.mu.Unlock()
+		return msg, nil
+	}
+	r.mu.Unlock()
+
+	<-ctx.Done()
+	return kafka.Message{}, ctx.Err()
+}
+
+func (r *syncReader) CommitMessages(ctx context.Context, msgs ...kafka.Message) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for _, msg := range msgs {
+		if msg.Offset < r.committed[msg.Partition] {
+			panic("committed offset moved back")
+		}
+		r.committed[msg.Partition] = msg.Offset
+	}
+	return nil
+}
+
+func (r *syncReader) Close() error { return nil }
+
+func (r *syncReader) committedOffset(partition int) (int64, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	offset, ok := r.committed[partition]
+	return offset, ok
+}
+
+// orderedProcessor записывает порядок обработки сообщений по ключам и задерживает сообщения
+// ключа blocked до закрытия release
+type orderedProcessor struct {
+	mu      sync.Mutex
+	order   map[string][]int64
+	blocked string
+	release chan struct{}
+}
+
+func (p *orderedProcessor) Execute(ctx context.Context, msg kafka.Message) error {
+	if string(msg.Key) == p.blocked {
+		<-p.release
+	}
+	time.Sleep(time.Duration(msg.Offset%3) * time.Millisecond)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.order[string(msg.Key)] = append(p.order[string(msg.Key)], msg.Offset)
+	return nil
+}
+
+func waitFor(t *testing.T, cond func() bool) {
+	t.Helper()
+
+	deadline := time.Now().Add(2 * time.Second)
+	for !cond() {
+		if time.Now().After(deadline) {
+			t.Fatal("condition was not met in time")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+}
+
+func TestConsumer_StartWorkers(t *testing.T) {
+	var messages []kafka.Message
+	for i := int64(0); i < 60; i++ {
+		messages = append(
+			messages, kafka.Message{
+				Topic:     "comments.created",
+				Partition: int(i % 5 % 2),
+				Offset:    i,
+				Key:       []byte(strconv.FormatInt(i%5, 10)),
+				Value:     []byte(`{}`),
+			},
+		)
+	}
+
+	reader := &syncReader{messages: messages, committed: make(map[int]int64)}
+	handler := &orderedProcessor{order: make(map[string][]int64), blocked: "3", release: make(chan struct{})}
+	c := newTestConsumer(handler, WithWorkers(4))
+	c.reader = reader
+
+	ctx, cancel := context.WithCancel(context.Background())
+	errCh := make(chan error, 1)
+	go func() { errCh <- c.Start(ctx) }()
+
+	// Ключ определяет партицию. Сообщение 3 (ключ "3", партиция 1) не обработано:
+	// смещения партиции 1 подтверждаются только до 1, партиции 0 - полностью
+	waitFor(
+		t, func() bool {
+			first, _ := reader.committedOffset(0)
+			second, _ := reader.committedOffset(1)
+			return first == 59 && second == 1
+		},
+	)
+	time.Sleep(20 * time.Millisecond)
+	if offset, _ := reader.committedOffset(1); offset != 1 {
+		t.Fatalf("expected partition 1 committed up to 1, got %d", offset)
+	}
+
+	close(handler.release)
+	waitFor(
+		t, func() bool {
+			offset, _ := reader.committedOffset(1)
+			return offset == 58
+		},
+	)
+
+	cancel()
+	if err := <-errCh; err != nil {
+		t.Fatalf("Start() error = %v", err)
+	}
+
+	for key, offsets := range handler.order {
+		if len(offsets) != 12 {
+			t.Errorf("key %s: expected 12 messages, got %d", key, len(offsets))
+		}
+		for i := 1; i < len(offsets); i++ {
+			if offsets[i] < offsets[i-1] {
+				t.Errorf("key %s: messages processed out of order: %v", key, offsets)
+				break
+			}
+		}
+	}
+}
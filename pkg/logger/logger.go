@@ -13,6 +13,24 @@ import (
 var log zerolog.Logger
 var once sync.Once
 
+// contextKey - тип ключей контекста пакета: они не совпадают с ключами других пакетов.
+type contextKey string
+
+// RequestIDKey - ключ request_id в контексте запроса и в Locals Fiber, под которым его сохраняет
+// middleware.RequestIDMiddleware.
+const RequestIDKey contextKey = "request_id"
+
+// WithRequestID возвращает копию ctx с request_id.
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, RequestIDKey, requestID)
+}
+
+// RequestID возвращает request_id из контекста или пустую строку, если его нет.
+func RequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(RequestIDKey).(string)
+	return requestID
+}
+
 // InitLogger инициализирует глобальный логгер.
 func InitLogger(serviceName string) {
 	once.Do(
@@ -44,7 +62,7 @@ func GetLogger() *zerolog.Logger {
 func WithContext(ctx context.Context) zerolog.Logger {
 	logCtx := log.With()
 
-	if requestID, ok := ctx.Value("request_id").(string); ok && requestID != "" {
+	if requestID := RequestID(ctx); requestID != "" {
 		logCtx = logCtx.Str("request_id", requestID)
 	}
 
@@ -61,12 +79,12 @@ func GetLoggerWithContext(ctx context.Context) *zerolog.Logger {
 
 // LogRequest логирует начало и завершение обработки запроса.
 func LogRequest(log zerolog.Logger, ctx context.Context, protocol, method, path string) (context.Context, func()) {
-	requestID, _ := ctx.Value("request_id").(string)
+	requestID := RequestID(ctx)
 	if requestID == "" {
 		requestID = "req-" + uuid.New().String()
 	}
 
-	ctx = context.WithValue(ctx, "request_id", requestID)
+	ctx = WithRequestID(ctx, requestID)
 
 	// Логирование начала обработки запроса
 	log.Info().
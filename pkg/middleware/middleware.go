@@ -1,7 +1,6 @@
 package middleware
 
 import (
-	"context"
 	"fmt"
 	"github.com/ee-crocush/go-news/pkg/logger"
 	"github.com/gofiber/fiber/v2"
@@ -15,7 +14,7 @@ func LoggingMiddleware(log zerolog.Logger) fiber.Handler {
 		ip := c.IP()
 
 		ctx, done := logger.LogRequest(
-			log, c.Context(), "http", c.Method(), c.Path(),
+			log, c.UserContext(), "http", c.Method(), c.Path(),
 		)
 		c.SetUserContext(ctx)
 
@@ -26,7 +25,7 @@ func LoggingMiddleware(log zerolog.Logger) fiber.Handler {
 		queryParams := c.Queries()
 
 		evt := log.With().
-			Str("request_id", ctx.Value("request_id").(string)).
+			Str("request_id", logger.RequestID(ctx)).
 			Int("status_code", status).
 			Str("ip", ip).
 			Str("method", c.Method()).
@@ -57,19 +56,19 @@ func LoggingMiddleware(log zerolog.Logger) fiber.Handler {
 	}
 }
 
-// RequestIDMiddleware генерирует и добавляет request_id.
+// RequestIDMiddleware генерирует и добавляет request_id. Он сохраняется под ключом logger.RequestIDKey
+// в Locals и в пользовательском контексте запроса, поэтому доступен и через c.Context(), и через c.UserContext().
 func RequestIDMiddleware() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		requestID := c.Get("X-Request-ID", uuid.New().String())
-		if existingID := c.Locals("request_id"); existingID != nil {
-			requestID = existingID.(string)
+		if existingID, ok := c.Locals(logger.RequestIDKey).(string); ok {
+			requestID = existingID
 		}
 		c.Set("X-Request-ID", requestID)
-		c.Locals("request_id", requestID)
+		c.Locals(logger.RequestIDKey, requestID)
 
 		// Добавляем request_id в контекст Go
-		ctx := context.WithValue(c.Context(), "request_id", requestID)
-		c.SetUserContext(ctx)
+		c.SetUserContext(logger.WithRequestID(c.UserContext(), requestID))
 
 		return c.Next()
 	}
@@ -80,7 +79,7 @@ func ErrorHandlerMiddleware() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		defer func() {
 			if err := recover(); err != nil {
-				requestID, _ := c.Locals("request_id").(string)
+				requestID, _ := c.Locals(logger.RequestIDKey).(string)
 
 				logger.GetLogger().Error().
 					Str("request_id", requestID).
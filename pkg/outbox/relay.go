@@ -0,0 +1,312 @@
+// Package outbox публикует события, сохраненные в outbox в той же транзакции, что и изменения данных.
+// Доставка - не менее одного раза: после сбоя между отправкой и отметкой событие отправляется повторно,
+// поэтому получатели должны обрабатывать повторы.
+package outbox
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ee-crocush/go-news/pkg/kafka"
+	"github.com/ee-crocush/go-news/pkg/logger"
+
+	kafkago "github.com/segmentio/kafka-go"
+)
+
+// ErrAlreadyStarted представляет ошибку повторного запуска relay.
+var ErrAlreadyStarted = errors.New("outbox relay already started")
+
+// Message - событие outbox, ожидающее отправки.
+type Message struct {
+	ID      string
+	Key     string
+	Payload []byte
+	// Headers - заголовки сообщения, сохраненные вместе с событием (см. ContextHeaders).
+	Headers map[string]string
+	// Attempts - количество неудачных попыток отправки.
+	Attempts int
+}
+
+// ContextHeaders возвращает заголовки сообщения из контекста, в котором записывается событие:
+// request_id запроса, чтобы relay отправил событие с тем же x-request-id. Возвращает nil, если
+// заголовков нет.
+func ContextHeaders(ctx context.Context) map[string]string {
+	requestID := logger.RequestID(ctx)
+	if requestID == "" {
+		return nil
+	}
+
+	return map[string]string{kafka.HeaderRequestID: requestID}
+}
+
+// record возвращает сообщение Kafka для события, заголовки упорядочены по имени.
+func (m Message) record() kafka.Record {
+	record := kafka.Record{Key: m.Key, Value: m.Payload}
+	for name, value := range m.Headers {
+		record.Headers = append(record.Headers, kafkago.Header{Key: name, Value: []byte(value)})
+	}
+	slices.SortFunc(
+		record.Headers, func(a, b kafkago.Header) int {
+			return strings.Compare(a.Key, b.Key)
+		},
+	)
+
+	return record
+}
+
+// Store — интерфейс хранилища outbox.
+type Store interface {
+	// Claim захватывает до limit готовых к отправке событий, старые первыми. Захваченные события
+	// не выдаются повторно в течение lease, поэтому несколько relay не отправляют их одновременно.
+	Claim(ctx context.Context, limit int, lease time.Duration) ([]Message, error)
+	// MarkSent отмечает события ids отправленными.
+	MarkSent(ctx context.Context, ids []string) error
+	// MarkFailed увеличивает счетчик попыток событий ids и откладывает их следующую отправку до retryAt.
+	MarkFailed(ctx context.Context, ids []string, retryAt time.Time, reason string) error
+}
+
+// Cleaner — необязательный интерфейс хранилища, удаляющего отправленные события. Хранилища
+// с собственным механизмом удаления (например, TTL-индексом) его не реализуют.
+type Cleaner interface {
+	// DeleteSent удаляет события, отправленные раньше before, и возвращает их количество.
+	DeleteSent(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Publisher — интерфейс отправки пакета событий.
+type Publisher interface {
+	PublishBatch(ctx context.Context, records []kafka.Record) error
+}
+
+// Config - параметры relay. Нулевые значения заменяются значениями по умолчанию.
+type Config struct {
+	// Interval - период проверки outbox.
+	Interval time.Duration
+	// BatchSize - максимальное количество событий за одну проверку.
+	BatchSize int
+	// Lease - время, на которое захватываются события.
+	Lease time.Duration
+	// MinBackoff и MaxBackoff - границы экспоненциальной задержки повторной отправки.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// Retention - срок хранения отправленных событий, если хранилище реализует Cleaner.
+	Retention time.Duration
+}
+
+const (
+	defaultInterval   = time.Second
+	defaultBatchSize  = 100
+	defaultLease      = 30 * time.Second
+	defaultMinBackoff = time.Second
+	defaultMaxBackoff = 5 * time.Minute
+	defaultRetention  = 72 * time.Hour
+	// cleanupInterval - период удаления отправленных событий.
+	cleanupInterval = time.Hour
+)
+
+// Relay периодически отправляет события из outbox: захваченные события отправляются одним пакетом.
+// После ошибки отправки весь пакет откладывается с экспоненциальной задержкой по наибольшему числу
+// попыток его событий, а проверка прерывается до следующего периода: брокер, скорее всего, недоступен.
+type Relay struct {
+	store     Store
+	publisher Publisher
+	cfg       Config
+
+	mu      sync.Mutex
+	started bool
+
+	wake   chan struct{}
+	ctx    context.Context
+	cancel context.CancelFunc
+	done   chan struct{}
+
+	// cleaned - время последнего удаления отправленных событий.
+	cleaned time.Time
+	now     func() time.Time
+}
+
+// NewRelay создает новый relay.
+func NewRelay(store Store, publisher Publisher, cfg Config) *Relay {
+	if cfg.Interval <= 0 {
+		cfg.Interval = defaultInterval
+	}
+	if cfg.BatchSize <= 0 {
+		cfg.BatchSize = defaultBatchSize
+	}
+	if cfg.Lease <= 0 {
+		cfg.Lease = defaultLease
+	}
+	if cfg.MinBackoff <= 0 {
+		cfg.MinBackoff = defaultMinBackoff
+	}
+	if cfg.MaxBackoff < cfg.MinBackoff {
+		cfg.MaxBackoff = max(defaultMaxBackoff, cfg.MinBackoff)
+	}
+	if cfg.Retention <= 0 {
+		cfg.Retention = defaultRetention
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+
+	return &Relay{
+		store:     store,
+		publisher: publisher,
+		cfg:       cfg,
+		wake:      make(chan struct{}, 1),
+		ctx:       ctx,
+		cancel:    cancel,
+		done:      make(chan struct{}),
+		now:       time.Now,
+	}
+}
+
+// Start запускает relay и блокируется до вызова Shutdown.
+func (r *Relay) Start() error {
+	r.mu.Lock()
+	if r.started {
+		r.mu.Unlock()
+		return ErrAlreadyStarted
+	}
+	r.started = true
+	r.mu.Unlock()
+
+	defer close(r.done)
+
+	ticker := time.NewTicker(r.cfg.Interval)
+	defer ticker.Stop()
+
+	for {
+		// Полный пакет - вероятно, событий больше: следующая проверка сразу.
+		for r.ctx.Err() == nil {
+			if r.RelayOnce(r.ctx) < r.cfg.BatchSize {
+				break
+			}
+		}
+		r.cleanup(r.ctx)
+
+		select {
+		case <-r.ctx.Done():
+			return nil
+		case <-ticker.C:
+		case <-r.wake:
+		}
+	}
+}
+
+// Shutdown останавливает relay, ожидая завершения текущей отправки, пока не истечет ctx.
+func (r *Relay) Shutdown(ctx context.Context) error {
+	r.cancel()
+
+	r.mu.Lock()
+	started := r.started
+	r.mu.Unlock()
+
+	if !started {
+		return nil
+	}
+
+	select {
+	case <-r.done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Notify запускает проверку outbox, не дожидаясь периода.
+func (r *Relay) Notify() {
+	select {
+	case r.wake <- struct{}{}:
+	default:
+	}
+}
+
+// RelayOnce отправляет один пакет событий и возвращает количество отправленных.
+func (r *Relay) RelayOnce(ctx context.Context) int {
+	log := logger.GetLogger()
+
+	messages, err := r.store.Claim(ctx, r.cfg.BatchSize, r.cfg.Lease)
+	if err != nil {
+		if ctx.Err() == nil {
+			log.Err(err).Msg("Failed to claim outbox messages")
+		}
+		return 0
+	}
+
+	if len(messages) == 0 {
+		return 0
+	}
+
+	ids := make([]string, 0, len(messages))
+	records := make([]kafka.Record, 0, len(messages))
+	attempts := 0
+	for _, msg := range messages {
+		ids = append(ids, msg.ID)
+		records = append(records, msg.record())
+		attempts = max(attempts, msg.Attempts+1)
+	}
+
+	// Отмечаем без отмененного ctx, чтобы при остановке не ждать истечения lease.
+	markCtx := context.WithoutCancel(ctx)
+
+	if err = r.publisher.PublishBatch(ctx, records); err != nil {
+		// Часть пакета могла быть записана: такие события будут отправлены повторно.
+		retryAt := r.now().Add(r.backoff(attempts))
+		if markErr := r.store.MarkFailed(markCtx, ids, retryAt, err.Error()); markErr != nil {
+			log.Err(markErr).Strs("ids", ids).Msg("Failed to mark outbox messages as failed")
+		}
+		log.Warn().
+			Err(err).
+			Int("messages", len(ids)).
+			Int("attempts", attempts).
+			Time("retry_at", retryAt).
+			Msg("Failed to publish outbox messages")
+		return 0
+	}
+
+	if err = r.store.MarkSent(markCtx, ids); err != nil {
+		// События будут отправлены повторно после истечения lease.
+		log.Err(err).Strs("ids", ids).Msg("Failed to mark outbox messages as sent")
+		return 0
+	}
+
+	return len(ids)
+}
+
+// cleanup удаляет отправленные события старше Retention не чаще раза в cleanupInterval.
+func (r *Relay) cleanup(ctx context.Context) {
+	cleaner, ok := r.store.(Cleaner)
+	if !ok {
+		return
+	}
+
+	now := r.now()
+	if now.Sub(r.cleaned) < cleanupInterval {
+		return
+	}
+	r.cleaned = now
+
+	deleted, err := cleaner.DeleteSent(ctx, now.Add(-r.cfg.Retention))
+	if err != nil {
+		if ctx.Err() == nil {
+			logger.GetLogger().Err(err).Msg("Failed to delete sent outbox messages")
+		}
+		return
+	}
+	if deleted > 0 {
+		logger.GetLogger().Info().Int64("deleted", deleted).Msg("Sent outbox messages deleted")
+	}
+}
+
+// backoff возвращает задержку перед попыткой attempt (с 1): MinBackoff, затем удваивается до MaxBackoff.
+func (r *Relay) backoff(attempt int) time.Duration {
+	delay := r.cfg.MinBackoff
+	for i := 1; i < attempt && delay < r.cfg.MaxBackoff; i++ {
+		delay *= 2
+	}
+
+	return min(delay, r.cfg.MaxBackoff)
+}
@@ -0,0 +1,237 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ee-crocush/go-news/pkg/kafka"
+	"github.com/ee-crocush/go-news/pkg/logger"
+)
+
+// mockStore реализует интерфейс Store для тестирования
+type mockStore struct {
+	mu       sync.Mutex
+	pending  []Message
+	sent     []string
+	failed   map[string]time.Time
+	claimErr error
+}
+
+func (m *mockStore) Claim(ctx context.Context, limit int, lease time.Duration) ([]Message, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.claimErr != nil {
+		return nil, m.claimErr
+	}
+
+	n := min(limit, len(m.pending))
+	claimed := m.pending[:n]
+	m.pending = m.pending[n:]
+
+	return claimed, nil
+}
+
+func (m *mockStore) MarkSent(ctx context.Context, ids []string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.sent = append(m.sent, ids...)
+	return nil
+}
+
+func (m *mockStore) MarkFailed(ctx context.Context, ids []string, retryAt time.Time, reason string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	if m.failed == nil {
+		m.failed = make(map[string]time.Time)
+	}
+	for _, id := range ids {
+		m.failed[id] = retryAt
+	}
+	return nil
+}
+
+// mockPublisher реализует интерфейс Publisher для тестирования
+type mockPublisher struct {
+	mu      sync.Mutex
+	keys    []string
+	records []kafka.Record
+	fail    map[string]bool
+	batches int
+}
+
+func (m *mockPublisher) PublishBatch(ctx context.Context, records []kafka.Record) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	m.batches++
+	for _, record := range records {
+		if m.fail[record.Key] {
+			return errors.New("broker unavailable")
+		}
+		m.keys = append(m.keys, record.Key)
+		m.records = append(m.records, record)
+	}
+	return nil
+}
+
+func TestRelay_RelayOnce(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+
+	t.Run(
+		"publishes and marks sent", func(t *testing.T) {
+			store := &mockStore{pending: []Message{{ID: "1", Key: "a"}, {ID: "2", Key: "b"}, {ID: "3", Key: "c"}}}
+			publisher := &mockPublisher{}
+			relay := NewRelay(store, publisher, Config{BatchSize: 2})
+
+			if sent := relay.RelayOnce(context.Background()); sent != 2 {
+				t.Fatalf("expected 2 sent, got %d", sent)
+			}
+			if sent := relay.RelayOnce(context.Background()); sent != 1 {
+				t.Fatalf("expected 1 sent, got %d", sent)
+			}
+			if len(store.sent) != 3 || store.sent[0] != "1" || store.sent[2] != "3" {
+				t.Errorf("unexpected sent messages: %v", store.sent)
+			}
+			if publisher.batches != 2 {
+				t.Errorf("expected one publish call per claimed batch, got %d", publisher.batches)
+			}
+		},
+	)
+
+	t.Run(
+		"failure postpones whole batch", func(t *testing.T) {
+			store := &mockStore{
+				pending: []Message{{ID: "1", Key: "a"}, {ID: "2", Key: "b", Attempts: 2}, {ID: "3", Key: "c"}},
+			}
+			publisher := &mockPublisher{fail: map[string]bool{"b": true}}
+			relay := NewRelay(store, publisher, Config{MinBackoff: time.Second, MaxBackoff: time.Minute})
+			relay.now = func() time.Time { return now }
+
+			if sent := relay.RelayOnce(context.Background()); sent != 0 {
+				t.Fatalf("expected 0 sent, got %d", sent)
+			}
+			if len(store.sent) != 0 {
+				t.Errorf("expected no messages marked sent, got %v", store.sent)
+			}
+			// Задержка считается по наибольшему числу попыток в пакете.
+			if len(store.failed) != 3 {
+				t.Fatalf("expected all 3 messages postponed, got %v", store.failed)
+			}
+			for id, retryAt := range store.failed {
+				if !retryAt.Equal(now.Add(4 * time.Second)) {
+					t.Errorf("message %s: expected retry in 4s, got %v", id, retryAt)
+				}
+			}
+		},
+	)
+
+	t.Run(
+		"publishes stored headers", func(t *testing.T) {
+			headers := ContextHeaders(logger.WithRequestID(context.Background(), "req-1"))
+			store := &mockStore{pending: []Message{{ID: "1", Key: "a", Headers: headers}, {ID: "2", Key: "b"}}}
+			publisher := &mockPublisher{}
+
+			if sent := NewRelay(store, publisher, Config{}).RelayOnce(context.Background()); sent != 2 {
+				t.Fatalf("expected 2 sent, got %d", sent)
+			}
+
+			first := publisher.records[0].Headers
+			if len(first) != 1 || first[0].Key != kafka.HeaderRequestID || string(first[0].Value) != "req-1" {
+				t.Errorf("expected request id header, got %v", first)
+			}
+			if len(publisher.records[1].Headers) != 0 {
+				t.Errorf("expected no headers, got %v", publisher.records[1].Headers)
+			}
+		},
+	)
+
+	t.Run(
+		"claim error", func(t *testing.T) {
+			store := &mockStore{claimErr: errors.New("database error")}
+			if sent := NewRelay(store, &mockPublisher{}, Config{}).RelayOnce(context.Background()); sent != 0 {
+				t.Errorf("expected 0 sent, got %d", sent)
+			}
+		},
+	)
+}
+
+func TestRelay_Backoff(t *testing.T) {
+	relay := NewRelay(&mockStore{}, &mockPublisher{}, Config{MinBackoff: time.Second, MaxBackoff: 10 * time.Second})
+
+	want := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 8 * time.Second, 10 * time.Second}
+	for i, w := range want {
+		if got := relay.backoff(i + 1); got != w {
+			t.Errorf("backoff(%d) = %s, want %s", i+1, got, w)
+		}
+	}
+	if got := relay.backoff(100); got != 10*time.Second {
+		t.Errorf("backoff(100) = %s, want 10s", got)
+	}
+}
+
+func TestRelay_StartShutdown(t *testing.T) {
+	store := &mockStore{pending: []Message{{ID: "1", Key: "a"}}}
+	relay := NewRelay(store, &mockPublisher{}, Config{Interval: time.Hour})
+
+	errCh := make(chan error, 1)
+	go func() { errCh <- relay.Start() }()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		store.mu.Lock()
+		sent := len(store.sent)
+		store.mu.Unlock()
+		if sent == 1 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("message was not relayed on start")
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	if err := relay.Shutdown(ctx); err != nil {
+		t.Fatalf("Shutdown() error = %v", err)
+	}
+	if err := <-errCh; err != nil {
+		t.Errorf("Start() error = %v", err)
+	}
+}
+
+// mockCleanerStore реализует интерфейсы Store и Cleaner для тестирования
+type mockCleanerStore struct {
+	mockStore
+	before []time.Time
+}
+
+func (m *mockCleanerStore) DeleteSent(ctx context.Context, before time.Time) (int64, error) {
+	m.before = append(m.before, before)
+	return 1, nil
+}
+
+func TestRelay_Cleanup(t *testing.T) {
+	now := time.Date(2025, 1, 1, 10, 0, 0, 0, time.UTC)
+	store := &mockCleanerStore{}
+	relay := NewRelay(store, &mockPublisher{}, Config{Retention: 24 * time.Hour})
+	relay.now = func() time.Time { return now }
+
+	relay.cleanup(context.Background())
+	relay.cleanup(context.Background())
+	if len(store.before) != 1 || !store.before[0].Equal(now.Add(-24*time.Hour)) {
+		t.Fatalf("expected one cleanup of messages before %v, got %v", now.Add(-24*time.Hour), store.before)
+	}
+
+	now = now.Add(cleanupInterval)
+	relay.cleanup(context.Background())
+	if len(store.before) != 2 {
+		t.Errorf("expected cleanup after %s, got %d cleanups", cleanupInterval, len(store.before))
+	}
+}
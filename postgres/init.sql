@@ -15,4 +15,19 @@ CREATE TABLE comments (
     pub_time INTEGER,
     created_at INTEGER NOT NULL DEFAULT 0,
     status comment_status NOT NULL DEFAULT 'pending'
-);
\ No newline at end of file
+);
+DROP TABLE IF EXISTS outbox;
+CREATE TABLE outbox (
+    id BIGSERIAL PRIMARY KEY,
+    event_type TEXT NOT NULL,
+    event_key TEXT NOT NULL,
+    payload JSONB NOT NULL,
+    headers JSONB NOT NULL DEFAULT '{}',
+    attempts INT NOT NULL DEFAULT 0,
+    created_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    next_attempt_at TIMESTAMPTZ NOT NULL DEFAULT now(),
+    sent_at TIMESTAMPTZ,
+    last_error TEXT
+);
+CREATE INDEX outbox_pending_idx ON outbox (event_type, next_attempt_at, id) WHERE sent_at IS NULL;
+CREATE INDEX outbox_sent_idx ON outbox (sent_at) WHERE sent_at IS NOT NULL;